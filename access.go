@@ -0,0 +1,198 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TAccessFunc()` is called by a `TSectionList` whenever a key
+	// is looked up.
+	//
+	// see `OnRead()`, `OnMiss()`
+	TAccessFunc func(aSection, aKey string)
+
+	// `tReadKeys` is the set of section/key pairs read so far.
+	tReadKeys struct {
+		keys map[string]struct{}
+		mtx  sync.Mutex
+	}
+)
+
+// `add()` records that `aKey` in `aSection` was read.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+func (rk *tReadKeys) add(aSection, aKey string) {
+	if nil == rk {
+		return
+	}
+	id := originID(aSection, aKey)
+
+	rk.mtx.Lock()
+	defer rk.mtx.Unlock()
+
+	if nil == rk.keys {
+		rk.keys = make(map[string]struct{})
+	}
+	rk.keys[id] = struct{}{}
+} // add()
+
+// `has()` returns whether `aKey` in `aSection` was read.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `bool`: `true` if the key was read, `false` otherwise.
+func (rk *tReadKeys) has(aSection, aKey string) bool {
+	if nil == rk {
+		return false
+	}
+	rk.mtx.Lock()
+	defer rk.mtx.Unlock()
+
+	_, ok := rk.keys[originID(aSection, aKey)]
+
+	return ok
+} // has()
+
+// `noteAccess()` calls the list's access hooks (if any) for the lookup
+// of `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section looked up.
+// - `aKey` The name of the key looked up.
+// - `aErr` The lookup's error (`nil` if the key was found).
+func (sl *TSectionList) noteAccess(aSection, aKey string, aErr error) {
+	hook := sl.onRead
+	if nil != aErr {
+		hook = sl.onMiss
+	}
+	if nil == hook {
+		return
+	}
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+
+	hook(aSection, strings.TrimSpace(aKey))
+} // noteAccess()
+
+// `OnMiss()` sets the function to call whenever a key looked up by one
+// of the list's `AsXxx()` or `GetXxx()` methods doesn't exist.
+//
+// This allows operators to find lookups failing in production, e.g. by
+// logging them or counting them in an `expvar.Map`. The function is
+// called synchronously and should return quickly. Passing `nil` removes
+// a previously set function.
+//
+// Parameters:
+// - `aFunc` The function to call on failing lookups.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) OnMiss(aFunc TAccessFunc) *TSectionList {
+	sl.onMiss = aFunc
+
+	return sl
+} // OnMiss()
+
+// `OnRead()` sets the function to call whenever a key is successfully
+// looked up by one of the list's `AsXxx()` or `GetXxx()` methods.
+//
+// This allows operators to find the keys actually used, e.g. by
+// counting them in an `expvar.Map`:
+//
+//	reads := expvar.NewMap("config_reads")
+//	sl.OnRead(func(aSection, aKey string) {
+//		reads.Add(aSection+"."+aKey, 1)
+//	})
+//
+// The function is called synchronously and should return quickly.
+// Passing `nil` removes a previously set function.
+//
+// Parameters:
+// - `aFunc` The function to call on successful lookups.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) OnRead(aFunc TAccessFunc) *TSectionList {
+	sl.onRead = aFunc
+
+	return sl
+} // OnRead()
+
+// `SetTrackReads()` determines whether the list records the keys read
+// by its `AsXxx()` and `GetXxx()` methods (see `UnreadKeys()`).
+//
+// The tracking is disabled by default so that the accessors don't
+// need to synchronise their lookups. Disabling the tracking discards
+// the keys recorded so far.
+//
+// Parameters:
+// - `aTrack` Whether to record the keys read.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetTrackReads(aTrack bool) *TSectionList {
+	if !aTrack {
+		sl.readKeys = nil
+	} else if nil == sl.readKeys {
+		sl.readKeys = &tReadKeys{}
+	}
+
+	return sl
+} // SetTrackReads()
+
+// `UnreadKeys()` returns the keys which were never read by one of the
+// list's `AsXxx()` or `GetXxx()` methods, in the order of the sections
+// and keys in the INI file.
+//
+// Logged e.g. at the application's shutdown this helps to find typos
+// and stale settings in the INI file:
+//
+//	sl.SetTrackReads(true)
+//	defer func() {
+//		for _, sk := range sl.UnreadKeys() {
+//			log.Printf("unused setting: [%s] %s", sk.Section, sk.Key)
+//		}
+//	}()
+//
+// Keys read by their deprecated name (see `RegisterAlias()`) or by the
+// default section's fallback (see `SetDefaultFallback()`) count as
+// read while the list's own lookups (e.g. by `Freeze()` or
+// `Validate()`) don't.
+//
+// Returns:
+// - `[]TSectionKey`: The list of keys never read, or `nil` if the
+// tracking isn't enabled by `SetTrackReads()`.
+func (sl *TSectionList) UnreadKeys() []TSectionKey {
+	if nil == sl.readKeys {
+		return nil
+	}
+
+	var result []TSectionKey
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		for _, key := range kl.Keys() {
+			if !sl.readKeys.has(name, key) {
+				result = append(result, TSectionKey{name, key})
+			}
+		}
+	}
+
+	return result
+} // UnreadKeys()
+
+/* _EoF_ */
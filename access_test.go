@@ -0,0 +1,93 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"slices"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_OnRead(t *testing.T) {
+	var reads, misses []string
+	sl := prepSectionList().
+		OnRead(func(aSection, aKey string) {
+			reads = append(reads, aSection+"."+aKey)
+		}).
+		OnMiss(func(aSection, aKey string) {
+			misses = append(misses, aSection+"."+aKey)
+		})
+
+	sl.AsString("", "key0")
+	sl.AsInt("s3", " int ")
+	sl.AsFloat64("s2", "float")
+	sl.AsBool("s1", "n.a.")
+	_, _ = sl.GetString("n.a.", "key")
+
+	if want := []string{DefSection + ".key0", "s3.int", "s2.float"}; !slices.Equal(reads, want) {
+		t.Errorf("TSectionList.OnRead() = %v, want %v", reads, want)
+	}
+	if want := []string{"s1.n.a.", "n.a..key"}; !slices.Equal(misses, want) {
+		t.Errorf("TSectionList.OnMiss() = %v, want %v", misses, want)
+	}
+
+	// the list's own lookups aren't reported:
+	sl.SetValidator("s3", "int", func(string) error { return nil })
+	_ = sl.Freeze()
+	_ = sl.Validate()
+	_ = sl.Find("*/*")
+	if (3 != len(reads)) || (2 != len(misses)) {
+		t.Errorf("internal lookups were reported: %v, %v", reads, misses)
+	}
+
+	// removing the hooks:
+	sl.OnRead(nil).OnMiss(nil)
+	sl.AsString("", "key0")
+	sl.AsString("", "n.a.")
+	if (3 != len(reads)) || (2 != len(misses)) {
+		t.Error("removed hooks were called")
+	}
+} // TestTSectionList_OnRead()
+
+func TestTSectionList_UnreadKeys(t *testing.T) {
+	sl := prepSectionList().SetDefaultFallback(true).
+		RegisterAlias("s4", "uint", "s4", "count")
+	sl.AddSectionKey("s1", "int", "1")
+	if got := sl.UnreadKeys(); nil != got {
+		t.Errorf("TSectionList.UnreadKeys() = %v, want nil w/o tracking", got)
+	}
+	sl.SetTrackReads(true)
+	sl.SetValidator("s3", "int", func(string) error { return nil })
+	_ = sl.Freeze()
+	_ = sl.Validate()
+
+	want := []TSectionKey{
+		{DefSection, "key0"}, {"s2", "float"}, {"s1", "bool"},
+		{"s1", "int"}, {"s4", "uint"}, {"s3", "int"},
+	}
+	if got := sl.UnreadKeys(); !slices.Equal(got, want) {
+		t.Errorf("TSectionList.UnreadKeys() = %v, want %v", got, want)
+	}
+
+	sl.AsString("s2", "float")
+	sl.AsInt("s1", " int ")
+	sl.AsString("s3", "key0") // by fallback
+	sl.AsInt("s4", "count")   // by alias
+	sl.AsString("s3", "n.a.")
+	want = []TSectionKey{{"s1", "bool"}, {"s3", "int"}}
+	if got := sl.UnreadKeys(); !slices.Equal(got, want) {
+		t.Errorf("TSectionList.UnreadKeys() = %v, want %v", got, want)
+	}
+
+	sl.SetTrackReads(false).SetTrackReads(true)
+	if got := sl.UnreadKeys(); 6 != len(got) {
+		t.Errorf("TSectionList.UnreadKeys() = %v after reset", got)
+	}
+} // TestTSectionList_UnreadKeys()
+
+/* _EoF_ */
@@ -0,0 +1,162 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "strings"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TAliasFunc()` is called whenever a value is read by the
+	// deprecated name `aOld` instead of its new name `aNew`.
+	//
+	// see `OnAlias()`
+	TAliasFunc func(aOld, aNew TSectionKey)
+
+	// `tAliases` maps the new section/key pairs to their old names.
+	tAliases map[string]TSectionKey
+)
+
+// `aliasValue()` returns the value of the deprecated key registered
+// for `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The (new) name of the INI section.
+// - `aKey` The (new) name of the key.
+//
+// Returns:
+// - `string`: The name of the INI section the value was found in.
+// - `string`: The value of the deprecated key.
+// - `bool`: `true` if a deprecated key was found, `false` otherwise.
+func (sl *TSectionList) aliasValue(aSection, aKey string) (string, string, bool) {
+	old, ok := sl.aliases[originID(aSection, aKey)]
+	if !ok {
+		return "", "", false
+	}
+	kl, exists := sl.section(old.Section)
+	if !exists {
+		return "", "", false
+	}
+	value, exists := kl.AsString(old.Key)
+	if !exists {
+		return "", "", false
+	}
+	sl.readKeys.add(old.Section, old.Key)
+	if plain, err := sl.decryptValue(old.Section, old.Key, value); nil == err {
+		value = plain // encrypted for the deprecated key
+	}
+	if nil != sl.onAlias {
+		sl.onAlias(old, TSectionKey{aSection, aKey})
+	}
+
+	return old.Section, value, true
+} // aliasValue()
+
+// `MigrateAliases()` renames all deprecated keys present in the list
+// to their new names (see `RegisterAlias()`) so that the next `Store()`
+// writes the new names only.
+//
+// A deprecated key whose new name already exists is just removed.
+//
+// Returns:
+// - `int`: The number of deprecated keys migrated.
+func (sl *TSectionList) MigrateAliases() (rCount int) {
+	if sl.readOnly {
+		return
+	}
+	for id, old := range sl.aliases {
+		oldList, exists := sl.section(old.Section)
+		if !exists {
+			continue
+		}
+		value, exists := oldList.AsString(old.Key)
+		if !exists {
+			continue
+		}
+		newSection, newKey, _ := strings.Cut(id, "\x00")
+
+		if newSection == old.Section {
+			if oldList.RenameKey(old.Key, newKey) {
+				if sealed := sl.resealValue(old.Section, old.Key, newSection, newKey, value); sealed != value {
+					oldList.AddKey(newKey, sealed)
+					value = sealed
+				}
+				unlock := sl.lock()
+				sl.dropOrigin(old.Section, old.Key)
+				unlock()
+				sl.noteChange(old.Section, old.Key, value, "", true)
+				sl.noteChange(newSection, newKey, "", value, false)
+				rCount++
+				continue
+			}
+		} else if !sl.HasSectionKey(newSection, newKey) {
+			sl.AddSectionKey(newSection, newKey,
+				sl.resealValue(old.Section, old.Key, newSection, newKey, value))
+		}
+		sl.RemoveSectionKey(old.Section, old.Key)
+		rCount++
+	}
+
+	return
+} // MigrateAliases()
+
+// `OnAlias()` sets the function to call whenever a value is read by
+// a deprecated name (see `RegisterAlias()`), e.g. to log a warning.
+//
+// Passing `nil` removes a previously set function.
+//
+// Parameters:
+// - `aFunc` The function to call.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) OnAlias(aFunc TAliasFunc) *TSectionList {
+	sl.onAlias = aFunc
+
+	return sl
+} // OnAlias()
+
+// `RegisterAlias()` registers `aOldKey` in `aOldSection` as the
+// deprecated name of `aNewKey` in `aNewSection`.
+//
+// Looking up the new name falls back to the old one if the new key
+// doesn't exist, so INI files written for an earlier version of an
+// application keep working; see `OnAlias()` to get notified and
+// `MigrateAliases()` to rename the old keys.
+//
+// Parameters:
+// - `aOldSection` The deprecated name of the INI section.
+// - `aOldKey` The deprecated name of the key.
+// - `aNewSection` The current name of the INI section.
+// - `aNewKey` The current name of the key.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) RegisterAlias(aOldSection, aOldKey, aNewSection, aNewKey string) *TSectionList {
+	if aOldSection = strings.TrimSpace(aOldSection); "" == aOldSection {
+		aOldSection = sl.defSect
+	}
+	if aNewSection = strings.TrimSpace(aNewSection); "" == aNewSection {
+		aNewSection = sl.defSect
+	}
+	aOldKey, aNewKey = strings.TrimSpace(aOldKey), strings.TrimSpace(aNewKey)
+	if ("" == aOldKey) || ("" == aNewKey) {
+		return sl
+	}
+	if (aOldSection == aNewSection) && (aOldKey == aNewKey) {
+		return sl
+	}
+
+	if nil == sl.aliases {
+		sl.aliases = make(tAliases)
+	}
+	sl.aliases[originID(aNewSection, aNewKey)] = TSectionKey{aOldSection, aOldKey}
+
+	return sl
+} // RegisterAlias()
+
+/* _EoF_ */
@@ -0,0 +1,88 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepAliasList() *TSectionList {
+	sl := NewSectionList().
+		RegisterAlias("", "port", "server", "port").
+		RegisterAlias("server", "hostname", "server", "host").
+		RegisterAlias("db", "user", "database", "user")
+	src := "port = 8080\n[server]\n; the host\nhostname = localhost\n[db]\nuser = admin\n[database]\n"
+	_, _ = sl.read(sl.newScanner(context.Background(), strings.NewReader(src)))
+
+	return sl
+} // prepAliasList()
+
+func TestTSectionList_RegisterAlias(t *testing.T) {
+	sl := prepAliasList()
+	var warned []TSectionKey
+	sl.OnAlias(func(aOld, aNew TSectionKey) {
+		warned = append(warned, aOld)
+	})
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    string
+		wantOK  bool
+	}{
+		{"1", "server", "port", "8080", true},
+		{"2", "server", "host", "localhost", true},
+		{"3", "database", "user", "admin", true},
+		{"4", "server", "hostname", "localhost", true},
+		{"5", "server", "n.a.", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sl.AsString(tt.section, tt.key)
+			if (got != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("%q: TSectionList.AsString() = %q, %v, want %q, %v",
+					tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+	if 3 != len(warned) {
+		t.Errorf("TSectionList.OnAlias() called %d times, want 3", len(warned))
+	}
+
+	// the new name takes precedence:
+	sl.AddSectionKey("server", "port", "443")
+	if got, _ := sl.AsInt("server", "port"); 443 != got {
+		t.Errorf("TSectionList.AsInt() = %d, want 443", got)
+	}
+} // TestTSectionList_RegisterAlias()
+
+func TestTSectionList_MigrateAliases(t *testing.T) {
+	sl := prepAliasList()
+	sl.AddSectionKey("database", "user", "root")
+
+	if got := sl.MigrateAliases(); 3 != got {
+		t.Errorf("TSectionList.MigrateAliases() = %d, want 3", got)
+	}
+	want := "[Default]\n\n[server]\n; the host\nhost = localhost\nport = 8080\n\n[db]\n\n[database]\nuser = root\n"
+	if got := sl.String(); !strings.Contains(got, want) {
+		t.Errorf("TSectionList.String() =\n%q\nwant\n%q", got, want)
+	}
+	if 0 != sl.MigrateAliases() {
+		t.Error("TSectionList.MigrateAliases() migrated keys twice")
+	}
+	if !sl.IsDirty() {
+		t.Error("TSectionList.IsDirty() = false after migration")
+	}
+} // TestTSectionList_MigrateAliases()
+
+/* _EoF_ */
@@ -0,0 +1,150 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tBinding` is a struct bound to an INI section by
+	// `TManager.BindSection()`.
+	tBinding struct {
+		locker  sync.Locker // protects the target while re-binding
+		section string      // name of the INI section
+		target  any         // pointer to the struct to fill
+	}
+)
+
+// `sectionChanged()` tells whether `aSection` differs between
+// `aOld` and `aNew`.
+//
+// Parameters:
+// - `aOld` The previous list.
+// - `aNew` The new list.
+// - `aSection` The name of the INI section.
+//
+// Returns:
+// - `bool`: `true` if the section was added, removed, or modified.
+func sectionChanged(aOld, aNew *TSectionList, aSection string) bool {
+	oldKL, oldOK := aOld.section(aSection)
+	newKL, newOK := aNew.section(aSection)
+	if oldOK != newOK {
+		return true
+	}
+
+	return newOK && !newKL.CompareTo(oldKL)
+} // sectionChanged()
+
+// `rebind()` fills the binding's target from `aList`.
+//
+// The section is unmarshalled into a copy of the target first, so the
+// target is updated either completely or not at all.
+//
+// Parameters:
+// - `aList` The list to read from.
+//
+// Returns:
+// - `error`: A possible conversion error.
+func (b *tBinding) rebind(aList *TSectionList) error {
+	target := reflect.ValueOf(b.target).Elem()
+	twin := reflect.New(target.Type())
+	twin.Elem().Set(target)
+	if err := aList.BindSection(b.section, twin.Interface()); nil != err {
+		return err
+	}
+
+	if nil != b.locker {
+		b.locker.Lock()
+		defer b.locker.Unlock()
+	}
+	target.Set(twin.Elem())
+
+	return nil
+} // rebind()
+
+// `BindSection()` copies the values of `aSection` into the struct
+// pointed to by `aTarget`.
+//
+// The key names are taken from the fields' `ini:"..."` tags (see
+// `Marshal()`); fields of struct type are not supported. Fields without
+// a corresponding key are left untouched so they can hold default
+// values.
+//
+// Example:
+//
+//	var srv struct {
+//		Host string `ini:"host"`
+//		Port int    `ini:"port"`
+//	}
+//	err := sl.BindSection("server", &srv)
+//
+// Parameters:
+// - `aSection` The name of the INI section (empty: default section).
+// - `aTarget` A pointer to the struct to fill.
+//
+// Returns:
+// - `error`: `ErrInvalidTarget`, `ErrSectionNotFound`, or a conversion error.
+func (sl *TSectionList) BindSection(aSection string, aTarget any) error {
+	sValue := reflect.ValueOf(aTarget)
+	if (reflect.Pointer != sValue.Kind()) || sValue.IsNil() {
+		return ErrInvalidTarget
+	}
+	if sValue = sValue.Elem(); reflect.Struct != sValue.Kind() {
+		return ErrInvalidTarget
+	}
+
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	if _, exists := sl.section(aSection); !exists {
+		return fmt.Errorf("[%s]: %w", aSection, ErrSectionNotFound)
+	}
+
+	return unmarshalSection(sl, aSection, sValue, true)
+} // BindSection()
+
+// `BindSection()` copies the values of `aSection` of the current
+// snapshot into the struct pointed to by `aTarget` and re-binds it
+// whenever a new snapshot with a modified section is published.
+//
+// Re-binding updates the target as a whole while holding `aLocker`
+// (if not `nil`) so that the subsystem owning the target can
+// synchronise its reads by the same lock. A new snapshot which can't
+// be converted leaves the target untouched.
+//
+// Parameters:
+// - `aSection` The name of the INI section (empty: default section).
+// - `aTarget` A pointer to the struct to fill.
+// - `aLocker` The lock protecting `aTarget` (may be `nil`).
+//
+// Returns:
+// - `error`: See `TSectionList.BindSection()`.
+func (m *TManager) BindSection(aSection string, aTarget any, aLocker sync.Locker) error {
+	current := m.Current()
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = current.defSect
+	}
+
+	binding := &tBinding{locker: aLocker, section: aSection, target: aTarget}
+	if err := binding.rebind(current); nil != err {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.binds = append(m.binds, binding)
+
+	return nil
+} // BindSection()
+
+/* _EoF_ */
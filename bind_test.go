@@ -0,0 +1,135 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type tBindServer struct {
+	Host string `ini:"host"`
+	Port int    `ini:"port"`
+}
+
+func TestTSectionList_BindSection(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("server", "host", "localhost")
+	sl.AddSectionKey("server", "port", "8080")
+	sl.AddSectionKey("bad", "port", "eighty")
+
+	tests := []struct {
+		name    string
+		section string
+		target  any
+		want    tBindServer
+		wantErr error
+	}{
+		{"ok", "server", &tBindServer{}, tBindServer{"localhost", 8080}, nil},
+		{"keep defaults", "", &tBindServer{Port: 1}, tBindServer{"", 1}, ErrSectionNotFound},
+		{"invalid value", "bad", &tBindServer{}, tBindServer{}, strconv.ErrSyntax},
+		{"no pointer", "server", tBindServer{}, tBindServer{}, ErrInvalidTarget},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sl.BindSection(tt.section, tt.target)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("BindSection() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got, ok := tt.target.(*tBindServer); ok && (nil == err) && (*got != tt.want) {
+				t.Errorf("BindSection() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_BindSection()
+
+func TestTManager_BindSection(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("server", "host", "localhost")
+	sl.AddSectionKey("server", "port", "8080")
+
+	var (
+		mtx sync.Mutex
+		srv tBindServer
+	)
+	mgr := NewManager(sl)
+	if err := mgr.BindSection("server", &srv, &mtx); nil != err {
+		t.Fatalf("BindSection() error = %v", err)
+	}
+	if 8080 != srv.Port {
+		t.Errorf("BindSection() port = %d, want 8080", srv.Port)
+	}
+
+	next := sl.copyList()
+	next.UpdateSectKeyStr("server", "port", "9090")
+	mgr.Update(next)
+	if want := (tBindServer{"localhost", 9090}); srv != want {
+		t.Errorf("re-bound = %v, want %v", srv, want)
+	}
+
+	// invalid values leave the target untouched
+	bad := next.copyList()
+	bad.UpdateSectKeyStr("server", "host", "example.com")
+	bad.UpdateSectKeyStr("server", "port", "eighty")
+	mgr.Update(bad)
+	if want := (tBindServer{"localhost", 9090}); srv != want {
+		t.Errorf("re-bound = %v, want %v", srv, want)
+	}
+} // TestTManager_BindSection()
+
+func TestTManager_BindSection_locker(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("server", "port", "8080")
+
+	var (
+		mtx sync.Mutex
+		srv tBindServer
+	)
+	mgr := NewManager(sl)
+	if err := mgr.BindSection("server", &srv, &mtx); nil != err {
+		t.Fatalf("BindSection() error = %v", err)
+	}
+
+	// the target's owner reads the manager while holding its lock:
+	mtx.Lock()
+	next := sl.copyList()
+	next.UpdateSectKeyStr("server", "port", "9090")
+	updated := make(chan struct{})
+	go func() {
+		mgr.Update(next)
+		close(updated)
+	}()
+	time.Sleep(20 * time.Millisecond) // let `Update()` wait for the lock
+
+	current := make(chan *TSectionList)
+	go func() {
+		current <- mgr.Current()
+	}()
+	select {
+	case list := <-current:
+		if list != next {
+			t.Error("TManager.Current() didn't return the new snapshot")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("TManager.Current() blocked by re-binding")
+	}
+	mtx.Unlock()
+
+	<-updated
+	if 9090 != srv.Port {
+		t.Errorf("re-bound port = %d, want 9090", srv.Port)
+	}
+} // TestTManager_BindSection_locker()
+
+/* _EoF_ */
@@ -0,0 +1,109 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "strings"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `DefBoolFalse` are the (lower case) spellings of `false` used
+	// by `SetBoolVocabulary()` by default.
+	DefBoolFalse = []string{"0", "disable", "disabled", "f", "false", "n", "no", "off"}
+
+	// `DefBoolTrue` are the (lower case) spellings of `true` used
+	// by `SetBoolVocabulary()` by default.
+	DefBoolTrue = []string{"1", "enable", "enabled", "on", "t", "true", "y", "yes"}
+)
+
+// `parseBool()` interprets `aValue` as a boolean value observing the
+// list's dialect and boolean vocabulary.
+//
+// Parameters:
+// - `aValue` The value to interpret.
+//
+// Returns:
+// - `bool`: The boolean value.
+// - `bool`: `true` if `aValue` is a valid boolean, `false` otherwise.
+func (sl *TSectionList) parseBool(aValue string) (bool, bool) {
+	if DialectPHP == sl.dialect {
+		result, ok := PHPBooleans[strings.ToLower(strings.TrimSpace(aValue))]
+		return result, ok
+	}
+	if result, ok := sl.boolWords[strings.ToLower(strings.TrimSpace(aValue))]; ok {
+		return result, true
+	}
+	if sl.boolStrict {
+		return false, false
+	}
+
+	return parseBool(aValue)
+} // parseBool()
+
+// `SetBoolVocabulary()` sets the spellings accepted as boolean values
+// by the list's `AsBool()` and `GetBool()` methods.
+//
+// The values are matched exactly (but case-insensitively), so e.g.
+// `talisman` or `2` aren't considered valid booleans. A `nil` slice
+// selects the respective default spellings (`DefBoolTrue` or
+// `DefBoolFalse`):
+//
+//	sl.SetBoolVocabulary(nil, nil) // on/off, enabled/disabled, 1/0 …
+//
+// This disables the legacy heuristic checking only a value's first
+// character; see `SetLegacyBool()` to use it for values not found in
+// the vocabulary. The `DialectPHP` dialect always uses `PHPBooleans`.
+//
+// Parameters:
+// - `aTrue` The spellings of `true`.
+// - `aFalse` The spellings of `false`.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetBoolVocabulary(aTrue, aFalse []string) *TSectionList {
+	if nil == aTrue {
+		aTrue = DefBoolTrue
+	}
+	if nil == aFalse {
+		aFalse = DefBoolFalse
+	}
+
+	sl.boolWords = make(map[string]bool, len(aTrue)+len(aFalse))
+	for _, word := range aFalse {
+		sl.boolWords[strings.ToLower(strings.TrimSpace(word))] = false
+	}
+	for _, word := range aTrue {
+		sl.boolWords[strings.ToLower(strings.TrimSpace(word))] = true
+	}
+	sl.boolStrict = true
+
+	return sl
+} // SetBoolVocabulary()
+
+// `SetLegacyBool()` determines whether boolean values not found in the
+// list's vocabulary (see `SetBoolVocabulary()`) are interpreted by the
+// legacy heuristic checking only their first character (which is the
+// default for compatibility).
+//
+// Disabling the heuristic without a vocabulary set before installs
+// the default spellings (`DefBoolTrue` and `DefBoolFalse`).
+//
+// Parameters:
+// - `aLegacy` Whether to use the legacy heuristic.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetLegacyBool(aLegacy bool) *TSectionList {
+	if !aLegacy && (nil == sl.boolWords) {
+		sl.SetBoolVocabulary(nil, nil)
+	}
+	sl.boolStrict = !aLegacy
+
+	return sl
+} // SetLegacyBool()
+
+/* _EoF_ */
@@ -0,0 +1,93 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "testing"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetBoolVocabulary(t *testing.T) {
+	sl := NewSectionList()
+	for key, value := range map[string]string{
+		"disabled": "Disabled",
+		"enabled":  "enabled",
+		"off":      "off",
+		"on":       "ON",
+		"talisman": "talisman",
+		"two":      "2",
+		"zero":     "0",
+	} {
+		sl.AddSectionKey("", key, value)
+	}
+
+	type tWant struct {
+		value, ok bool
+	}
+	tests := []struct {
+		name  string
+		setup func()
+		key   string
+		want  tWant
+	}{
+		{"legacy talisman", func() {}, "talisman", tWant{true, true}},
+		{"legacy off", func() {}, "off", tWant{true, true}},
+		{"legacy two", func() {}, "two", tWant{false, false}},
+		{"default talisman", func() { sl.SetBoolVocabulary(nil, nil) }, "talisman", tWant{false, false}},
+		{"default off", func() {}, "off", tWant{false, true}},
+		{"default on", func() {}, "on", tWant{true, true}},
+		{"default disabled", func() {}, "disabled", tWant{false, true}},
+		{"default enabled", func() {}, "enabled", tWant{true, true}},
+		{"default zero", func() {}, "zero", tWant{false, true}},
+		{"compat talisman", func() { sl.SetLegacyBool(true) }, "talisman", tWant{true, true}},
+		{"compat off", func() {}, "off", tWant{false, true}},
+		{"custom two", func() { sl.SetBoolVocabulary([]string{"2"}, []string{"0"}) }, "two", tWant{true, true}},
+		{"custom on", func() {}, "on", tWant{false, false}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			value, ok := sl.AsBool("", tt.key)
+			if got := (tWant{value, ok}); got != tt.want {
+				t.Errorf("%q: TSectionList.AsBool() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_SetBoolVocabulary()
+
+func TestTSectionList_SetLegacyBool(t *testing.T) {
+	sl := NewSectionList().SetLegacyBool(false)
+	sl.AddSectionKey("", "on", "on")
+	sl.AddSectionKey("", "off", "Off")
+	sl.AddSectionKey("", "talisman", "talisman")
+
+	type tWant struct {
+		value, ok bool
+	}
+	tests := []struct {
+		name string
+		key  string
+		want tWant
+	}{
+		{"on", "on", tWant{true, true}},
+		{"off", "off", tWant{false, true}},
+		{"talisman", "talisman", tWant{false, false}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := sl.AsBool("", tt.key)
+			if got := (tWant{value, ok}); got != tt.want {
+				t.Errorf("%q: TSectionList.AsBool() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_SetLegacyBool()
+
+/* _EoF_ */
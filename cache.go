@@ -0,0 +1,125 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "sync"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tRenderKey` identifies the state of a list the cached INI data
+	// was rendered from.
+	tRenderKey struct {
+		crlf     bool
+		dialect  TDialect
+		options  TFormatOptions
+		edits    uint64 // the sum of the sections' modifications
+		sections int    // number of sections
+		version  uint64 // the list's version
+	}
+
+	// `tRenderCache` holds the INI data rendered last by `Format()`.
+	tRenderCache struct {
+		key     tRenderKey
+		mtx     sync.Mutex
+		text    string
+		valid   bool
+		version uint64 // incremented by the list's structural changes
+	}
+)
+
+// `cached()` returns the cached INI data rendered with `aOptions`.
+//
+// Parameters:
+// - `aOptions` The layout to use.
+//
+// Returns:
+// - `tRenderKey`: The key identifying the list's current state.
+// - `string`: The cached INI data.
+// - `bool`: `true` if the cached data is up to date, `false` otherwise.
+func (sl *TSectionList) cached(aOptions *TFormatOptions) (tRenderKey, string, bool) {
+	if nil == sl.cache {
+		return tRenderKey{}, "", false
+	}
+	sl.cache.mtx.Lock()
+	defer sl.cache.mtx.Unlock()
+
+	key := tRenderKey{
+		crlf:     sl.crlf,
+		dialect:  sl.dialect,
+		edits:    sl.sectionEdits(),
+		options:  *aOptions,
+		sections: len(sl.secOrder),
+		version:  sl.cache.version,
+	}
+
+	if sl.cache.valid && (key == sl.cache.key) {
+		return key, sl.cache.text, true
+	}
+
+	return key, "", false
+} // cached()
+
+// `keepCache()` stores `aText` rendered from the list's state `aKey`.
+//
+// Parameters:
+// - `aKey` The key identifying the list's state rendered.
+// - `aText` The rendered INI data.
+func (sl *TSectionList) keepCache(aKey tRenderKey, aText string) {
+	if nil == sl.cache {
+		return
+	}
+	sl.cache.mtx.Lock()
+	defer sl.cache.mtx.Unlock()
+
+	sl.cache.key, sl.cache.text, sl.cache.valid = aKey, aText, true
+} // keepCache()
+
+// `sectionEdits()` returns the sum of the modifications of the list's
+// sections.
+//
+// Since each section counts its own modifications the sum changes
+// with every modification of one of the list's sections while
+// modifications of other lists' sections don't affect it.
+//
+// Returns:
+// - `uint64`: The number of the sections' modifications.
+func (sl *TSectionList) sectionEdits() (rEdits uint64) {
+	for _, kl := range sl.sections {
+		kl.mtx.RLock()
+		rEdits += kl.edits
+		kl.mtx.RUnlock()
+	}
+
+	return
+} // sectionEdits()
+
+// `InvalidateCache()` discards the INI data cached by `Format()` and
+// `String()`.
+//
+// The rendered INI data is cached and reused until the list is
+// modified, so that rendering large lists repeatedly is cheap. All
+// modifications by the list's and its sections' methods invalidate the
+// cache automatically; this method allows to release the memory held
+// by the cached data or to force the data's rendering.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) InvalidateCache() *TSectionList {
+	if nil == sl.cache {
+		return sl
+	}
+	sl.cache.mtx.Lock()
+	defer sl.cache.mtx.Unlock()
+
+	sl.cache.text, sl.cache.valid = "", false
+	sl.cache.version++
+
+	return sl
+} // InvalidateCache()
+
+/* _EoF_ */
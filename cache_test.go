@@ -0,0 +1,79 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_InvalidateCache(t *testing.T) {
+	sl := prepSectionList()
+	want := sl.String()
+	if key, got, ok := sl.cached(&sl.fmtOpts); !ok || (got != want) {
+		t.Fatalf("TSectionList.cached() = %v, %v, want cached data", key, ok)
+	}
+
+	sl.InvalidateCache()
+	if _, _, ok := sl.cached(&sl.fmtOpts); ok {
+		t.Error("TSectionList.cached() = true after InvalidateCache()")
+	}
+	if got := sl.String(); got != want {
+		t.Errorf("TSectionList.String() = %q, want %q", got, want)
+	}
+} // TestTSectionList_InvalidateCache()
+
+func TestTSectionList_Format_cache(t *testing.T) {
+	sl := prepSectionList()
+
+	tests := []struct {
+		name   string
+		modify func()
+		want   string
+	}{
+		{"AddSectionKey", func() { sl.AddSectionKey("s1", "added", "yes") }, "added = yes"},
+		{"AddSectionKey new", func() { sl.AddSectionKey("new", "key", "value") }, "[new]"},
+		{"UpdateSectKeyStr", func() { sl.UpdateSectKeyStr("s1", "added", "no") }, "added = no"},
+		{"GetSection AddKey", func() {
+			sl.GetSection("s1").AddKey("direct", "value")
+		}, "direct = value"},
+		{"RenameSection", func() { sl.RenameSection("new", "renamed") }, "[renamed]"},
+		{"RemoveSection", func() { sl.RemoveSection("renamed") }, "[s1]"},
+		{"SetDialect", func() { sl.SetDialect(DialectSystemd) }, "added=no"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := sl.String()
+			tt.modify()
+			got := sl.String()
+			if got == before {
+				t.Fatalf("TSectionList.String() unchanged after %s()", tt.name)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("TSectionList.String() = %q, want to contain %q", got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_Format_cache()
+
+func TestTSectionList_Format_cacheOther(t *testing.T) {
+	sl := prepSectionList()
+	other := prepSectionList()
+
+	want := sl.String()
+	other.AddSectionKey("s1", "added", "yes")
+	other.GetSection("s2").AddKey("direct", "value")
+
+	if _, got, ok := sl.cached(&sl.fmtOpts); !ok || (got != want) {
+		t.Error("modifying another list invalidated the cached data")
+	}
+} // TestTSectionList_Format_cacheOther()
+
+/* _EoF_ */
@@ -0,0 +1,237 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "slices"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TSectionKey` identifies a key within an INI section.
+	TSectionKey struct {
+		Section string
+		Key     string
+	}
+
+	// `TChangeFunc()` is called by a `TSectionList` whenever the value
+	// of a key is added, changed, or removed.
+	//
+	// For a newly added key `aOld` is empty, for a removed key `aNew`
+	// is empty.
+	//
+	// see `OnChange()`
+	TChangeFunc func(aSection, aKey, aOld, aNew string)
+
+	// `tChangeEvent` is a change notification held back until the
+	// list's lock is released.
+	tChangeEvent struct {
+		section string
+		key     string
+		oldVal  string
+		newVal  string
+	}
+)
+
+// `ChangedKeys()` returns the keys modified since the list was created
+// or last stored, in the order they were first modified.
+//
+// Only modifications made through the list's methods (e.g.
+// `AddSectionKey()`, `UpdateSectKeyStr()`, `RemoveSection()`) are
+// tracked; changes made directly to a `TSection` returned by
+// `GetSection()` are not. Reading an INI file is not a modification.
+//
+// Returns:
+// - `[]TSectionKey`: The list of modified keys.
+func (sl *TSectionList) ChangedKeys() []TSectionKey {
+	defer sl.rlock()()
+
+	return slices.Clone(sl.changed)
+} // ChangedKeys()
+
+// `clearChanges()` resets the list's modification tracking.
+func (sl *TSectionList) clearChanges() {
+	defer sl.lock()()
+
+	sl.changed = nil
+} // clearChanges()
+
+// `IsDirty()` reports whether the list was modified since it was
+// created or last stored.
+//
+// See `ChangedKeys()` for which modifications are tracked.
+//
+// Returns:
+// - `bool`: `true` if there are unsaved modifications, `false` otherwise.
+func (sl *TSectionList) IsDirty() bool {
+	defer sl.rlock()()
+
+	return 0 < len(sl.changed)
+} // IsDirty()
+
+// `noteChange()` records a modification of `aKey` in `aSection`
+// and calls the list's change hook (if any).
+//
+// The caller mustn't hold the list's lock.
+//
+// Parameters:
+// - `aSection` The name of the INI section modified.
+// - `aKey` The name of the key modified.
+// - `aOld` The key's previous value.
+// - `aNew` The key's new value.
+// - `aExisted` Whether the key existed before.
+func (sl *TSectionList) noteChange(aSection, aKey, aOld, aNew string, aExisted bool) {
+	unlock := sl.lock()
+	notify := sl.recordChange(aSection, aKey, aOld, aNew, aExisted)
+	unlock()
+
+	if notify && (nil != sl.onChange) {
+		sl.onChange(aSection, aKey, aOld, aNew)
+	}
+} // noteChange()
+
+// `noteRemoval()` records the removal of all keys in `aSection`.
+//
+// The change hook isn't called here so that the caller, holding the
+// list's lock, can send the returned notifications (see
+// `notifyChanges()`) after releasing it.
+//
+// Parameters:
+// - `aSection` The name of the INI section to be removed.
+// - `aList` The section's key/value pairs.
+//
+// Returns:
+// - `[]tChangeEvent`: The change notifications to send.
+func (sl *TSectionList) noteRemoval(aSection string, aList *TSection) []tChangeEvent {
+	if nil == aList {
+		return nil
+	}
+
+	var result []tChangeEvent
+	for _, kv := range aList.KeyVals() {
+		if sl.recordChange(aSection, kv.Key, kv.Value, "", true) {
+			result = append(result, tChangeEvent{aSection, kv.Key, kv.Value, ""})
+		}
+	}
+
+	return result
+} // noteRemoval()
+
+// `notifyChanges()` calls the list's change hook (if any) for all
+// `aEvents`.
+//
+// Parameters:
+// - `aEvents` The change notifications to send.
+func (sl *TSectionList) notifyChanges(aEvents []tChangeEvent) {
+	if nil == sl.onChange {
+		return
+	}
+	for _, event := range aEvents {
+		sl.onChange(event.section, event.key, event.oldVal, event.newVal)
+	}
+} // notifyChanges()
+
+// `recordChange()` records a modification of `aKey` in `aSection`.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section modified.
+// - `aKey` The name of the key modified.
+// - `aOld` The key's previous value.
+// - `aNew` The key's new value.
+// - `aExisted` Whether the key existed before.
+//
+// Returns:
+// - `bool`: `true` if the modification is to be notified, `false` otherwise.
+func (sl *TSectionList) recordChange(aSection, aKey, aOld, aNew string, aExisted bool) bool {
+	if sl.loading || (aExisted && (aOld == aNew)) {
+		return false
+	}
+
+	id := TSectionKey{aSection, aKey}
+	if !slices.Contains(sl.changed, id) {
+		sl.changed = append(sl.changed, id)
+	}
+
+	return true
+} // recordChange()
+
+// `OnChange()` sets the function to call whenever a key's value is
+// added, changed, or removed.
+//
+// Passing `nil` removes a previously set function.
+//
+// Parameters:
+// - `aFunc` The function to call on modifications.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) OnChange(aFunc TChangeFunc) *TSectionList {
+	sl.onChange = aFunc
+
+	return sl
+} // OnChange()
+
+// `putKey()` sets `aKey` in section `aList` named `aSection` to the
+// (already validated) `aValue` recording the modification.
+//
+// Values of secret keys are encrypted (see `SetCipher()`). The caller
+// has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aList` The INI section to use.
+// - `aKey` The name of the key to set.
+// - `aValue` The key's new value.
+// - `aEvents` Collects the change notifications to send.
+//
+// Returns:
+// - `bool`: `true` on success, `false` otherwise.
+func (sl *TSectionList) putKey(aSection string, aList *TSection, aKey, aValue string, aEvents *[]tChangeEvent) bool {
+	if sl.readOnly {
+		return false
+	}
+	value, err := sl.encryptValue(aSection, aKey, aValue)
+	if nil != err {
+		return false
+	}
+	old, existed := aList.AsString(aKey)
+	if !aList.AddKey(aKey, value) {
+		return false
+	}
+	sl.dropOrigin(aSection, aKey)
+	if !sl.loading {
+		value, _ = aList.AsString(aKey)
+		if sl.recordChange(aSection, aKey, old, value, existed) {
+			*aEvents = append(*aEvents, tChangeEvent{aSection, aKey, old, value})
+		}
+	}
+
+	return true
+} // putKey()
+
+// `removeKey()` removes `aKey` from section `aList` named `aSection`
+// recording the modification.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aList` The INI section to use.
+// - `aKey` The name of the key to remove.
+// - `aEvents` Collects the change notifications to send.
+func (sl *TSectionList) removeKey(aSection string, aList *TSection, aKey string, aEvents *[]tChangeEvent) {
+	sl.dropOrigin(aSection, aKey)
+	if old, ok := aList.AsString(aKey); ok {
+		if sl.recordChange(aSection, aKey, old, "", true) {
+			*aEvents = append(*aEvents, tChangeEvent{aSection, aKey, old, ""})
+		}
+		aList.RemoveKey(aKey)
+	}
+} // removeKey()
+
+/* _EoF_ */
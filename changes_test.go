@@ -0,0 +1,69 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_ChangedKeys(t *testing.T) {
+	sl, err := NewIni("testIn.ini")
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	if sl.IsDirty() {
+		t.Fatal("TSectionList.IsDirty() = true after loading")
+	}
+
+	var calls []string
+	sl.OnChange(func(aSection, aKey, aOld, aNew string) {
+		calls = append(calls, aSection+"/"+aKey+": "+aOld+" -> "+aNew)
+	})
+
+	sl.UpdateSectKeyStr("general", "loglevel", "8")  // unchanged value
+	sl.UpdateSectKeyStr("general", "loglevel", "9")  // changed
+	sl.AddSectionKey("general", "newKey", "new")     // added
+	sl.UpdateSectKeyStr("general", "loglevel", "10") // changed again
+	sl.RemoveSectionKey("general", "newKey")         // removed
+	sl.RemoveSectionKey("general", "n.a.")           // nothing to remove
+
+	want := []TSectionKey{{"general", "loglevel"}, {"general", "newKey"}}
+	if got := sl.ChangedKeys(); !slices.Equal(got, want) {
+		t.Errorf("TSectionList.ChangedKeys() = %v, want %v", got, want)
+	}
+	wantCalls := []string{
+		"general/loglevel: 8 -> 9",
+		"general/newKey:  -> new",
+		"general/loglevel: 9 -> 10",
+		"general/newKey: new -> ",
+	}
+	if !slices.Equal(calls, wantCalls) {
+		t.Errorf("TSectionList.OnChange() calls = %q, want %q", calls, wantCalls)
+	}
+	if !sl.IsDirty() {
+		t.Error("TSectionList.IsDirty() = false after modifications")
+	}
+
+	sl.SetFilename(filepath.Join(t.TempDir(), "changes.ini"))
+	if _, err = sl.Store(); nil != err {
+		t.Fatalf("TSectionList.Store() error = %v", err)
+	}
+	if sl.IsDirty() {
+		t.Error("TSectionList.IsDirty() = true after storing")
+	}
+
+	sl.RemoveSection("general")
+	if got := len(sl.ChangedKeys()); 0 == got {
+		t.Error("TSectionList.ChangedKeys() empty after removing a section")
+	}
+} // TestTSectionList_ChangedKeys()
+
+/* _EoF_ */
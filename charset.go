@@ -0,0 +1,366 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TEncoding` is the character encoding of the INI data read
+	// and written.
+	//
+	// Internally all data are kept as UTF-8.
+	TEncoding int
+
+	// `tDecodeReader` transcodes the data read from an 8-bit or
+	// UTF-16 encoded source to UTF-8.
+	tDecodeReader struct {
+		encoding TEncoding
+		err      error     // the source's last error
+		out      []byte    // decoded data not yet returned
+		reader   io.Reader // the source of the encoded data
+		rest     []byte    // undecoded bytes of the last chunk
+	}
+)
+
+const (
+	// `EncodingAuto` reads UTF-8 unless the data start with a byte
+	// order mark (BOM) identifying UTF-8 or UTF-16.
+	EncodingAuto TEncoding = iota
+
+	// `EncodingUTF8` is the UTF-8 encoding.
+	EncodingUTF8
+
+	// `EncodingUTF16LE` is the little endian UTF-16 encoding.
+	EncodingUTF16LE
+
+	// `EncodingUTF16BE` is the big endian UTF-16 encoding.
+	EncodingUTF16BE
+
+	// `EncodingWindows1252` is the Windows "ANSI" code page 1252
+	// used by many legacy Windows INI files.
+	EncodingWindows1252
+
+	// `EncodingISO8859_1` is the ISO-8859-1 (Latin-1) encoding.
+	EncodingISO8859_1
+)
+
+var (
+	// `ErrEncoding` is returned if the INI data contain characters
+	// which can't be represented in the list's encoding.
+	ErrEncoding = errors.New("ini: character not encodable")
+
+	// `cp1252` holds the characters of the code points `0x80`…`0x9F`
+	// of Windows-1252 (the undefined ones map to the C1 controls).
+	cp1252 = [32]rune{
+		0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+		0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+		0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+		0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+	}
+
+	// The byte order marks recognised by `EncodingAuto`.
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+)
+
+// `String()` returns the name of the encoding.
+//
+// Returns:
+// - `string`: The encoding's name.
+func (e TEncoding) String() string {
+	switch e {
+	case EncodingAuto:
+		return "EncodingAuto"
+	case EncodingUTF8:
+		return "EncodingUTF8"
+	case EncodingUTF16LE:
+		return "EncodingUTF16LE"
+	case EncodingUTF16BE:
+		return "EncodingUTF16BE"
+	case EncodingWindows1252:
+		return "EncodingWindows1252"
+	case EncodingISO8859_1:
+		return "EncodingISO8859_1"
+	}
+
+	return fmt.Sprintf("TEncoding(%d)", int(e))
+} // String()
+
+// `decode()` appends the UTF-8 encoded characters of `aChunk` to the
+// reader's output.
+//
+// Parameters:
+// - `aChunk` The encoded bytes to decode.
+// - `aEOF` Whether `aChunk` is the final part of the data.
+func (dr *tDecodeReader) decode(aChunk []byte, aEOF bool) {
+	switch dr.encoding {
+	case EncodingWindows1252, EncodingISO8859_1:
+		for _, b := range aChunk {
+			r := rune(b)
+			if (EncodingWindows1252 == dr.encoding) && (0x80 <= b) && (0x9F >= b) {
+				r = cp1252[b-0x80]
+			}
+			dr.out = utf8.AppendRune(dr.out, r)
+		}
+
+	case EncodingUTF16LE, EncodingUTF16BE:
+		data := append(dr.rest, aChunk...)
+		units := make([]uint16, len(data)/2)
+		for idx := range units {
+			if EncodingUTF16LE == dr.encoding {
+				units[idx] = uint16(data[2*idx]) | uint16(data[2*idx+1])<<8
+			} else {
+				units[idx] = uint16(data[2*idx])<<8 | uint16(data[2*idx+1])
+			}
+		}
+		if last := len(units) - 1; !aEOF && (0 <= last) &&
+			(0xD800 <= units[last]) && (0xDBFF >= units[last]) {
+			// keep the first half of a surrogate pair for the next chunk
+			units = units[:last]
+		}
+		for _, r := range utf16.Decode(units) {
+			dr.out = utf8.AppendRune(dr.out, r)
+		}
+		dr.rest = append(dr.rest[:0:0], data[2*len(units):]...)
+		if aEOF && (0 < len(dr.rest)) {
+			dr.out = utf8.AppendRune(dr.out, utf8.RuneError)
+			dr.rest = nil
+		}
+	}
+} // decode()
+
+// `Read()` implements the `io.Reader` interface.
+func (dr *tDecodeReader) Read(aBuffer []byte) (int, error) {
+	for 0 == len(dr.out) {
+		if nil != dr.err {
+			return 0, dr.err
+		}
+		var chunk [4096]byte
+		n, err := dr.reader.Read(chunk[:])
+		dr.decode(chunk[:n], nil != err)
+		dr.err = err
+	}
+	n := copy(aBuffer, dr.out)
+	dr.out = dr.out[n:]
+
+	return n, nil
+} // Read()
+
+// --------------------------------------------------------------------------
+
+// `decoder()` returns a reader transcoding the data of `aReader` from
+// the list's encoding to UTF-8.
+//
+// A byte order mark (BOM) at the start of the data is removed but
+// remembered (see `HasBOM()`); since it identifies the data's encoding
+// unambiguously, the list's encoding is set accordingly.
+//
+// Parameters:
+// - `aReader` The source of the INI data.
+//
+// Returns:
+// - `io.Reader`: The reader providing UTF-8 data.
+func (sl *TSectionList) decoder(aReader io.Reader) io.Reader {
+	reader := bufio.NewReader(aReader)
+	start, _ := reader.Peek(len(bomUTF8))
+	sl.bom = true
+	switch {
+	case bytes.HasPrefix(start, bomUTF8):
+		_, _ = reader.Discard(len(bomUTF8))
+		sl.encoding = EncodingUTF8
+	case bytes.HasPrefix(start, bomUTF16LE):
+		_, _ = reader.Discard(len(bomUTF16LE))
+		sl.encoding = EncodingUTF16LE
+	case bytes.HasPrefix(start, bomUTF16BE):
+		_, _ = reader.Discard(len(bomUTF16BE))
+		sl.encoding = EncodingUTF16BE
+	default:
+		sl.bom = false
+	}
+
+	switch sl.encoding {
+	case EncodingAuto, EncodingUTF8:
+		return reader
+	}
+
+	return &tDecodeReader{encoding: sl.encoding, reader: reader}
+} // decoder()
+
+// `encode()` returns `aText` in the list's encoding.
+//
+// UTF-16 encoded data always start with a byte order mark while UTF-8
+// encoded data do so only if enabled by `SetBOM()`.
+//
+// Parameters:
+// - `aText` The UTF-8 text to encode.
+//
+// Returns:
+// - `[]byte`: The encoded text.
+// - `error`: `ErrEncoding` if a character can't be encoded.
+func (sl *TSectionList) encode(aText string) ([]byte, error) {
+	var result []byte
+
+	switch sl.encoding {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		result = make([]byte, 0, 2*len(aText)+2)
+		for _, unit := range utf16.Encode(append([]rune{0xFEFF}, []rune(aText)...)) {
+			if EncodingUTF16LE == sl.encoding {
+				result = append(result, byte(unit), byte(unit>>8))
+			} else {
+				result = append(result, byte(unit>>8), byte(unit))
+			}
+		}
+
+	case EncodingWindows1252, EncodingISO8859_1:
+		result = make([]byte, 0, len(aText))
+		line := 1
+		for _, r := range aText {
+			b, ok := encodeByte(sl.encoding, r)
+			if !ok {
+				return nil, fmt.Errorf("%w: %q (line %d) in %v",
+					ErrEncoding, r, line, sl.encoding)
+			}
+			if '\n' == r {
+				line++
+			}
+			result = append(result, b)
+		}
+
+	default:
+		if sl.bom {
+			result = append(append(result, bomUTF8...), aText...)
+		} else {
+			result = []byte(aText)
+		}
+	}
+
+	return result, nil
+} // encode()
+
+// `encodeByte()` returns the 8-bit code of `aRune` in `aEncoding`.
+//
+// Parameters:
+// - `aEncoding` Either `EncodingWindows1252` or `EncodingISO8859_1`.
+// - `aRune` The character to encode.
+//
+// Returns:
+// - `byte`: The character's code.
+// - `bool`: `true` if `aRune` is encodable, `false` otherwise.
+func encodeByte(aEncoding TEncoding, aRune rune) (byte, bool) {
+	if EncodingWindows1252 == aEncoding {
+		for idx, r := range cp1252 {
+			if r == aRune {
+				return byte(0x80 + idx), true
+			}
+		}
+		if (0x80 <= aRune) && (0x9F >= aRune) {
+			return 0, false // not part of Windows-1252
+		}
+	}
+	if 0xFF < aRune {
+		return 0, false
+	}
+
+	return byte(aRune), true
+} // encodeByte()
+
+// `skipBOM()` returns a reader for `aReader`'s data without a leading
+// UTF-8 byte order mark.
+//
+// Parameters:
+// - `aReader` The source of the INI data.
+//
+// Returns:
+// - `io.Reader`: The reader without the BOM.
+func skipBOM(aReader io.Reader) io.Reader {
+	reader := bufio.NewReader(aReader)
+	if start, _ := reader.Peek(len(bomUTF8)); bytes.Equal(start, bomUTF8) {
+		_, _ = reader.Discard(len(bomUTF8))
+	}
+
+	return reader
+} // skipBOM()
+
+// `HasBOM()` reports whether the INI data read started with a byte
+// order mark (BOM) or whether `Store()` will write one.
+//
+// Returns:
+// - `bool`: `true` if a BOM was read or is to be written.
+func (sl *TSectionList) HasBOM() bool {
+	return sl.bom
+} // HasBOM()
+
+// `SetBOM()` determines whether `Store()` writes a UTF-8 byte order
+// mark (BOM) in front of the INI data.
+//
+// A BOM read from an INI file is removed from the data but remembered,
+// so by default the file is written back as it was read. Some (mostly
+// Windows) tools require the BOM while many Unix tools stumble over it.
+// UTF-16 encoded files (see `SetEncoding()`) are always written with
+// a BOM.
+//
+// Parameters:
+// - `aBOM` Whether to write a UTF-8 BOM.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetBOM(aBOM bool) *TSectionList {
+	sl.bom = aBOM
+
+	return sl
+} // SetBOM()
+
+// `Encoding()` returns the character encoding of the list's INI file.
+//
+// After reading a file starting with a byte order mark (BOM) the
+// encoding identified by the BOM is returned.
+//
+// Returns:
+// - `TEncoding`: The list's current encoding.
+func (sl *TSectionList) Encoding() TEncoding {
+	return sl.encoding
+} // Encoding()
+
+// `SetEncoding()` sets the character encoding of the list's INI file.
+//
+// The INI data read are transcoded from `aEncoding` to UTF-8, and
+// `Store()` writes the data in `aEncoding` again. So to convert e.g.
+// a legacy Windows INI file to UTF-8 it's read with
+// `EncodingWindows1252` and stored after setting `EncodingUTF8`.
+// A byte order mark at the start of the INI data takes precedence
+// over `aEncoding`.
+//
+// Example:
+//
+//	sl, err := ini.NewSectionList().
+//		SetEncoding(ini.EncodingWindows1252).
+//		SetFilename(`C:\Windows\legacy.ini`).
+//		Load()
+//
+// Parameters:
+// - `aEncoding` The encoding to use.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetEncoding(aEncoding TEncoding) *TSectionList {
+	sl.encoding = aEncoding
+
+	return sl
+} // SetEncoding()
+
+/* _EoF_ */
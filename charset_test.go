@@ -0,0 +1,153 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding TEncoding
+		data     []byte
+		wantEnc  TEncoding
+	}{
+		{"utf8", EncodingAuto,
+			[]byte("[s]\nkey = Grüße €\n"), EncodingAuto},
+		{"utf8 BOM", EncodingAuto,
+			append([]byte{0xEF, 0xBB, 0xBF}, "[s]\nkey = Grüße €\n"...), EncodingUTF8},
+		{"utf16le BOM", EncodingAuto,
+			[]byte("\xFF\xFE[\x00s\x00]\x00\n\x00k\x00e\x00y\x00=\x00G\x00r\x00\xFC\x00\xDF\x00e\x00 \x00\xAC\x20\n\x00"),
+			EncodingUTF16LE},
+		{"utf16be BOM", EncodingAuto,
+			[]byte("\xFE\xFF\x00[\x00s\x00]\x00\n\x00k\x00e\x00y\x00=\x00G\x00r\x00\xFC\x00\xDF\x00e\x00 \x20\xAC\x00\n"),
+			EncodingUTF16BE},
+		{"cp1252", EncodingWindows1252,
+			[]byte("[s]\r\nkey = Gr\xFC\xDFe \x80\r\n"), EncodingWindows1252},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList().SetEncoding(tt.encoding)
+			if _, err := sl.read(sl.newScanner(context.Background(), bytes.NewReader(tt.data))); nil != err {
+				t.Fatalf("read() error = %v", err)
+			}
+			if got, _ := sl.AsString("s", "key"); "Grüße €" != got {
+				t.Errorf("AsString() = %q, want %q", got, "Grüße €")
+			}
+			if got := sl.Encoding(); got != tt.wantEnc {
+				t.Errorf("Encoding() = %v, want %v", got, tt.wantEnc)
+			}
+		})
+	}
+} // TestTSectionList_SetEncoding()
+
+func TestTSectionList_Store_encoding(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "legacy.ini")
+	sl := NewSectionList().SetFilename(fName).SetEncoding(EncodingWindows1252)
+	_ = sl.AddSectionKey("s", "key", "Grüße €")
+	if _, err := sl.Store(); nil != err {
+		t.Fatalf("Store() error = %v", err)
+	}
+	data, _ := os.ReadFile(fName)
+	if !bytes.Contains(data, []byte("Gr\xFC\xDFe \x80")) {
+		t.Errorf("Store() wrote %q", data)
+	}
+
+	// and back again:
+	back, err := NewSectionList().SetFilename(fName).SetEncoding(EncodingWindows1252).Load()
+	if nil != err {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got, _ := back.AsString("s", "key"); "Grüße €" != got {
+		t.Errorf("AsString() = %q, want %q", got, "Grüße €")
+	}
+
+	// characters not part of the encoding:
+	_ = sl.AddSectionKey("s", "key", "日本")
+	if _, err = sl.Store(); !errors.Is(err, ErrEncoding) {
+		t.Errorf("Store() error = %v, want %v", err, ErrEncoding)
+	}
+
+	// UTF-16 with a BOM:
+	sl.SetEncoding(EncodingUTF16LE)
+	if _, err = sl.Store(); nil != err {
+		t.Fatalf("Store() error = %v", err)
+	}
+	back, err = NewIni(fName)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	if got, _ := back.AsString("s", "key"); "日本" != got {
+		t.Errorf("AsString() = %q, want %q", got, "日本")
+	}
+} // TestTSectionList_Store_encoding()
+
+func TestTSectionList_SetBOM(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "bom.ini")
+	_ = os.WriteFile(fName, append([]byte{0xEF, 0xBB, 0xBF}, "key = value\n[s]\nk = v\n"...), 0600)
+
+	sl, err := NewIni(fName)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	if !sl.HasBOM() {
+		t.Error("HasBOM() = false, want true")
+	}
+	if got, ok := sl.AsString("", "key"); !ok || ("value" != got) {
+		t.Errorf("AsString() = %q, %v, want %q, true", got, ok, "value")
+	}
+
+	// the BOM is written back:
+	if _, err = sl.Store(); nil != err {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if data, _ := os.ReadFile(fName); !bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF, '\n', '['}) {
+		t.Errorf("Store() wrote %q", data)
+	}
+
+	// unless disabled:
+	if _, err = sl.SetBOM(false).Store(); nil != err {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if data, _ := os.ReadFile(fName); !bytes.HasPrefix(data, []byte("\n[Default]")) {
+		t.Errorf("Store() wrote %q", data)
+	}
+
+	// and `Parse()` skips it as well:
+	var section string
+	err = Parse(bytes.NewReader([]byte("\xEF\xBB\xBF[first]\n")), TParseFuncs{
+		OnSectionStart: func(aLineNum int, aSection string) error {
+			section = aSection
+			return nil
+		},
+	})
+	if (nil != err) || ("first" != section) {
+		t.Errorf("Parse() = %q, %v, want %q", section, err, "first")
+	}
+} // TestTSectionList_SetBOM()
+
+func Test_tDecodeReader_surrogates(t *testing.T) {
+	// a surrogate pair split between two reads
+	src := []byte("\x3D\xD8\x00\xDE") // 😀 in UTF-16LE
+	dr := &tDecodeReader{encoding: EncodingUTF16LE}
+	dr.decode(src[:2], false)
+	dr.decode(src[2:], true)
+	if got := string(dr.out); "😀" != got {
+		t.Errorf("decode() = %q, want %q", got, "😀")
+	}
+} // Test_tDecodeReader_surrogates()
+
+/* _EoF_ */
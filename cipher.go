@@ -0,0 +1,285 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TCipher` encrypts and decrypts the values of secret keys
+	// (see `SetCipher()`).
+	//
+	// The `aContext` passed to both methods identifies the value's
+	// section and key; it has to be authenticated (but not encrypted)
+	// along with the value so that an encrypted value can't be moved
+	// to another key unnoticed.
+	TCipher interface {
+		// `Decrypt()` returns the plain text of `aCipherText`.
+		Decrypt(aCipherText, aContext []byte) ([]byte, error)
+
+		// `Encrypt()` returns the cipher text of `aPlainText`.
+		Encrypt(aPlainText, aContext []byte) ([]byte, error)
+	}
+
+	// `tAESCipher` is an AES-GCM based `TCipher`.
+	tAESCipher struct {
+		aead cipher.AEAD
+	}
+
+	// `tSecretKeys` is the set of section/key pairs to encrypt.
+	tSecretKeys map[string]struct{}
+)
+
+const (
+	// `encPrefix` marks the start of an encrypted value.
+	encPrefix = `ENC[`
+
+	// `encSuffix` marks the end of an encrypted value.
+	encSuffix = `]`
+)
+
+var (
+	// `ErrDecrypt` is returned if an encrypted value can't be decrypted.
+	ErrDecrypt = errors.New("ini: can't decrypt value")
+)
+
+// `Decrypt()` returns the plain text of `aCipherText`.
+//
+// Parameters:
+// - `aCipherText` The nonce followed by the sealed data.
+// - `aContext` The additional data authenticated along with the value.
+//
+// Returns:
+// - `[]byte`: The decrypted data.
+// - `error`: A possible authentication error.
+func (ac *tAESCipher) Decrypt(aCipherText, aContext []byte) ([]byte, error) {
+	size := ac.aead.NonceSize()
+	if len(aCipherText) < size {
+		return nil, ErrDecrypt
+	}
+
+	return ac.aead.Open(nil, aCipherText[:size], aCipherText[size:], aContext)
+} // Decrypt()
+
+// `Encrypt()` returns the cipher text of `aPlainText`.
+//
+// Parameters:
+// - `aPlainText` The data to encrypt.
+// - `aContext` The additional data to authenticate along with the value.
+//
+// Returns:
+// - `[]byte`: A random nonce followed by the sealed data.
+// - `error`: A possible error reading random data.
+func (ac *tAESCipher) Encrypt(aPlainText, aContext []byte) ([]byte, error) {
+	nonce := make([]byte, ac.aead.NonceSize())
+	if _, err := rand.Read(nonce); nil != err {
+		return nil, err
+	}
+
+	return ac.aead.Seal(nonce, nonce, aPlainText, aContext), nil
+} // Encrypt()
+
+// `NewAESCipher()` returns an AES-GCM based cipher.
+//
+// Parameters:
+// - `aKey` The secret key of 16, 24, or 32 bytes (AES-128, AES-192,
+// or AES-256).
+//
+// Returns:
+// - `TCipher`: The new cipher.
+// - `error`: An error if `aKey` has an invalid size.
+func NewAESCipher(aKey []byte) (TCipher, error) {
+	block, err := aes.NewCipher(aKey)
+	if nil != err {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if nil != err {
+		return nil, err
+	}
+
+	return &tAESCipher{aead: aead}, nil
+} // NewAESCipher()
+
+// --------------------------------------------------------------------------
+
+// `isEncrypted()` tells whether `aValue` is marked as encrypted.
+//
+// Parameters:
+// - `aValue` The value to check.
+//
+// Returns:
+// - `bool`: `true` if `aValue` looks like `ENC[...]`.
+func isEncrypted(aValue string) bool {
+	return strings.HasPrefix(aValue, encPrefix) &&
+		strings.HasSuffix(aValue, encSuffix)
+} // isEncrypted()
+
+// `decryptValue()` returns the plain text of the encrypted `aValue`.
+//
+// Values not marked as encrypted are returned unchanged as are all
+// values if no cipher is set. A value encrypted for another section
+// or key can't be decrypted.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The value to decrypt.
+//
+// Returns:
+// - `string`: The decrypted value.
+// - `error`: A wrapped `ErrDecrypt` if the value can't be decrypted.
+func (sl *TSectionList) decryptValue(aSection, aKey, aValue string) (string, error) {
+	if (nil == sl.cipher) || !isEncrypted(aValue) {
+		return aValue, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(
+		aValue[len(encPrefix) : len(aValue)-len(encSuffix)])
+	if nil == err {
+		data, err = sl.cipher.Decrypt(data, []byte(originID(aSection, aKey)))
+	}
+	if nil != err {
+		return "", fmt.Errorf("[%s] %s: %w: %w", aSection, aKey, ErrDecrypt, err)
+	}
+
+	return string(data), nil
+} // decryptValue()
+
+// `encryptValue()` returns `aValue` encrypted if `aKey` in `aSection`
+// is a secret key.
+//
+// Values of other keys and values already encrypted are returned
+// unchanged.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The value to encrypt.
+//
+// Returns:
+// - `string`: The (possibly) encrypted value.
+// - `error`: A possible encryption error.
+func (sl *TSectionList) encryptValue(aSection, aKey, aValue string) (string, error) {
+	if (nil == sl.cipher) || isEncrypted(aValue) {
+		return aValue, nil
+	}
+	if _, secret := sl.secrets[originID(aSection, aKey)]; !secret {
+		return aValue, nil
+	}
+
+	return sl.sealValue(aSection, aKey, strings.TrimSpace(aValue))
+} // encryptValue()
+
+// `resealValue()` returns the encrypted `aValue` of `aOldKey` in
+// `aOldSection` encrypted for `aNewKey` in `aNewSection` instead.
+//
+// It's used when a value is moved to another section or key (e.g. by
+// `RenameSection()`). Values not marked as encrypted and values which
+// can't be decrypted are returned unchanged.
+//
+// Parameters:
+// - `aOldSection` The name of the INI section the value belongs to.
+// - `aOldKey` The name of the key the value belongs to.
+// - `aNewSection` The name of the INI section to move the value to.
+// - `aNewKey` The name of the key to move the value to.
+// - `aValue` The value to reseal.
+//
+// Returns:
+// - `string`: The (possibly) resealed value.
+func (sl *TSectionList) resealValue(aOldSection, aOldKey, aNewSection, aNewKey, aValue string) string {
+	if (nil == sl.cipher) || !isEncrypted(aValue) {
+		return aValue
+	}
+	plain, err := sl.decryptValue(aOldSection, aOldKey, aValue)
+	if nil != err {
+		return aValue
+	}
+	if result, err := sl.sealValue(aNewSection, aNewKey, plain); nil == err {
+		return result
+	}
+
+	return aValue
+} // resealValue()
+
+// `sealValue()` returns `aValue` encrypted for `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The plain text value to encrypt.
+//
+// Returns:
+// - `string`: The encrypted value in the form `ENC[base64]`.
+// - `error`: A possible encryption error.
+func (sl *TSectionList) sealValue(aSection, aKey, aValue string) (string, error) {
+	data, err := sl.cipher.Encrypt([]byte(aValue), []byte(originID(aSection, aKey)))
+	if nil != err {
+		return aValue, err
+	}
+
+	return encPrefix + base64.StdEncoding.EncodeToString(data) + encSuffix, nil
+} // sealValue()
+
+// `SetCipher()` sets the cipher used to encrypt the values of the
+// given secret keys.
+//
+// The values of the secret keys are kept (and stored) encrypted in the
+// form `ENC[base64]` while the `AsXxx()` and `GetXxx()` methods return
+// the decrypted values. Existing plain text values of the secret keys
+// are encrypted immediately, as are the values set or read later on;
+// so a plain text secret in the INI file is written encrypted by the
+// next `Store()`. Each value is bound to its section and key, so an
+// encrypted value copied to another key can't be decrypted.
+//
+// Example:
+//
+//	c, _ := ini.NewAESCipher(key)
+//	sl.SetCipher(c, ini.TSectionKey{Section: "db", Key: "password"})
+//
+// Parameters:
+// - `aCipher` The cipher to use (`nil` disables decryption).
+// - `aKeys` The section/key pairs to encrypt.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetCipher(aCipher TCipher, aKeys ...TSectionKey) *TSectionList {
+	sl.cipher = aCipher
+	sl.secrets = make(tSecretKeys, len(aKeys))
+
+	var events []tChangeEvent
+	unlock := sl.lock()
+	for _, sk := range aKeys {
+		section := strings.TrimSpace(sk.Section)
+		if "" == section {
+			section = sl.defSect
+		}
+		key := strings.TrimSpace(sk.Key)
+		sl.secrets[originID(section, key)] = struct{}{}
+
+		if kl, exists := sl.sections[section]; exists {
+			if value, ok := kl.AsString(key); ok {
+				sl.putKey(section, kl, key, value, &events)
+			}
+		}
+	}
+	unlock()
+	sl.notifyChanges(events)
+
+	return sl
+} // SetCipher()
+
+/* _EoF_ */
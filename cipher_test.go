@@ -0,0 +1,91 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetCipher(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	c, err := NewAESCipher(key)
+	if nil != err {
+		t.Fatalf("NewAESCipher() error = %v", err)
+	}
+	if _, err = NewAESCipher([]byte("short")); nil == err {
+		t.Error("NewAESCipher() expected error for invalid key size")
+	}
+
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "user", "admin")
+	sl.AddSectionKey("db", "password", "s3cr3t")
+	sl.SetCipher(c, TSectionKey{Section: "db", Key: "password"},
+		TSectionKey{Key: "token"})
+	sl.AddSectionKey("", "token", "abc123")
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    string
+		wantEnc bool
+	}{
+		{"plain", "db", "user", "admin", false},
+		{"existing", "db", "password", "s3cr3t", true},
+		{"added later", "", "token", "abc123", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := sl.AsString(tt.section, tt.key); got != tt.want {
+				t.Errorf("AsString() = %q, want %q", got, tt.want)
+			}
+			_, raw, _ := sl.rawValue(tt.section, tt.key)
+			if isEncrypted(raw) != tt.wantEnc {
+				t.Errorf("rawValue() = %q, encrypted want %v", raw, tt.wantEnc)
+			}
+		})
+	}
+	if strings.Contains(sl.String(), "s3cr3t") {
+		t.Error("String() contains the plain text secret")
+	}
+
+	// read back the encrypted data
+	_, raw, _ := sl.rawValue("db", "password")
+	back := NewSectionList().SetCipher(c)
+	back.AddSectionKey("db", "password", raw)
+	if got, _ := back.AsString("db", "password"); "s3cr3t" != got {
+		t.Errorf("AsString() = %q, want %q", got, "s3cr3t")
+	}
+
+	// a value copied to another key fails
+	back.AddSectionKey("db", "backup", raw)
+	if _, err = back.GetString("db", "backup"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("GetString() error = %v, want %v", err, ErrDecrypt)
+	}
+
+	// renaming the section keeps the value readable
+	if !back.RenameSection("db", "database") {
+		t.Fatal("RenameSection() failed")
+	}
+	if got, _ := back.AsString("database", "password"); "s3cr3t" != got {
+		t.Errorf("AsString() = %q, want %q", got, "s3cr3t")
+	}
+
+	// a wrong key fails
+	other, _ := NewAESCipher([]byte("fedcba9876543210"))
+	back.SetCipher(other)
+	if _, err = back.GetString("database", "password"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("GetString() error = %v, want %v", err, ErrDecrypt)
+	}
+} // TestTSectionList_SetCipher()
+
+/* _EoF_ */
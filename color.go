@@ -0,0 +1,231 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `errInvalidColor` is returned for values that aren't colours.
+	errInvalidColor = errors.New("invalid colour")
+)
+
+// `formatColor()` returns `aColor` in `#RRGGBB` notation or, if it's
+// not opaque, in `#RRGGBBAA` notation.
+//
+// Parameters:
+// - `aColor` The (alpha-premultiplied) colour to format.
+//
+// Returns:
+// - `string`: The colour's hexadecimal notation.
+func formatColor(aColor color.RGBA) string {
+	c := color.NRGBAModel.Convert(aColor).(color.NRGBA)
+	if 0xff == c.A {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+} // formatColor()
+
+// `parseColorComponent()` interprets `aValue` as a colour component
+// given as a number between `0` and `255` or as a percentage.
+//
+// Parameters:
+// - `aValue` The component to parse.
+// - `aAlpha` Whether `aValue` is an alpha value (between `0` and `1`).
+//
+// Returns:
+// - `uint8`: The component's value.
+// - `error`: A possible parsing error.
+func parseColorComponent(aValue string, aAlpha bool) (uint8, error) {
+	scale := 1.0
+	if number, found := strings.CutSuffix(aValue, "%"); found {
+		aValue, scale = number, 100.0
+	} else if !aAlpha {
+		scale = 255.0
+	}
+
+	f64, err := strconv.ParseFloat(aValue, 64)
+	if nil != err {
+		return 0, err
+	}
+	if f64 = math.Round(f64 * 255 / scale); (0 > f64) || (255 < f64) {
+		return 0, errInvalidColor
+	}
+
+	return uint8(f64), nil
+} // parseColorComponent()
+
+// `parseColor()` interprets `aValue` as a colour.
+//
+// The notations `#RRGGBB`, `#RRGGBBAA`, `rgb(r, g, b)`, and
+// `rgba(r, g, b, a)` are accepted. The `rgb()` components are numbers
+// between `0` and `255` or percentages while the alpha value is a
+// number between `0` and `1` or a percentage; the components may be
+// separated by commas or spaces.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `color.RGBA`: The (alpha-premultiplied) colour represented by `aValue`.
+// - `error`: A possible parsing error.
+func parseColor(aValue string) (color.RGBA, error) {
+	c := color.NRGBA{A: 0xff}
+	aValue = strings.ToLower(strings.TrimSpace(aValue))
+
+	if digits, found := strings.CutPrefix(aValue, "#"); found {
+		if (6 != len(digits)) && (8 != len(digits)) {
+			return color.RGBA{}, errInvalidColor
+		}
+		data, err := hex.DecodeString(digits)
+		if nil != err {
+			return color.RGBA{}, errInvalidColor
+		}
+		c.R, c.G, c.B = data[0], data[1], data[2]
+		if 4 == len(data) {
+			c.A = data[3]
+		}
+
+		return color.RGBAModel.Convert(c).(color.RGBA), nil
+	}
+
+	args, found := strings.CutSuffix(aValue, ")")
+	if !found {
+		return color.RGBA{}, errInvalidColor
+	}
+	if args, found = strings.CutPrefix(args, "rgba("); !found {
+		if args, found = strings.CutPrefix(args, "rgb("); !found {
+			return color.RGBA{}, errInvalidColor
+		}
+	}
+	fields := strings.Fields(strings.NewReplacer(",", " ", "/", " ").Replace(args))
+	if (3 != len(fields)) && (4 != len(fields)) {
+		return color.RGBA{}, errInvalidColor
+	}
+
+	components := []*uint8{&c.R, &c.G, &c.B, &c.A}
+	for idx, field := range fields {
+		value, err := parseColorComponent(field, 3 == idx)
+		if nil != err {
+			return color.RGBA{}, errInvalidColor
+		}
+		*components[idx] = value
+	}
+
+	return color.RGBAModel.Convert(c).(color.RGBA), nil
+} // parseColor()
+
+// --------------------------------------------------------------------------
+
+// `AsColor()` returns the value of `aKey` as a colour.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid colour
+// (like `#336699`, `#33669980`, or `rgb(51, 102, 153)`) then the
+// second return value will be `false`.
+//
+// Note that `color.RGBA` is alpha-premultiplied, i.e. a translucent
+// colour's red, green, and blue components are scaled by its alpha.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `color.RGBA`: The value of `aKey` as a colour.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsColor(aKey string) (color.RGBA, bool) {
+	if value, ok := kl.AsString(aKey); ok {
+		if result, err := parseColor(value); nil == err {
+			return result, true
+		}
+	}
+
+	return color.RGBA{}, false
+} // AsColor()
+
+// `UpdateKeyColor()` replaces the current value of `aKey` by the
+// provided colour in `#RRGGBB` (or, if it's not opaque, `#RRGGBBAA`)
+// notation.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The colour to store.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeyColor(aKey string, aValue color.RGBA) bool {
+	return kl.UpdateKey(aKey, formatColor(aValue))
+} // UpdateKeyColor()
+
+// --------------------------------------------------------------------------
+
+// `AsColor()` returns the value of `aKey` in `aSection` as a colour.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// a valid colour then the second return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `color.RGBA`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsColor(aSection, aKey string) (color.RGBA, bool) {
+	result, err := sl.GetColor(aSection, aKey)
+
+	return result, (nil == err)
+} // AsColor()
+
+// `GetColor()` returns the value of `aKey` in `aSection` as a colour.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `color.RGBA`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetColor(aSection, aKey string) (color.RGBA, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return color.RGBA{}, err
+	}
+	result, err := parseColor(value)
+	if nil != err {
+		return color.RGBA{}, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetColor()
+
+// `UpdateSectKeyColor()` replaces the current value of `aKey` in
+// `aSection` by the provided colour in `#RRGGBB` (or `#RRGGBBAA`)
+// notation.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The colour to store.
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeyColor(aSection, aKey string, aValue color.RGBA) bool {
+	return sl.updateSectKey(aSection, aKey, formatColor(aValue))
+} // UpdateSectKeyColor()
+
+/* _EoF_ */
@@ -0,0 +1,111 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_parseColor(t *testing.T) {
+	tests := []struct {
+		args    string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{"#336699", color.RGBA{0x33, 0x66, 0x99, 0xff}, false},
+		{"#336699FF", color.RGBA{0x33, 0x66, 0x99, 0xff}, false},
+		{" #FFFFFF ", color.RGBA{0xff, 0xff, 0xff, 0xff}, false},
+		{"#ffffff00", color.RGBA{0, 0, 0, 0}, false},
+		{"#ff000080", color.RGBA{0x80, 0, 0, 0x80}, false},
+		{"rgb(51, 102, 153)", color.RGBA{0x33, 0x66, 0x99, 0xff}, false},
+		{"RGB(51 102 153)", color.RGBA{0x33, 0x66, 0x99, 0xff}, false},
+		{"rgb(100%, 0%, 50%)", color.RGBA{0xff, 0, 0x80, 0xff}, false},
+		{"rgba(255, 0, 0, 0.5)", color.RGBA{0x80, 0, 0, 0x80}, false},
+		{"rgb(255 0 0 / 50%)", color.RGBA{0x80, 0, 0, 0x80}, false},
+		{"#369", color.RGBA{}, true},
+		{"#33669g", color.RGBA{}, true},
+		{"rgb(256, 0, 0)", color.RGBA{}, true},
+		{"rgb(-1, 0, 0)", color.RGBA{}, true},
+		{"rgb(1, 2)", color.RGBA{}, true},
+		{"rgb(1, 2, 3", color.RGBA{}, true},
+		{"hsl(0, 0%, 0%)", color.RGBA{}, true},
+		{"rgba(0, 0, 0, 2)", color.RGBA{}, true},
+		{"red", color.RGBA{}, true},
+		{"", color.RGBA{}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, err := parseColor(tt.args)
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("parseColor(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseColor(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+} // Test_parseColor()
+
+func Test_formatColor(t *testing.T) {
+	tests := []struct {
+		args color.RGBA
+		want string
+	}{
+		{color.RGBA{0x33, 0x66, 0x99, 0xff}, "#336699"},
+		{color.RGBA{0, 0, 0, 0xff}, "#000000"},
+		{color.RGBA{0x80, 0, 0, 0x80}, "#ff000080"},
+		{color.RGBA{0, 0, 0, 0}, "#00000000"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatColor(tt.args); got != tt.want {
+				t.Errorf("formatColor(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+} // Test_formatColor()
+
+func TestTSection_AsColor(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("background", "#336699")
+	_ = kl.AddKey("bad", "blueish")
+
+	if got, ok := kl.AsColor("background"); !ok || (color.RGBA{0x33, 0x66, 0x99, 0xff} != got) {
+		t.Errorf("TSection.AsColor() = %v, %v", got, ok)
+	}
+	if _, ok := kl.AsColor("bad"); ok {
+		t.Error("TSection.AsColor() ok = true, want false")
+	}
+	_ = kl.UpdateKeyColor("shadow", color.RGBA{0, 0, 0, 0x80})
+	if got, _ := kl.AsString("shadow"); "#00000080" != got {
+		t.Errorf("TSection.UpdateKeyColor() stored %q, want %q", got, "#00000080")
+	}
+} // TestTSection_AsColor()
+
+func TestTSectionList_AsColor(t *testing.T) {
+	sl := prepSectionList()
+	_ = sl.UpdateSectKeyColor("theme", "accent", color.RGBA{0xcc, 0x33, 0, 0xff})
+	_ = sl.AddSectionKey("theme", "bad", "rgb(1, 2, 3, 4, 5)")
+
+	if got, ok := sl.AsColor("theme", "accent"); !ok || (color.RGBA{0xcc, 0x33, 0, 0xff} != got) {
+		t.Errorf("TSectionList.AsColor() = %v, %v", got, ok)
+	}
+	if _, err := sl.GetColor("theme", "bad"); !errors.Is(err, ErrParseValue) {
+		t.Errorf("TSectionList.GetColor() error = %v, want %v", err, ErrParseValue)
+	}
+	if _, err := sl.GetColor("theme", "n.a."); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TSectionList.GetColor() error = %v, want %v", err, ErrKeyNotFound)
+	}
+} // TestTSectionList_AsColor()
+
+/* _EoF_ */
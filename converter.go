@@ -0,0 +1,175 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TParseFunc` converts an INI value to a value of a registered type.
+	TParseFunc func(aValue string) (any, error)
+
+	// `TFormatFunc` converts a value of a registered type to an INI value.
+	TFormatFunc func(aValue any) string
+
+	// `tConverter` holds the conversion functions of a registered type.
+	tConverter struct {
+		parse  TParseFunc
+		format TFormatFunc
+	}
+)
+
+var (
+	// the registered converters
+	converters    = make(map[reflect.Type]tConverter)
+	convertersMtx sync.RWMutex
+)
+
+// `converterFor()` returns the converter registered for `aType`.
+//
+// Parameters:
+// - `aType` The type to lookup.
+//
+// Returns:
+// - `tConverter`: The registered converter.
+// - `bool`: `true` if a converter was found, `false` otherwise.
+func converterFor(aType reflect.Type) (tConverter, bool) {
+	convertersMtx.RLock()
+	defer convertersMtx.RUnlock()
+
+	result, ok := converters[aType]
+
+	return result, ok
+} // converterFor()
+
+// `convertValue()` uses the converter registered for the type of
+// `aField` (if any) to assign `aValue`.
+//
+// Parameters:
+// - `aField` The (settable) value to update.
+// - `aValue` The INI value to convert.
+//
+// Returns:
+// - `bool`: `true` if a converter was found, `false` otherwise.
+// - `error`: A possible conversion error.
+func convertValue(aField reflect.Value, aValue string) (bool, error) {
+	conv, ok := converterFor(aField.Type())
+	if (!ok) || (nil == conv.parse) {
+		return false, nil
+	}
+
+	parsed, err := conv.parse(aValue)
+	if nil != err {
+		return true, err
+	}
+	result := reflect.ValueOf(parsed)
+	switch {
+	case !result.IsValid():
+		aField.Set(reflect.Zero(aField.Type()))
+	case result.Type().AssignableTo(aField.Type()):
+		aField.Set(result)
+	case result.Type().ConvertibleTo(aField.Type()):
+		aField.Set(result.Convert(aField.Type()))
+	default:
+		return true, fmt.Errorf("%w: parser for %s returned %s",
+			ErrUnsupportedType, aField.Type(), result.Type())
+	}
+
+	return true, nil
+} // convertValue()
+
+// `formatValue()` uses the converter registered for the type of
+// `aField` (if any) to return its INI representation.
+//
+// Parameters:
+// - `aField` The value to convert.
+//
+// Returns:
+// - `string`: The INI representation of `aField`.
+// - `bool`: `true` if a converter was found, `false` otherwise.
+func formatValue(aField reflect.Value) (string, bool) {
+	conv, ok := converterFor(aField.Type())
+	if (!ok) || (nil == conv.format) {
+		return "", false
+	}
+
+	return conv.format(aField.Interface()), true
+} // formatValue()
+
+// `RegisterType()` registers the conversion functions for `aType`.
+//
+// Once registered the functions are used by `Marshal()`, `Unmarshal()`,
+// `AsType()`, and `Get()` for values of `aType`, taking precedence
+// over the built-in conversions. Registering the same type again
+// replaces the former functions; passing `nil` for both functions
+// removes the registration.
+//
+//	ini.RegisterType(reflect.TypeOf(LogLevel(0)),
+//		func(aValue string) (any, error) { return ParseLogLevel(aValue) },
+//		func(aValue any) string { return aValue.(LogLevel).String() })
+//
+// Parameters:
+// - `aType` The type to register.
+// - `aParse` The function converting an INI value to `aType`.
+// - `aFormat` The function converting a value of `aType` to an INI value.
+func RegisterType(aType reflect.Type, aParse TParseFunc, aFormat TFormatFunc) {
+	if nil == aType {
+		return
+	}
+
+	convertersMtx.Lock()
+	defer convertersMtx.Unlock()
+
+	if (nil == aParse) && (nil == aFormat) {
+		delete(converters, aType)
+		return
+	}
+	converters[aType] = tConverter{parse: aParse, format: aFormat}
+} // RegisterType()
+
+// --------------------------------------------------------------------------
+
+// `AsType()` converts the value of `aKey` in `aSection` and stores
+// it in the variable `aTarget` points to.
+//
+// A converter registered by `RegisterType()` for the target's type
+// is used first; otherwise the conversions of `Unmarshal()` apply
+// (including `encoding.TextUnmarshaler` implementations).
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` A non-nil pointer to the variable to update.
+//
+// Returns:
+// - `error`: `ErrInvalidTarget`, `ErrSectionNotFound`, `ErrKeyNotFound`,
+// `ErrParseValue`, `ErrUnsupportedType`, or `nil`.
+func (sl *TSectionList) AsType(aSection, aKey string, aTarget any) error {
+	target := reflect.ValueOf(aTarget)
+	if (reflect.Pointer != target.Kind()) || target.IsNil() {
+		return ErrInvalidTarget
+	}
+
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return err
+	}
+	if err = setFieldValue(sl, target.Elem(), value); (nil != err) &&
+		!errors.Is(err, ErrUnsupportedType) {
+		err = parseError(section, aKey, value, err)
+	}
+
+	return err
+} // AsType()
+
+/* _EoF_ */
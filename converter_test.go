@@ -0,0 +1,109 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	tTestCIDR struct {
+		Net *net.IPNet
+	}
+
+	tTestConvConfig struct {
+		Allowed tTestCIDR `ini:"allowed"`
+		Name    string    `ini:"name"`
+	}
+)
+
+func registerTestCIDR() func() {
+	cidrType := reflect.TypeOf(tTestCIDR{})
+	RegisterType(cidrType,
+		func(aValue string) (any, error) {
+			_, ipNet, err := net.ParseCIDR(aValue)
+			return tTestCIDR{ipNet}, err
+		},
+		func(aValue any) string {
+			return aValue.(tTestCIDR).Net.String()
+		})
+
+	return func() { RegisterType(cidrType, nil, nil) }
+} // registerTestCIDR()
+
+func TestTSectionList_AsType(t *testing.T) {
+	defer registerTestCIDR()()
+
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("net", "allowed", "10.0.0.0/8")
+	_ = sl.AddSectionKey("net", "bad", "10.0.0.0")
+	_ = sl.AddSectionKey("net", "port", "8080")
+
+	var cidr tTestCIDR
+	if err := sl.AsType("net", "allowed", &cidr); (nil != err) || ("10.0.0.0/8" != cidr.Net.String()) {
+		t.Errorf("TSectionList.AsType() = %v, %v", cidr, err)
+	}
+	if err := sl.AsType("net", "bad", &cidr); !errors.Is(err, ErrParseValue) {
+		t.Errorf("TSectionList.AsType() error = %v, want %v", err, ErrParseValue)
+	}
+	if err := sl.AsType("net", "allowed", cidr); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("TSectionList.AsType() error = %v, want %v", err, ErrInvalidTarget)
+	}
+	var port uint16
+	if err := sl.AsType("net", "port", &port); (nil != err) || (8080 != port) {
+		t.Errorf("TSectionList.AsType() = %v, %v", port, err)
+	}
+	if got, err := Get[tTestCIDR](sl, "net", "allowed"); (nil != err) || ("10.0.0.0/8" != got.Net.String()) {
+		t.Errorf("Get[tTestCIDR]() = %v, %v", got, err)
+	}
+} // TestTSectionList_AsType()
+
+func TestRegisterType(t *testing.T) {
+	defer registerTestCIDR()()
+
+	_, ipNet, _ := net.ParseCIDR("192.168.0.0/16")
+	sl, err := Marshal(&tTestConvConfig{Allowed: tTestCIDR{ipNet}, Name: "x"})
+	if nil != err {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, _ := sl.AsString("", "allowed"); "192.168.0.0/16" != got {
+		t.Errorf("Marshal() allowed = %q", got)
+	}
+
+	var cfg tTestConvConfig
+	if err = sl.Unmarshal(&cfg); nil != err {
+		t.Fatalf("TSectionList.Unmarshal() error = %v", err)
+	}
+	if !ipNet.IP.Equal(cfg.Allowed.Net.IP) {
+		t.Errorf("TSectionList.Unmarshal() allowed = %v", cfg.Allowed.Net)
+	}
+
+	// overriding a built-in type:
+	RegisterType(reflect.TypeOf(""),
+		func(aValue string) (any, error) { return strings.ToUpper(aValue), nil }, nil)
+	defer RegisterType(reflect.TypeOf(""), nil, nil)
+	if got, err := Get[string](sl, "", "name"); (nil != err) || ("X" != got) {
+		t.Errorf("Get[string]() = %q, %v", got, err)
+	}
+
+	// a parser returning the wrong type:
+	RegisterType(reflect.TypeOf(0),
+		func(aValue string) (any, error) { return fmt.Sprint(aValue), nil }, nil)
+	defer RegisterType(reflect.TypeOf(0), nil, nil)
+	if _, err := Get[int](sl, "", "name"); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Get[int]() error = %v, want %v", err, ErrUnsupportedType)
+	}
+} // TestRegisterType()
+
+/* _EoF_ */
@@ -0,0 +1,108 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `NewWithDefaults()` reads the given `aDefaults` INI data (e.g. embedded
+// by a `//go:embed` directive) and then overlays the data read from
+// `aFilename` if that file exists.
+//
+// The returned list remembers `aDefaults` so that `StoreDefaults()`
+// can create a missing INI file with the default content and reloading
+// the list (see `Watch()`) again starts with the default values.
+//
+// Parameters:
+// - `aDefaults` The default INI data to start with.
+// - `aFilename` The name of the INI file to overlay the defaults.
+//
+// Returns:
+// - `*TSectionList`: The list of sections of the merged INI data.
+// - `error`: A possible error condition.
+func NewWithDefaults(aDefaults []byte, aFilename string) (*TSectionList, error) {
+	result := NewSectionList().SetFilename(strings.TrimSpace(aFilename))
+	result.defaults = bytes.Clone(aDefaults)
+
+	return result.loadWithDefaults()
+} // NewWithDefaults()
+
+// `loadWithDefaults()` reads the list's default data (if any) and then
+// overlays the data read from the list's INI file.
+//
+// A missing INI file is not considered an error.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) loadWithDefaults() (*TSectionList, error) {
+	if 0 < len(sl.defaults) {
+		fName := sl.fName
+		sl.fName = "" // default values don't come from a file
+		_, err := sl.read(sl.newScanner(context.Background(), bytes.NewReader(sl.defaults)))
+		sl.fName = fName
+		if nil != err {
+			return sl, err
+		}
+	}
+	if "" == sl.fName {
+		return sl, nil
+	}
+
+	if _, err := sl.load(); nil != err {
+		if errors.Is(err, fs.ErrNotExist) {
+			return sl, nil
+		}
+		return sl, err
+	}
+
+	return sl, nil
+} // loadWithDefaults()
+
+// `StoreDefaults()` creates the list's INI file with the default data
+// given to `NewWithDefaults()` if that file doesn't exist yet.
+//
+// Missing parent directories are created as well. An existing INI file
+// is left untouched.
+//
+// Returns:
+// - `int`: The number of bytes written (`0` if the file exists).
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreDefaults() (int, error) {
+	if "" == sl.fName {
+		return 0, fs.ErrInvalid
+	}
+	if err := os.MkdirAll(filepath.Dir(sl.fName), 0755); nil != err {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(sl.fName, os.O_CREATE|os.O_EXCL|os.O_WRONLY, DefFileMode)
+	if nil != err {
+		if errors.Is(err, fs.ErrExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	result, err := file.Write(sl.defaults)
+	if cErr := file.Close(); nil == err {
+		err = cErr
+	}
+
+	return result, err
+} // StoreDefaults()
+
+/* _EoF_ */
@@ -0,0 +1,78 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var testDefaults = []byte("; defaults\nname = default\nport = 80\n\n[log]\nlevel = info\n")
+
+func TestNewWithDefaults(t *testing.T) {
+	dir := t.TempDir()
+	userFile := filepath.Join(dir, "user.ini")
+	_ = os.WriteFile(userFile, []byte("port = 8080\n"), 0600)
+
+	tests := []struct {
+		name     string
+		filename string
+		key      string
+		want     string
+		wantErr  bool
+	}{
+		{"0", "", "port", "80", false},
+		{"1", filepath.Join(dir, "n.a.ini"), "port", "80", false},
+		{"2", userFile, "port", "8080", false},
+		{"3", userFile, "name", "default", false},
+		{"4", dir, "port", "80", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWithDefaults(testDefaults, tt.filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: NewWithDefaults() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if val, _ := got.AsString("", tt.key); val != tt.want {
+				t.Errorf("%q: NewWithDefaults() value = %q, want %q",
+					tt.name, val, tt.want)
+			}
+		})
+	}
+} // TestNewWithDefaults()
+
+func TestTSectionList_StoreDefaults(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "sub", "app.ini")
+	sl, _ := NewWithDefaults(testDefaults, fName)
+
+	n, err := sl.StoreDefaults()
+	if (nil != err) || (len(testDefaults) != n) {
+		t.Fatalf("TSectionList.StoreDefaults() = %d, %v, want %d, nil",
+			n, err, len(testDefaults))
+	}
+	if data, _ := os.ReadFile(fName); string(data) != string(testDefaults) {
+		t.Errorf("TSectionList.StoreDefaults() wrote %q, want %q",
+			data, testDefaults)
+	}
+
+	// an existing file must not be overwritten
+	_ = os.WriteFile(fName, []byte("port = 1\n"), 0600)
+	if n, err = sl.StoreDefaults(); (nil != err) || (0 != n) {
+		t.Errorf("TSectionList.StoreDefaults() = %d, %v, want 0, nil", n, err)
+	}
+	if _, err = NewSectionList().StoreDefaults(); nil == err {
+		t.Error("TSectionList.StoreDefaults() expected error without filename")
+	}
+} // TestTSectionList_StoreDefaults()
+
+/* _EoF_ */
@@ -0,0 +1,129 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `DesktopEntrySection` is the name of the main section of a
+	// `.desktop` file.
+	DesktopEntrySection = `Desktop Entry`
+)
+
+var (
+	// `ErrDesktopEntry` is returned by `ValidateDesktopEntry()` for
+	// an invalid Desktop Entry.
+	ErrDesktopEntry = errors.New("ini: invalid desktop entry")
+)
+
+// `localeVariants()` returns the keys to lookup for `aKey` and `aLocale`
+// in the order of their specificity.
+//
+// A locale of the form `lang_COUNTRY.ENCODING@MODIFIER` results in the
+// keys `aKey[lang_COUNTRY@MODIFIER]`, `aKey[lang_COUNTRY]`,
+// `aKey[lang@MODIFIER]`, `aKey[lang]`, and `aKey` (the encoding is
+// ignored as required by the Desktop Entry specification).
+//
+// Parameters:
+// - `aKey` The name of the key.
+// - `aLocale` The locale to use.
+//
+// Returns:
+// - `[]string`: The keys to lookup.
+func localeVariants(aKey, aLocale string) []string {
+	locale, modifier, _ := strings.Cut(strings.TrimSpace(aLocale), "@")
+	locale, _, _ = strings.Cut(locale, ".")
+	lang, country, _ := strings.Cut(locale, "_")
+
+	result := make([]string, 0, 5)
+	add := func(aVariant string) {
+		result = append(result, aKey+"["+aVariant+"]")
+	}
+	if "" != lang {
+		if "" != country {
+			if "" != modifier {
+				add(lang + "_" + country + "@" + modifier)
+			}
+			add(lang + "_" + country)
+		}
+		if "" != modifier {
+			add(lang + "@" + modifier)
+		}
+		add(lang)
+	}
+
+	return append(result, aKey)
+} // localeVariants()
+
+// `AsLocalizedString()` returns the value of `aKey` in `aSection`
+// localised for `aLocale`.
+//
+// Following the Desktop Entry specification the keys like
+// `Name[de_DE@euro]`, `Name[de_DE]`, `Name[de@euro]`, `Name[de]`, and
+// finally `Name` are tried for the locale `de_DE.UTF-8@euro`.
+// An empty `aLocale` returns the unlocalised value.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup (without locale).
+// - `aLocale` The locale to use (e.g. `de_DE.UTF-8`).
+//
+// Returns:
+// - `string`: The localised value associated with `aKey`.
+// - `bool`: `true` if a value was found, or false otherwise.
+func (sl *TSectionList) AsLocalizedString(aSection, aKey, aLocale string) (string, bool) {
+	aKey = strings.TrimSpace(aKey)
+	for _, key := range localeVariants(aKey, aLocale) {
+		if value, ok := sl.AsString(aSection, key); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+} // AsLocalizedString()
+
+// `ValidateDesktopEntry()` checks whether the list holds a valid
+// Desktop Entry (i.e. the data of a `.desktop` file).
+//
+// The `[Desktop Entry]` section has to exist and provide the keys
+// `Type` and `Name`; an entry of type `Link` needs an `URL` key as well.
+//
+// Returns:
+// - `error`: `nil` or an `ErrDesktopEntry` wrapping all problems found.
+func (sl *TSectionList) ValidateDesktopEntry() error {
+	if !sl.HasSection(DesktopEntrySection) {
+		return fmt.Errorf("%w: missing section [%s]", ErrDesktopEntry, DesktopEntrySection)
+	}
+
+	var errs []error
+	missing := func(aKey string) {
+		errs = append(errs, fmt.Errorf("%w: [%s] missing key %q",
+			ErrDesktopEntry, DesktopEntrySection, aKey))
+	}
+	_, entryType, err := sl.resolveValue(DesktopEntrySection, "Type")
+	if (nil != err) || ("" == entryType) {
+		missing("Type")
+	}
+	if _, value, err := sl.resolveValue(DesktopEntrySection, "Name"); (nil != err) || ("" == value) {
+		missing("Name")
+	}
+	if "Link" == entryType {
+		if _, value, err := sl.resolveValue(DesktopEntrySection, "URL"); (nil != err) || ("" == value) {
+			missing("URL")
+		}
+	}
+
+	return errors.Join(errs...)
+} // ValidateDesktopEntry()
+
+/* _EoF_ */
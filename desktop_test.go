@@ -0,0 +1,90 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepDesktopList(aData string) *TSectionList {
+	sl := NewSectionList().SetDialect(DialectDesktop)
+	_, _ = sl.read(bufio.NewScanner(strings.NewReader(aData)))
+
+	return sl
+} // prepDesktopList()
+
+func TestTSectionList_AsLocalizedString(t *testing.T) {
+	sl := prepDesktopList(`[Desktop Entry]
+Type=Application
+Name=Editor
+Name[de]=Bearbeiter
+Name[de_AT]=Editierer
+Name[sr@latin]=Uređivač
+Exec="/opt/my app/editor" %U
+`)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"", "Editor"},
+		{"fr_FR.UTF-8", "Editor"},
+		{"de_DE.UTF-8", "Bearbeiter"},
+		{"de_AT", "Editierer"},
+		{"de_AT.UTF-8@euro", "Editierer"},
+		{"sr_RS@latin", "Uređivač"},
+		{"sr_RS", "Editor"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got, _ := sl.AsLocalizedString(DesktopEntrySection, "Name", tt.locale); got != tt.want {
+				t.Errorf("AsLocalizedString(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+
+	if got, _ := sl.AsString(DesktopEntrySection, "Exec"); `"/opt/my app/editor" %U` != got {
+		t.Errorf("AsString() = %q, quotes not kept", got)
+	}
+	if out := sl.String(); !strings.Contains(out, "\nName[de]=Bearbeiter\n") {
+		t.Errorf("String() = \n%s", out)
+	}
+} // TestTSectionList_AsLocalizedString()
+
+func TestTSectionList_ValidateDesktopEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"valid", "[Desktop Entry]\nType=Application\nName=Editor\n", false},
+		{"valid link", "[Desktop Entry]\nType=Link\nName=Home\nURL=https://example.com/\n", false},
+		{"no section", "[Other]\nType=Application\n", true},
+		{"no name", "[Desktop Entry]\nType=Application\n", true},
+		{"no URL", "[Desktop Entry]\nType=Link\nName=Home\n", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := prepDesktopList(tt.data).ValidateDesktopEntry()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("ValidateDesktopEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrDesktopEntry) {
+				t.Errorf("ValidateDesktopEntry() error = %v, want %v", err, ErrDesktopEntry)
+			}
+		})
+	}
+} // TestTSectionList_ValidateDesktopEntry()
+
+/* _EoF_ */
@@ -0,0 +1,318 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TDialect` determines the syntax variant of the INI data read
+	// and written.
+	TDialect int
+)
+
+const (
+	// `DialectDefault` is the package's standard INI syntax.
+	DialectDefault TDialect = iota
+
+	// `DialectGit` is the syntax of git's configuration files
+	// (e.g. `.gitconfig`): a section header like `[remote "origin"]`
+	// denotes the subsection `origin` of section `remote` (see
+	// `GetSubsection()` and `GetSubsections()`), and a key without
+	// a value (e.g. `bare`) means `true`.
+	DialectGit
+
+	// `DialectSystemd` is the syntax of systemd's unit files: repeated
+	// keys (e.g. several `ExecStartPre=`) are collected as a list which
+	// is returned by `AsStringSlice()` with an empty separator, an
+	// empty assignment (e.g. `ExecStartPre=`) resets that list, quotes
+	// are kept as part of the values, and comment lines within a value
+	// continued by a trailing backslash are ignored. The data are
+	// written back with repeated keys and without blanks around `=`.
+	DialectSystemd
+
+	// `DialectDesktop` is the syntax of XDG Desktop Entry files (i.e.
+	// `.desktop` files): quotes are kept as part of the values and the
+	// data are written without blanks around `=`. Localised keys like
+	// `Name[de]` are read by `AsLocalizedString()`.
+	DialectDesktop
+
+	// `DialectPHP` is the syntax of PHP's configuration files (i.e.
+	// `php.ini`): array keys like `extension[] = foo` are collected
+	// as a list which is returned by `AsStringSlice()` with an empty
+	// separator, unquoted values end at a `;` comment, booleans are
+	// interpreted by `PHPBooleans`, and integers may be given by the
+	// constants of `PHPConstants` (e.g. `E_ALL & ~E_DEPRECATED`).
+	DialectPHP
+
+	// `DialectEditorConfig` is the syntax of `.editorconfig` files:
+	// section headers are glob patterns (e.g. `[*.{js,py}]` or
+	// `[lib/**.[ch]]`) which may contain brackets themselves, and
+	// keys before the first section (e.g. `root = true`) form the
+	// preamble. The properties for a certain file are returned by
+	// `ResolveFor()`.
+	DialectEditorConfig
+)
+
+// Regular expressions to identify dialect specific parts of an INI file.
+var (
+	// match: section "subsection"
+	isGitSubsectionRE = regexp.MustCompile(`^([^\s"]+)\s+"((?:[^"\\]|\\.)*)"$`)
+
+	// match: key (git's boolean shortcut)
+	isGitBareKeyRE = regexp.MustCompile(`^[A-Za-z][-A-Za-z0-9]*$`)
+)
+
+// `String()` returns the name of the dialect.
+//
+// Returns:
+// - `string`: The dialect's name.
+func (d TDialect) String() string {
+	switch d {
+	case DialectDefault:
+		return "DialectDefault"
+	case DialectGit:
+		return "DialectGit"
+	case DialectSystemd:
+		return "DialectSystemd"
+	case DialectDesktop:
+		return "DialectDesktop"
+	case DialectPHP:
+		return "DialectPHP"
+	case DialectEditorConfig:
+		return "DialectEditorConfig"
+	}
+
+	return fmt.Sprintf("TDialect(%d)", int(d))
+} // String()
+
+// `addUnitKey()` adds a key/value pair read from a systemd unit file
+// to `aSection`.
+//
+// Repeated keys are collected as a list of lines while an empty value
+// resets the list.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The key of the key/value pair to add.
+// - `aValue` The value of the key/value pair to add.
+// - `aSeen` The section/key pairs read so far.
+//
+// Returns:
+// - `bool`: `true` if the key/value pair was stored, `false` otherwise.
+func (sl *TSectionList) addUnitKey(aSection, aKey, aValue string, aSeen tSeenKeys) bool {
+	if aValue = strings.TrimSpace(aValue); "" == aValue {
+		delete(aSeen, originID(aSection, aKey)) // the next assignment starts a new list
+		return sl.AddSectionKey(aSection, aKey, "")
+	}
+
+	return sl.addListKey(aSection, aKey, aValue, aSeen)
+} // addUnitKey()
+
+// `addListKey()` appends `aValue` as a new line to the value of a
+// repeated key.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The key of the key/value pair to add.
+// - `aValue` The value of the key/value pair to add.
+// - `aSeen` The section/key pairs read so far.
+//
+// Returns:
+// - `bool`: `true` if the key/value pair was stored, `false` otherwise.
+func (sl *TSectionList) addListKey(aSection, aKey, aValue string, aSeen tSeenKeys) bool {
+	id := originID(aSection, aKey)
+	if _, dup := aSeen[id]; dup {
+		if old, ok := sl.GetSection(aSection).AsString(aKey); ok && ("" != old) {
+			aValue = old + "\n" + aValue
+		}
+	} else {
+		aSeen[id] = struct{}{}
+	}
+
+	return sl.AddSectionKey(aSection, aKey, aValue)
+} // addListKey()
+
+// `compactEquals()` tells whether the list's dialect requires key/value
+// pairs without blanks around the equal sign.
+//
+// Returns:
+// - `bool`: `true` for the `key=value` syntax.
+func (sl *TSectionList) compactEquals() bool {
+	return (DialectSystemd == sl.dialect) || (DialectDesktop == sl.dialect)
+} // compactEquals()
+
+// `Dialect()` returns the list's syntax variant.
+//
+// Returns:
+// - `TDialect`: The current dialect.
+func (sl *TSectionList) Dialect() TDialect {
+	return sl.dialect
+} // Dialect()
+
+// `headerName()` returns the section name of the section header
+// `aHeader` (i.e. the text between the brackets).
+//
+// Parameters:
+// - `aHeader` The trimmed text of the section header.
+//
+// Returns:
+// - `string`: The name of the section.
+func (sl *TSectionList) headerName(aHeader string) string {
+	if DialectGit == sl.dialect {
+		if matches := isGitSubsectionRE.FindStringSubmatch(aHeader); nil != matches {
+			// normalise the blanks between section and subsection
+			return matches[1] + ` "` + matches[2] + `"`
+		}
+	}
+
+	return aHeader
+} // headerName()
+
+// `sectionMatch()` returns the section name given by the header `aLine`.
+//
+// Parameters:
+// - `aLine` The trimmed INI line to check.
+//
+// Returns:
+// - `string`: The (untrimmed) section name.
+// - `bool`: `true` if `aLine` is a section header, `false` otherwise.
+func (sl *TSectionList) sectionMatch(aLine string) (string, bool) {
+	if DialectEditorConfig == sl.dialect {
+		// glob patterns may contain brackets
+		if (2 <= len(aLine)) && ('[' == aLine[0]) && (']' == aLine[len(aLine)-1]) {
+			return aLine[1 : len(aLine)-1], true
+		}
+		return "", false
+	}
+	if matches := isSectionRE.FindStringSubmatch(aLine); nil != matches {
+		return matches[1], true
+	}
+
+	return "", false
+} // sectionMatch()
+
+// `dialectValue()` returns `aValue` prepared for writing in `aDialect`.
+//
+// Parameters:
+// - `aDialect` The dialect to use.
+// - `aValue` The value to write.
+//
+// Returns:
+// - `string`: The value to write.
+func dialectValue(aDialect TDialect, aValue string) string {
+	if (DialectPHP == aDialect) && strings.ContainsAny(aValue, ";=") &&
+		!strings.Contains(aValue, `"`) {
+		return `"` + aValue + `"`
+	}
+
+	return aValue
+} // dialectValue()
+
+// `repeatsKey()` tells whether the lines of the value of `aKey` are
+// written as repeated keys in `aDialect`.
+//
+// Parameters:
+// - `aDialect` The dialect to use.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `bool`: `true` if the key is repeated for each line of its value.
+func repeatsKey(aDialect TDialect, aKey string) bool {
+	switch aDialect {
+	case DialectSystemd:
+		return true
+	case DialectPHP:
+		return isPHPArrayKey(aKey)
+	}
+
+	return false
+} // repeatsKey()
+
+// `listKey()` returns the name of the key storing the values of the
+// repeated key `aKey`.
+//
+// Parameters:
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `string`: The name of the key holding the values.
+// - `bool`: `true` if the dialect supports repeated keys, `false` otherwise.
+func (sl *TSectionList) listKey(aKey string) (string, bool) {
+	switch sl.dialect {
+	case DialectSystemd:
+		return aKey, true
+	case DialectPHP:
+		return strings.TrimSuffix(strings.TrimSpace(aKey), "[]") + "[]", true
+	}
+
+	return aKey, false
+} // listKey()
+
+// `keepQuotes()` tells whether the list's dialect keeps quotes
+// enclosing a value.
+//
+// Returns:
+// - `bool`: `true` if quotes are part of the values.
+func (sl *TSectionList) keepQuotes() bool {
+	return (DialectSystemd == sl.dialect) || (DialectDesktop == sl.dialect)
+} // keepQuotes()
+
+// `SetDialect()` sets the syntax variant of the INI data read and
+// written.
+//
+// Since the dialect is applied while reading, it has to be set before
+// the INI data is loaded (see `Load()`).
+//
+// Example:
+//
+//	sl, err := ini.NewSectionList().
+//		SetDialect(ini.DialectGit).
+//		SetFilename(os.ExpandEnv("$HOME/.gitconfig")).
+//		Load()
+//	url, _ := sl.GetSubsection("remote", "origin").AsString("url")
+//
+// Parameters:
+// - `aDialect` The dialect to use.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetDialect(aDialect TDialect) *TSectionList {
+	sl.dialect = aDialect
+
+	return sl
+} // SetDialect()
+
+// `GetSubsection()` returns the subsection `aSubsection` of `aSection`
+// given either in git-config style (e.g. `[remote "origin"]`) or as
+// a dotted section name (e.g. `[remote.origin]`).
+//
+// If the subsection doesn't exist an empty section is returned.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aSubsection` The name of the subsection.
+//
+// Returns:
+// - `*TSection`: The requested subsection.
+func (sl *TSectionList) GetSubsection(aSection, aSubsection string) *TSection {
+	aSection = strings.TrimSpace(aSection)
+	quoted := aSection + ` "` +
+		strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(aSubsection) + `"`
+	if sl.HasSection(quoted) {
+		return sl.GetSection(quoted)
+	}
+
+	return sl.GetSection(aSection + "." + aSubsection)
+} // GetSubsection()
+
+/* _EoF_ */
@@ -0,0 +1,133 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetDialect_git(t *testing.T) {
+	data := `[core   ]
+	bare = false
+	filemode
+[remote   "origin"]
+	url = https://github.com/mwat56/ini.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[branch "feature.x"]
+	remote = origin
+[alias "say \"hi\""]
+	hi = !echo hi
+`
+	sl := NewSectionList().SetDialect(DialectGit)
+	if _, err := sl.read(bufio.NewScanner(strings.NewReader(data))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		section string
+		sub     string
+		key     string
+		want    string
+	}{
+		{"plain section", "core", "", "bare", "false"},
+		{"bare key", "core", "", "filemode", "true"},
+		{"subsection", "remote", "origin", "url", "https://github.com/mwat56/ini.git"},
+		{"dotted subsection", "branch", "feature.x", "remote", "origin"},
+		{"escaped subsection", "alias", `say "hi"`, "hi", "!echo hi"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kl := sl.GetSection(tt.section)
+			if "" != tt.sub {
+				kl = sl.GetSubsection(tt.section, tt.sub)
+			}
+			if got, _ := kl.AsString(tt.key); got != tt.want {
+				t.Errorf("AsString(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	if got := sl.GetSubsections("remote"); !reflect.DeepEqual(got, []string{`remote "origin"`}) {
+		t.Errorf("GetSubsections() = %q, want %q", got, []string{`remote "origin"`})
+	}
+
+	// the same syntax is written back
+	out := sl.Format(TFormatOptions{Indent: "\t"})
+	for _, header := range []string{`[remote "origin"]`, `[branch "feature.x"]`, `[alias "say \"hi\""]`, `[core]`} {
+		if !strings.Contains(out, header+"\n") {
+			t.Errorf("Format() misses %s:\n%s", header, out)
+		}
+	}
+	back := NewSectionList().SetDialect(DialectGit)
+	_, _ = back.read(bufio.NewScanner(strings.NewReader(out)))
+	if !back.CompareTo(sl) {
+		t.Errorf("re-read data differ:\n%s", out)
+	}
+} // TestTSectionList_SetDialect_git()
+
+func TestTSectionList_SetDialect_systemd(t *testing.T) {
+	data := `[Unit]
+Description=My "quoted" service
+After=network.target
+
+[Service]
+Environment="A=1" "B=2"
+ExecStartPre=/bin/true
+ExecStartPre=/bin/false
+ExecStartPre=
+ExecStartPre=/bin/mkdir -p /run/a,b
+ExecStart=/usr/bin/app \
+  # a comment within the continuation
+  --verbose
+`
+	sl := NewSectionList().SetDialect(DialectSystemd)
+	if _, err := sl.read(bufio.NewScanner(strings.NewReader(data))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    []string
+	}{
+		{"quotes kept", "Unit", "Description", []string{`My "quoted" service`}},
+		{"quoted words", "Service", "Environment", []string{`"A=1" "B=2"`}},
+		{"reset list", "Service", "ExecStartPre", []string{"/bin/mkdir -p /run/a,b"}},
+		{"continuation", "Service", "ExecStart", []string{"/usr/bin/app --verbose"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := sl.AsStringSlice(tt.section, tt.key, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AsStringSlice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// editing and writing back repeated keys
+	sl.UpdateSectKeySlice("Service", "ExecStartPre", []string{"/bin/a", "/bin/b"}, "")
+	out := sl.String()
+	if !strings.Contains(out, "ExecStartPre=/bin/a\nExecStartPre=/bin/b\n") {
+		t.Errorf("String() misses the repeated keys:\n%s", out)
+	}
+	back := NewSectionList().SetDialect(DialectSystemd)
+	_, _ = back.read(bufio.NewScanner(strings.NewReader(out)))
+	if !back.CompareTo(sl) {
+		t.Errorf("re-read data differ:\n%s", out)
+	}
+} // TestTSectionList_SetDialect_systemd()
+
+/* _EoF_ */
@@ -0,0 +1,793 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tDocLineKind` tells what a document line contains.
+	tDocLineKind int
+
+	// `tDocLine` is a line of a `TDocument`.
+	//
+	// A key/value pair continued by trailing backslashes comprises
+	// several physical lines.
+	tDocLine struct {
+		key     string       // the key of a key/value line
+		kind    tDocLineKind // the line's contents
+		raw     string       // the original text incl. line ending(s)
+		section string       // the section containing the line
+		value   string       // the value of a key/value line
+	}
+
+	// `TDocument` is a lossless representation of an INI file.
+	//
+	// Unlike `TSectionList` a document keeps every line of the INI file
+	// as it was read: comments, blank lines, spacing, the order of the
+	// entries, and even malformed lines. Modifications only touch the
+	// lines of the keys actually changed, so storing an edited document
+	// produces minimal diffs (like e.g. `git config` does).
+	TDocument struct {
+		bom     bool        // the data start with a UTF-8 BOM
+		changed bool        // the document was modified
+		eol     string      // the dominant line ending
+		fName   string      // name of the INI file to use
+		lines   []*tDocLine // the document's lines
+		mtx     sync.RWMutex
+	}
+)
+
+const (
+	docBlank   tDocLineKind = iota // an empty line
+	docComment                     // a comment line
+	docSection                     // a section header
+	docKeyVal                      // a key/value pair
+	docJunk                        // a malformed line
+)
+
+// `docLineEnding()` returns the line ending of `aRaw`.
+//
+// Parameters:
+// - `aRaw` The physical line to check.
+//
+// Returns:
+// - `string`: `\r\n`, `\n`, or an empty string.
+func docLineEnding(aRaw string) string {
+	switch {
+	case strings.HasSuffix(aRaw, "\r\n"):
+		return "\r\n"
+	case strings.HasSuffix(aRaw, "\n"):
+		return "\n"
+	}
+
+	return ""
+} // docLineEnding()
+
+// `docSectionName()` returns the normalised name of `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+//
+// Returns:
+// - `string`: The name used by the document.
+func docSectionName(aSection string) string {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		return DefSection
+	}
+
+	return aSection
+} // docSectionName()
+
+// `parseValue()` sets the key and value of a (possibly continued)
+// key/value line.
+//
+// Returns:
+// - `bool`: `true` if the line holds a key/value pair, `false` otherwise.
+func (dl *tDocLine) parseValue() bool {
+	var logical string
+	physical := strings.SplitAfter(dl.raw, "\n")
+	for idx, line := range physical {
+		line = strings.TrimSpace(line)
+		if (idx+1 < len(physical)) && strings.HasSuffix(line, `\`) {
+			// same concatenation as by `scanLines()`
+			if (1 < len(line)) && (' ' == line[len(line)-2]) {
+				logical += line[:len(line)-1]
+			} else {
+				logical += line[:len(line)-1] + " "
+			}
+			continue
+		}
+		logical += line
+	}
+
+	matches := isKeyValRE.FindStringSubmatch(logical)
+	if nil == matches {
+		return false
+	}
+	dl.key = strings.TrimSpace(matches[1])
+	dl.value = removeQuotes(matches[2])
+
+	return true
+} // parseValue()
+
+// `patch()` replaces the value of the key/value line by `aValue`.
+//
+// The line's indentation, the spacing around the `=`, the kind of
+// quotes used, and the line ending are kept.
+//
+// Parameters:
+// - `aValue` The new value.
+func (dl *tDocLine) patch(aValue string) {
+	first, rest, _ := strings.Cut(dl.raw, "\n")
+	first = strings.TrimRight(first, "\r")
+	matches := isKeyValRE.FindStringSubmatchIndex(first)
+	if nil == matches {
+		return
+	}
+
+	head, old := first[:matches[4]], strings.TrimSpace(first[matches[4]:])
+	if "" != rest {
+		old = "" // the old value spans several lines
+	}
+	if (1 < len(old)) && (('"' == old[0]) || ('\'' == old[0])) && (old[0] == old[len(old)-1]) {
+		aValue = old[:1] + aValue + old[:1]
+	}
+
+	dl.raw = head + aValue + docLineEnding(dl.raw)
+	dl.parseValue()
+} // patch()
+
+// --------------------------------------------------------------------------
+
+// `AddSectionKey()` sets the value of `aKey` in `aSection`.
+//
+// An existing key is updated in place (keeping its indentation, spacing,
+// and quotes) while a new key is added after the section's last key/value
+// line using the layout of that line. A missing section is appended to
+// the document.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The key of the key/value pair to set.
+// - `aValue` The value of the key/value pair to set.
+//
+// Returns:
+// - `bool`: `true` if the document was updated, `false` otherwise.
+func (doc *TDocument) AddSectionKey(aSection, aKey, aValue string) bool {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return false
+	}
+	aSection = docSectionName(aSection)
+	aValue = strings.TrimSpace(aValue)
+
+	doc.mtx.Lock()
+	defer doc.mtx.Unlock()
+
+	if idx := doc.findKey(aSection, aKey); 0 <= idx {
+		line := doc.lines[idx]
+		if line.value != aValue {
+			line.patch(aValue)
+			doc.changed = true
+		}
+		return true
+	}
+
+	// a new key: use the layout of the section's last key/value line
+	text := aKey + " = " + aValue + doc.eol
+	pos, header := doc.sectionEnd(aSection)
+	if 0 > pos {
+		pos = len(doc.lines)
+		doc.terminate()
+		if (DefSection == aSection) && !doc.hasHeader(DefSection) {
+			pos = doc.preambleEnd()
+		} else {
+			if (0 < pos) && (docBlank != doc.lines[pos-1].kind) {
+				doc.lines = append(doc.lines, &tDocLine{kind: docBlank, raw: doc.eol})
+				pos++
+			}
+			doc.lines = append(doc.lines, &tDocLine{
+				kind:    docSection,
+				raw:     "[" + aSection + "]" + doc.eol,
+				section: aSection,
+			})
+			pos++
+		}
+	} else if !header {
+		last := doc.lines[pos-1]
+		first, _, _ := strings.Cut(last.raw, "\n")
+		first = strings.TrimRight(first, "\r")
+		if matches := isKeyValRE.FindStringSubmatchIndex(first); nil != matches {
+			indent := first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+			text = indent + aKey + first[matches[3]:matches[4]] + aValue + doc.eol
+		}
+		if "" == docLineEnding(last.raw) {
+			last.raw += doc.eol // the section was the document's end
+		}
+	}
+
+	line := &tDocLine{kind: docKeyVal, raw: text, section: aSection}
+	line.parseValue()
+	doc.lines = append(doc.lines[:pos], append([]*tDocLine{line}, doc.lines[pos:]...)...)
+	doc.changed = true
+
+	return true
+} // AddSectionKey()
+
+// `AsString()` returns the value of `aKey` in `aSection`.
+//
+// If a key appears several times in a section the last one is used.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value of `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (doc *TDocument) AsString(aSection, aKey string) (string, bool) {
+	doc.mtx.RLock()
+	defer doc.mtx.RUnlock()
+
+	if idx := doc.findKey(docSectionName(aSection), strings.TrimSpace(aKey)); 0 <= idx {
+		return doc.lines[idx].value, true
+	}
+
+	return "", false
+} // AsString()
+
+// `Bytes()` returns the document's data.
+//
+// An unmodified document returns exactly the bytes read.
+//
+// Returns:
+// - `[]byte`: The document's data.
+func (doc *TDocument) Bytes() []byte {
+	return []byte(doc.String())
+} // Bytes()
+
+// `Filename()` returns the name of the document's INI file.
+//
+// Returns:
+// - `string`: The document's filename.
+func (doc *TDocument) Filename() string {
+	return doc.fName
+} // Filename()
+
+// `findLine()` returns the index of the line holding `aKey` in
+// `aSection` or of the section's header if `aKey` is empty.
+//
+// Parameters:
+// - `aSection` The normalised name of the INI section.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The line's index or `-1` if not found.
+func (doc *TDocument) findLine(aSection, aKey string) int {
+	if "" != aKey {
+		return doc.findKey(aSection, aKey)
+	}
+	for idx, line := range doc.lines {
+		if (docSection == line.kind) && (line.section == aSection) {
+			return idx
+		}
+	}
+
+	return -1
+} // findLine()
+
+// `findKey()` returns the index of the last line holding `aKey`
+// in `aSection`.
+//
+// Parameters:
+// - `aSection` The normalised name of the INI section.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The line's index or `-1` if not found.
+func (doc *TDocument) findKey(aSection, aKey string) int {
+	for idx := len(doc.lines) - 1; 0 <= idx; idx-- {
+		line := doc.lines[idx]
+		if (docKeyVal == line.kind) && (line.section == aSection) && (line.key == aKey) {
+			return idx
+		}
+	}
+
+	return -1
+} // findKey()
+
+// `hasHeader()` checks whether the document contains a header for
+// `aSection`.
+//
+// Parameters:
+// - `aSection` The normalised name of the INI section.
+//
+// Returns:
+// - `bool`: `true` if the section header exists, `false` otherwise.
+func (doc *TDocument) hasHeader(aSection string) bool {
+	for _, line := range doc.lines {
+		if (docSection == line.kind) && (line.section == aSection) {
+			return true
+		}
+	}
+
+	return false
+} // hasHeader()
+
+// `HasSectionKey()` checks whether `aKey` exists in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `bool`: `true` if `aKey` exists, `false` otherwise.
+func (doc *TDocument) HasSectionKey(aSection, aKey string) bool {
+	_, ok := doc.AsString(aSection, aKey)
+
+	return ok
+} // HasSectionKey()
+
+// `InsertCommentBefore()` inserts the comment `aText` before the line
+// of `aKey` in `aSection`.
+//
+// If `aKey` is empty the comment is inserted before the section's
+// header. Each line of `aText` becomes a comment line using the key's
+// indentation; lines not starting with `;` or `#` are prefixed by `; `.
+//
+// Example:
+//
+//	doc.InsertCommentBefore("server", "port",
+//		"# managed by ansible - do not edit")
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key to annotate.
+// - `aText` The comment to insert.
+//
+// Returns:
+// - `bool`: `true` if the comment was inserted, `false` if the line
+// wasn't found.
+func (doc *TDocument) InsertCommentBefore(aSection, aKey, aText string) bool {
+	doc.mtx.Lock()
+	defer doc.mtx.Unlock()
+
+	idx := doc.findLine(docSectionName(aSection), strings.TrimSpace(aKey))
+	if 0 > idx {
+		return false
+	}
+	doc.insertComment(idx, aText)
+
+	return true
+} // InsertCommentBefore()
+
+// `insertComment()` inserts the comment lines of `aText` before the
+// line at `aIndex`.
+//
+// Parameters:
+// - `aIndex` The index of the line to annotate.
+// - `aText` The comment to insert.
+func (doc *TDocument) insertComment(aIndex int, aText string) {
+	line := doc.lines[aIndex]
+	indent := line.raw[:len(line.raw)-len(strings.TrimLeft(line.raw, " \t"))]
+
+	var comments []*tDocLine
+	for _, text := range strings.Split(strings.TrimRight(aText, "\r\n"), "\n") {
+		text = strings.TrimSpace(text)
+		if !strings.HasPrefix(text, ";") && !strings.HasPrefix(text, "#") {
+			text = strings.TrimSpace("; " + text)
+		}
+		comments = append(comments, &tDocLine{
+			kind:    docComment,
+			raw:     indent + text + doc.eol,
+			section: line.section,
+		})
+	}
+	if (docSection == line.kind) && (0 < aIndex) {
+		// a section's comment belongs to the preceding section
+		for _, comment := range comments {
+			comment.section = doc.lines[aIndex-1].section
+		}
+	}
+	doc.lines = append(doc.lines[:aIndex], append(comments, doc.lines[aIndex:]...)...)
+	doc.changed = true
+} // insertComment()
+
+// `IsDirty()` reports whether the document was modified since it was
+// read or stored.
+//
+// Returns:
+// - `bool`: `true` if the document was modified, `false` otherwise.
+func (doc *TDocument) IsDirty() bool {
+	doc.mtx.RLock()
+	defer doc.mtx.RUnlock()
+
+	return doc.changed
+} // IsDirty()
+
+// `LineNumber()` returns the number of the (first) line holding `aKey`
+// in `aSection` or of the section's header if `aKey` is empty.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The 1-based line number or `0` if not found.
+func (doc *TDocument) LineNumber(aSection, aKey string) int {
+	doc.mtx.RLock()
+	defer doc.mtx.RUnlock()
+
+	idx := doc.findLine(docSectionName(aSection), strings.TrimSpace(aKey))
+	if 0 > idx {
+		return 0
+	}
+	result := 1
+	for _, line := range doc.lines[:idx] {
+		result += strings.Count(line.raw, "\n")
+	}
+
+	return result
+} // LineNumber()
+
+// `preambleEnd()` returns the index where keys without a section are
+// inserted, i.e. before the first section header and the comments
+// directly preceding it.
+//
+// Returns:
+// - `int`: The index of the insert position.
+func (doc *TDocument) preambleEnd() int {
+	for idx, line := range doc.lines {
+		if docSection != line.kind {
+			continue
+		}
+		for (0 < idx) && (docComment == doc.lines[idx-1].kind) {
+			idx--
+		}
+		return idx
+	}
+
+	return len(doc.lines)
+} // preambleEnd()
+
+// `RemoveLine()` removes the line with the number `aLine`.
+//
+// If the line belongs to a key/value pair spanning several lines the
+// whole pair is removed. Removing a section header makes the section's
+// keys part of the preceding section.
+//
+// Parameters:
+// - `aLine` The 1-based number of the line to remove.
+//
+// Returns:
+// - `bool`: `true` if the line was removed, `false` if it didn't exist.
+func (doc *TDocument) RemoveLine(aLine int) bool {
+	if 1 > aLine {
+		return false
+	}
+
+	doc.mtx.Lock()
+	defer doc.mtx.Unlock()
+
+	num := 1
+	for idx, line := range doc.lines {
+		count := strings.Count(line.raw, "\n")
+		if "" == docLineEnding(line.raw) {
+			count++ // the last line without line ending
+		}
+		if aLine < num+count {
+			doc.lines = append(doc.lines[:idx], doc.lines[idx+1:]...)
+			if docSection == line.kind {
+				doc.resection()
+			}
+			doc.changed = true
+			return true
+		}
+		num += count
+	}
+
+	return false
+} // RemoveLine()
+
+// `RemoveSectionKey()` removes all occurrences of `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key to remove.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was removed, `false` if it didn't exist.
+func (doc *TDocument) RemoveSectionKey(aSection, aKey string) bool {
+	aSection, aKey = docSectionName(aSection), strings.TrimSpace(aKey)
+
+	doc.mtx.Lock()
+	defer doc.mtx.Unlock()
+
+	result := false
+	lines := doc.lines[:0]
+	for _, line := range doc.lines {
+		if (docKeyVal == line.kind) && (line.section == aSection) && (line.key == aKey) {
+			result = true
+			continue
+		}
+		lines = append(lines, line)
+	}
+	doc.lines = lines
+	if result {
+		doc.changed = true
+	}
+
+	return result
+} // RemoveSectionKey()
+
+// `resection()` updates the section names of all lines after the
+// removal of a section header.
+func (doc *TDocument) resection() {
+	section := DefSection
+	for _, line := range doc.lines {
+		if docSection == line.kind {
+			section = line.section
+		} else {
+			line.section = section
+		}
+	}
+} // resection()
+
+// `SectionList()` returns the document's data as a `TSectionList`
+// for typed access to the values.
+//
+// The returned list is independent of the document.
+//
+// Returns:
+// - `*TSectionList`: The list of the document's sections.
+func (doc *TDocument) SectionList() *TSectionList {
+	result := NewSectionList().SetFilename(doc.fName)
+	_, _ = result.read(result.newScanner(context.Background(),
+		strings.NewReader(doc.String())))
+
+	return result
+} // SectionList()
+
+// `sectionEnd()` returns the index after the last key/value (or
+// malformed) line of `aSection`.
+//
+// Parameters:
+// - `aSection` The normalised name of the INI section.
+//
+// Returns:
+// - `int`: The insert position or `-1` if the section doesn't exist.
+// - `bool`: `true` if the position follows the section's header.
+func (doc *TDocument) sectionEnd(aSection string) (int, bool) {
+	result, header := -1, false
+	for idx, line := range doc.lines {
+		if line.section != aSection {
+			continue
+		}
+		switch line.kind {
+		case docSection:
+			if 0 > result {
+				result, header = idx+1, true
+			}
+		case docKeyVal, docJunk:
+			result, header = idx+1, false
+		}
+	}
+
+	return result, header
+} // sectionEnd()
+
+// `SetKeyComment()` replaces the comment lines directly preceding the
+// line of `aKey` in `aSection` by `aText`.
+//
+// If `aKey` is empty the section header's comment is replaced. An empty
+// `aText` removes the comment. See `InsertCommentBefore()` for how
+// `aText` is formatted.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key to annotate.
+// - `aText` The new comment.
+//
+// Returns:
+// - `bool`: `true` if the comment was set, `false` if the line wasn't
+// found.
+func (doc *TDocument) SetKeyComment(aSection, aKey, aText string) bool {
+	doc.mtx.Lock()
+	defer doc.mtx.Unlock()
+
+	idx := doc.findLine(docSectionName(aSection), strings.TrimSpace(aKey))
+	if 0 > idx {
+		return false
+	}
+	start := idx
+	for (0 < start) && (docComment == doc.lines[start-1].kind) {
+		start--
+	}
+	if start < idx {
+		doc.lines = append(doc.lines[:start], doc.lines[idx:]...)
+		idx = start
+		doc.changed = true
+	}
+	if "" != strings.TrimSpace(aText) {
+		doc.insertComment(idx, aText)
+	}
+
+	return true
+} // SetKeyComment()
+
+// `SetFilename()` sets the name of the document's INI file.
+//
+// Parameters:
+// - `aFilename` The name of the INI file to use.
+//
+// Returns:
+// - `*TDocument`: The current document.
+func (doc *TDocument) SetFilename(aFilename string) *TDocument {
+	doc.fName = strings.TrimSpace(aFilename)
+
+	return doc
+} // SetFilename()
+
+// `Store()` writes the document to its INI file.
+//
+// An existing file keeps its mode while a new file is created with
+// `DefFileMode`.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (doc *TDocument) Store() (int, error) {
+	n, err := writeFile(doc.fName, doc.Bytes(), tFileMode{})
+	if nil == err {
+		doc.mtx.Lock()
+		doc.changed = false
+		doc.mtx.Unlock()
+	}
+
+	return n, err
+} // Store()
+
+// `String()` returns the document's data as a string.
+//
+// Returns:
+// - `string`: The document's data.
+func (doc *TDocument) String() string {
+	var sb strings.Builder
+
+	doc.mtx.RLock()
+	defer doc.mtx.RUnlock()
+
+	if doc.bom {
+		sb.Write(bomUTF8)
+	}
+	for _, line := range doc.lines {
+		sb.WriteString(line.raw)
+	}
+
+	return sb.String()
+} // String()
+
+// `terminate()` adds the dominant line ending to the document's last
+// line if it has none.
+func (doc *TDocument) terminate() {
+	if last := len(doc.lines) - 1; 0 <= last {
+		if "" == docLineEnding(doc.lines[last].raw) {
+			doc.lines[last].raw += doc.eol
+		}
+	}
+} // terminate()
+
+// --------------------------------------------------------------------------
+
+// `ParseDocument()` returns a lossless document of the INI data `aData`.
+//
+// Parameters:
+// - `aData` The INI data to parse.
+//
+// Returns:
+// - `*TDocument`: The document of `aData`.
+func ParseDocument(aData []byte) *TDocument {
+	data := string(aData)
+	result := &TDocument{eol: "\n"}
+	if strings.HasPrefix(data, string(bomUTF8)) {
+		result.bom, data = true, data[len(bomUTF8):]
+	}
+
+	var cont *tDocLine // a key/value line continued by a backslash
+	crlf, lf := 0, 0
+	section := DefSection
+	for "" != data {
+		raw := data
+		if end := strings.IndexByte(data, '\n'); 0 <= end {
+			raw = data[:end+1]
+		}
+		data = data[len(raw):]
+		switch docLineEnding(raw) {
+		case "\r\n":
+			crlf++
+		case "\n":
+			lf++
+		}
+
+		text := strings.TrimSpace(raw)
+		if nil != cont {
+			cont.raw += raw
+			if !strings.HasSuffix(text, `\`) {
+				cont.parseValue()
+				cont = nil
+			}
+			continue
+		}
+
+		line := &tDocLine{raw: raw, section: section}
+		switch {
+		case "" == text:
+			line.kind = docBlank
+		case (';' == text[0]) || ('#' == text[0]):
+			line.kind = docComment
+		default:
+			if matches := isSectionRE.FindStringSubmatch(text); nil != matches {
+				section = docSectionName(matches[1])
+				line.kind, line.section = docSection, section
+			} else if line.parseValue() {
+				line.kind = docKeyVal
+				if strings.HasSuffix(text, `\`) {
+					cont = line
+				}
+			} else {
+				line.kind = docJunk
+			}
+		}
+		result.lines = append(result.lines, line)
+	}
+	if nil != cont {
+		cont.parseValue()
+	}
+	if crlf > lf {
+		result.eol = "\r\n"
+	}
+
+	return result
+} // ParseDocument()
+
+// `NewDocument()` reads the INI file `aFilename` into a lossless
+// document.
+//
+// Storing an unmodified document writes exactly the bytes read, and
+// modifying a key only rewrites that key's line. Use `SectionList()`
+// for typed access to the values.
+//
+// Example:
+//
+//	doc, err := ini.NewDocument("app.ini")
+//	if nil == err {
+//		doc.AddSectionKey("server", "port", "8080")
+//		_, err = doc.Store()
+//	}
+//
+// Parameters:
+// - `aFilename` The name of the INI file to read.
+//
+// Returns:
+// - `*TDocument`: The document of the INI file.
+// - `error`: A possible error condition.
+func NewDocument(aFilename string) (*TDocument, error) {
+	data, err := os.ReadFile(aFilename)
+	if nil != err {
+		return ParseDocument(nil).SetFilename(aFilename), err
+	}
+
+	return ParseDocument(data).SetFilename(aFilename), nil
+} // NewDocument()
+
+/* _EoF_ */
@@ -0,0 +1,228 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const docTestData = "; the app's configuration\r\n" +
+	"global=1\r\n" +
+	"\r\n" +
+	"# the server\r\n" +
+	"[server]\r\n" +
+	"   host   =  \"localhost\"   \r\n" +
+	"\tport:8080 (junk)\r\n" +
+	"  port=80\r\n" +
+	"list = a, \\\r\n" +
+	"       b\r\n" +
+	"\r\n" +
+	"[empty]\r\n" +
+	"; nothing here"
+
+func TestParseDocument(t *testing.T) {
+	doc := ParseDocument([]byte(docTestData))
+	if got := doc.String(); docTestData != got {
+		t.Errorf("TDocument.String() = %q, want %q", got, docTestData)
+	}
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    string
+		wantOK  bool
+	}{
+		{"1", "", "global", "1", true},
+		{"2", "server", "host", "localhost", true},
+		{"3", "server", "port", "80", true},
+		{"4", "server", "list", "a, b", true},
+		{"5", "empty", "key", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := doc.AsString(tt.section, tt.key)
+			if (got != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("TDocument.AsString() = %q, %v, want %q, %v",
+					got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+} // TestParseDocument()
+
+func TestTDocument_AddSectionKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		value   string
+		old     string // the part of `docTestData` replaced
+		new     string // the replacement
+	}{
+		{"update quoted", "server", "host", "example.com",
+			"\"localhost\"   \r\n", "\"example.com\"\r\n"},
+		{"update continued", "server", "list", "c",
+			"list = a, \\\r\n       b\r\n", "list = c\r\n"},
+		{"add to section", "server", "user", "www",
+			"       b\r\n", "       b\r\nuser = www\r\n"},
+		{"add to default", "", "other", "2",
+			"global=1\r\n", "global=1\r\nother=2\r\n"},
+		{"add to empty section", "empty", "key", "v",
+			"[empty]\r\n", "[empty]\r\nkey = v\r\n"},
+		{"add section", "new", "key", "v",
+			"; nothing here", "; nothing here\r\n\r\n[new]\r\nkey = v\r\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseDocument([]byte(docTestData))
+			if !doc.AddSectionKey(tt.section, tt.key, tt.value) {
+				t.Fatal("TDocument.AddSectionKey() = false")
+			}
+			if got, _ := doc.AsString(tt.section, tt.key); got != tt.value {
+				t.Errorf("TDocument.AsString() = %q, want %q", got, tt.value)
+			}
+			if !doc.IsDirty() {
+				t.Error("TDocument.IsDirty() = false")
+			}
+			want := strings.Replace(docTestData, tt.old, tt.new, 1)
+			if got := doc.String(); got != want {
+				t.Errorf("TDocument.String() =\n%q\nwant\n%q", got, want)
+			}
+		})
+	}
+
+	// a value set to its current value doesn't modify the document:
+	doc := ParseDocument([]byte(docTestData))
+	doc.AddSectionKey("server", "host", "localhost")
+	if doc.IsDirty() || (doc.String() != docTestData) {
+		t.Errorf("TDocument.AddSectionKey() modified an unchanged value")
+	}
+} // TestTDocument_AddSectionKey()
+
+func TestTDocument_RemoveSectionKey(t *testing.T) {
+	doc := ParseDocument([]byte(docTestData))
+	if !doc.RemoveSectionKey("server", "list") {
+		t.Fatal("TDocument.RemoveSectionKey() = false")
+	}
+	if doc.HasSectionKey("server", "list") {
+		t.Error("TDocument.HasSectionKey() = true after removal")
+	}
+	if strings.Contains(doc.String(), "b\r\n") {
+		t.Errorf("TDocument.RemoveSectionKey() kept a continuation line: %q", doc.String())
+	}
+	if doc.RemoveSectionKey("server", "list") {
+		t.Error("TDocument.RemoveSectionKey() = true for a missing key")
+	}
+} // TestTDocument_RemoveSectionKey()
+
+func TestTDocument_InsertCommentBefore(t *testing.T) {
+	doc := ParseDocument([]byte(docTestData))
+	if !doc.InsertCommentBefore("server", "port", "# managed by ansible\ndo not edit") {
+		t.Fatal("TDocument.InsertCommentBefore() = false")
+	}
+	want := strings.Replace(docTestData, "  port=80",
+		"  # managed by ansible\r\n  ; do not edit\r\n  port=80", 1)
+	if got := doc.String(); got != want {
+		t.Errorf("TDocument.String() =\n%q\nwant\n%q", got, want)
+	}
+	if doc.InsertCommentBefore("server", "missing", "text") {
+		t.Error("TDocument.InsertCommentBefore() = true for a missing key")
+	}
+
+	// comment a section header:
+	doc = ParseDocument([]byte(docTestData))
+	doc.InsertCommentBefore("empty", "", "unused")
+	if got := doc.String(); !strings.Contains(got, "\r\n; unused\r\n[empty]") {
+		t.Errorf("TDocument.String() = %q", got)
+	}
+} // TestTDocument_InsertCommentBefore()
+
+func TestTDocument_SetKeyComment(t *testing.T) {
+	doc := ParseDocument([]byte(docTestData))
+	if !doc.SetKeyComment("server", "", "# the web server") {
+		t.Fatal("TDocument.SetKeyComment() = false")
+	}
+	want := strings.Replace(docTestData, "# the server", "# the web server", 1)
+	if got := doc.String(); got != want {
+		t.Errorf("TDocument.String() =\n%q\nwant\n%q", got, want)
+	}
+
+	// an empty text removes the comment:
+	doc.SetKeyComment("server", "", "")
+	want = strings.Replace(docTestData, "# the server\r\n", "", 1)
+	if got := doc.String(); got != want {
+		t.Errorf("TDocument.String() =\n%q\nwant\n%q", got, want)
+	}
+} // TestTDocument_SetKeyComment()
+
+func TestTDocument_RemoveLine(t *testing.T) {
+	doc := ParseDocument([]byte(docTestData))
+	if got := doc.LineNumber("server", "list"); 9 != got {
+		t.Errorf("TDocument.LineNumber() = %d, want 9", got)
+	}
+	if !doc.RemoveLine(10) { // the continuation of `list`
+		t.Fatal("TDocument.RemoveLine() = false")
+	}
+	if doc.HasSectionKey("server", "list") {
+		t.Error("TDocument.RemoveLine() kept the key")
+	}
+
+	// removing a header merges the sections:
+	doc.RemoveLine(doc.LineNumber("server", ""))
+	if got, _ := doc.AsString("", "host"); "localhost" != got {
+		t.Errorf("TDocument.AsString() = %q, want %q", got, "localhost")
+	}
+	for _, num := range []int{0, 99} {
+		if doc.RemoveLine(num) {
+			t.Errorf("TDocument.RemoveLine(%d) = true", num)
+		}
+	}
+	if got := doc.LineNumber("empty", ""); 9 != got {
+		t.Errorf("TDocument.LineNumber() = %d, want 9", got)
+	}
+	if !doc.RemoveLine(10) { // the last line without line ending
+		t.Error("TDocument.RemoveLine() = false for the last line")
+	}
+} // TestTDocument_RemoveLine()
+
+func TestNewDocument(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "doc.ini")
+	_ = os.WriteFile(fName, []byte(docTestData), 0600)
+
+	doc, err := NewDocument(fName)
+	if nil != err {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	doc.AddSectionKey("server", "port", "443")
+	if _, err = doc.Store(); nil != err {
+		t.Fatalf("TDocument.Store() error = %v", err)
+	}
+	if doc.IsDirty() {
+		t.Error("TDocument.IsDirty() = true after Store()")
+	}
+	want := strings.Replace(docTestData, "  port=80", "  port=443", 1)
+	if data, _ := os.ReadFile(fName); string(data) != want {
+		t.Errorf("TDocument.Store() wrote %q, want %q", data, want)
+	}
+
+	if got, _ := doc.SectionList().AsInt("server", "port"); 443 != got {
+		t.Errorf("TDocument.SectionList() port = %d, want 443", got)
+	}
+
+	if _, err = NewDocument(filepath.Join(t.TempDir(), "missing.ini")); nil == err {
+		t.Error("NewDocument() expected an error for a missing file")
+	}
+} // TestNewDocument()
+
+/* _EoF_ */
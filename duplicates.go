@@ -0,0 +1,144 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TDuplicatePolicy` determines how keys occurring more than once
+	// in the same section of an INI file are handled while reading it.
+	TDuplicatePolicy int
+
+	// `tSeenKeys` holds the section/key pairs read so far.
+	tSeenKeys map[string]struct{}
+)
+
+const (
+	// `KeepLast` uses the value of a key's last occurrence (default).
+	KeepLast TDuplicatePolicy = iota
+
+	// `KeepFirst` uses the value of a key's first occurrence.
+	KeepFirst
+
+	// `ErrorOnDuplicate` makes reading the INI file fail.
+	ErrorOnDuplicate
+
+	// `CollectAsList` collects the values of all occurrences as a
+	// list which can be retrieved by `AsStringSlice()`.
+	CollectAsList
+)
+
+var (
+	// `ErrDuplicateKey` is returned by the `ErrorOnDuplicate` policy.
+	ErrDuplicateKey = errors.New("ini: duplicate key")
+)
+
+// `String()` returns the name of the policy.
+//
+// Returns:
+// - `string`: The policy's name.
+func (dp TDuplicatePolicy) String() string {
+	switch dp {
+	case KeepLast:
+		return "KeepLast"
+	case KeepFirst:
+		return "KeepFirst"
+	case ErrorOnDuplicate:
+		return "ErrorOnDuplicate"
+	case CollectAsList:
+		return "CollectAsList"
+	}
+
+	return fmt.Sprintf("TDuplicatePolicy(%d)", int(dp))
+} // String()
+
+// `addParsedKey()` adds a key/value pair read from the INI file to
+// `aSection` observing the list's duplicate key policy (or the rules
+// of its dialect).
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The key of the key/value pair to add.
+// - `aValue` The value of the key/value pair to add.
+// - `aLineNum` The number of the key's (first) line in the INI file.
+// - `aSeen` The section/key pairs read so far.
+//
+// Returns:
+// - `bool`: `true` if the key/value pair was stored, `false` otherwise.
+// - `error`: `ErrDuplicateKey` with the `ErrorOnDuplicate` policy.
+func (sl *TSectionList) addParsedKey(aSection, aKey, aValue string, aLineNum int, aSeen tSeenKeys) (rOK bool, rErr error) {
+	defer func() {
+		if rOK {
+			sl.setOrigin(aSection, aKey, sl.fName, aLineNum)
+		}
+	}()
+
+	switch {
+	case DialectSystemd == sl.dialect:
+		return sl.addUnitKey(aSection, aKey, aValue, aSeen), nil
+	case (DialectPHP == sl.dialect) && isPHPArrayKey(aKey):
+		return sl.addListKey(aSection, aKey, aValue, aSeen), nil
+	}
+
+	id := originID(aSection, aKey)
+	if _, dup := aSeen[id]; !dup {
+		aSeen[id] = struct{}{}
+		if CollectAsList == sl.dupPolicy {
+			// protect separators within the first value as well
+			aValue = joinList([]string{aValue}, DefListSeparator)
+		}
+		return sl.AddSectionKey(aSection, aKey, aValue), nil
+	}
+
+	switch sl.dupPolicy {
+	case KeepFirst:
+		return false, nil
+
+	case ErrorOnDuplicate:
+		return false, fmt.Errorf("%w: [%s] %s", ErrDuplicateKey, aSection, aKey)
+
+	case CollectAsList:
+		if old, ok := sl.GetSection(aSection).AsString(aKey); ok {
+			aValue = old + DefListSeparator + " " +
+				joinList([]string{aValue}, DefListSeparator)
+		}
+	}
+
+	return sl.AddSectionKey(aSection, aKey, aValue), nil
+} // addParsedKey()
+
+// `DuplicatePolicy()` returns the list's duplicate key policy.
+//
+// Returns:
+// - `TDuplicatePolicy`: The current policy.
+func (sl *TSectionList) DuplicatePolicy() TDuplicatePolicy {
+	return sl.dupPolicy
+} // DuplicatePolicy()
+
+// `SetDuplicatePolicy()` sets how keys occurring more than once in the
+// same section of an INI file are handled while reading it.
+//
+// Since the policy is applied while reading, it has to be set before
+// the INI data is loaded (see `Load()`).
+//
+// Parameters:
+// - `aPolicy` The policy to use.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetDuplicatePolicy(aPolicy TDuplicatePolicy) *TSectionList {
+	sl.dupPolicy = aPolicy
+
+	return sl
+} // SetDuplicatePolicy()
+
+/* _EoF_ */
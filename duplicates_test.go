@@ -0,0 +1,71 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetDuplicatePolicy(t *testing.T) {
+	const src = `[s]
+key = one, two
+other = x
+key = three
+[t]
+key = own
+[s]
+key = four
+`
+	tests := []struct {
+		name    string
+		policy  TDuplicatePolicy
+		want    string
+		wantErr error
+	}{
+		{"KeepLast", KeepLast, "four", nil},
+		{"KeepFirst", KeepFirst, "one, two", nil},
+		{"ErrorOnDuplicate", ErrorOnDuplicate, "", ErrDuplicateKey},
+		{"CollectAsList", CollectAsList, `one\, two, three, four`, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList().SetDuplicatePolicy(tt.policy)
+			if tt.policy != sl.DuplicatePolicy() {
+				t.Errorf("%q: DuplicatePolicy() = %v", tt.name, sl.DuplicatePolicy())
+			}
+			_, err := sl.read(bufio.NewScanner(strings.NewReader(src)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%q: read() error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if nil != err {
+				return
+			}
+			if got, _ := sl.AsString("s", "key"); tt.want != got {
+				t.Errorf("%q: AsString() = %q, want %q", tt.name, got, tt.want)
+			}
+			if got, _ := sl.AsString("t", "key"); "own" != got {
+				t.Errorf("%q: AsString() = %q, want %q", tt.name, got, "own")
+			}
+		})
+	}
+
+	sl := NewSectionList().SetDuplicatePolicy(CollectAsList)
+	_, _ = sl.read(bufio.NewScanner(strings.NewReader(src)))
+	want := []string{"one, two", "three", "four"}
+	if got, _ := sl.AsStringSlice("s", "key", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("AsStringSlice() = %q, want %q", got, want)
+	}
+} // TestTSectionList_SetDuplicatePolicy()
+
+/* _EoF_ */
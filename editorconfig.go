@@ -0,0 +1,328 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `EditorConfigFilename` is the name of EditorConfig files.
+	EditorConfigFilename = `.editorconfig`
+
+	// `maxGlobRange` is the largest numeric range `{n1..n2}` which is
+	// matched exactly; larger ranges match any integer.
+	maxGlobRange = 1 << 12
+)
+
+// Regular expressions to identify parts of an EditorConfig glob.
+var (
+	// match: {num1..num2}
+	isGlobRangeRE = regexp.MustCompile(`^([+-]?\d+)\.\.([+-]?\d+)$`)
+)
+
+// `globAlternatives()` splits the contents of a `{…}` glob group at
+// its top-level commas.
+//
+// Parameters:
+// - `aGroup` The group's contents (without braces).
+//
+// Returns:
+// - `[]string`: The alternatives (a single one if there's no comma).
+func globAlternatives(aGroup string) []string {
+	var result []string
+	depth, start := 0, 0
+	for idx := 0; idx < len(aGroup); idx++ {
+		switch aGroup[idx] {
+		case '\\':
+			idx++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if 0 == depth {
+				result = append(result, aGroup[start:idx])
+				start = idx + 1
+			}
+		}
+	}
+
+	return append(result, aGroup[start:])
+} // globAlternatives()
+
+// `globClose()` returns the index of the brace closing the group
+// opened at `aStart`.
+//
+// Parameters:
+// - `aGlob` The glob pattern.
+// - `aStart` The index of the opening brace.
+//
+// Returns:
+// - `int`: The index of the closing brace or `-1` if there's none.
+func globClose(aGlob string, aStart int) int {
+	depth := 0
+	for idx := aStart; idx < len(aGlob); idx++ {
+		switch aGlob[idx] {
+		case '\\':
+			idx++
+		case '{':
+			depth++
+		case '}':
+			if depth--; 0 == depth {
+				return idx
+			}
+		}
+	}
+
+	return -1
+} // globClose()
+
+// `globRange()` returns a regular expression matching the integers
+// between `aFrom` and `aTo`.
+//
+// Parameters:
+// - `aFrom` The first integer of the range.
+// - `aTo` The last integer of the range.
+//
+// Returns:
+// - `string`: The regular expression.
+func globRange(aFrom, aTo int) string {
+	if aFrom > aTo {
+		aFrom, aTo = aTo, aFrom
+	}
+	if maxGlobRange < aTo-aFrom {
+		return `[+-]?[0-9]+`
+	}
+	numbers := make([]string, 0, aTo-aFrom+1)
+	for num := aFrom; num <= aTo; num++ {
+		numbers = append(numbers, regexp.QuoteMeta(strconv.Itoa(num)))
+	}
+
+	return `(?:` + strings.Join(numbers, `|`) + `)`
+} // globRange()
+
+// `globToRegexp()` translates the EditorConfig glob `aGlob` into
+// (unanchored) regular expression syntax.
+//
+// Supported are `*` (any string without `/`), `**` (any string),
+// `?` (any character but `/`), `[name]` and `[!name]` (character
+// classes), `{s1,s2}` (alternatives), `{n1..n2}` (integer ranges),
+// and `\` escaping the following character.
+//
+// Parameters:
+// - `aGlob` The glob pattern to translate.
+//
+// Returns:
+// - `string`: The regular expression.
+func globToRegexp(aGlob string) string {
+	var sb strings.Builder
+
+	for idx := 0; idx < len(aGlob); idx++ {
+		switch aGlob[idx] {
+		case '\\':
+			if idx+1 < len(aGlob) {
+				idx++
+				sb.WriteString(regexp.QuoteMeta(aGlob[idx : idx+1]))
+			} else {
+				sb.WriteString(`\\`)
+			}
+
+		case '*':
+			if (idx+1 < len(aGlob)) && ('*' == aGlob[idx+1]) {
+				idx++
+				sb.WriteString(`.*`)
+			} else {
+				sb.WriteString(`[^/]*`)
+			}
+
+		case '?':
+			sb.WriteString(`[^/]`)
+
+		case '[':
+			end := strings.IndexByte(aGlob[idx+1:], ']')
+			if 0 > end {
+				sb.WriteString(`\[`)
+				break
+			}
+			class := aGlob[idx+1 : idx+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			idx += end + 1
+
+		case '{':
+			end := globClose(aGlob, idx)
+			if 0 > end {
+				sb.WriteString(`\{`)
+				break
+			}
+			group := aGlob[idx+1 : end]
+			if matches := isGlobRangeRE.FindStringSubmatch(group); nil != matches {
+				from, _ := strconv.Atoi(matches[1])
+				to, _ := strconv.Atoi(matches[2])
+				sb.WriteString(globRange(from, to))
+			} else if alts := globAlternatives(group); 1 < len(alts) {
+				for i, alt := range alts {
+					alts[i] = globToRegexp(alt)
+				}
+				sb.WriteString(`(?:` + strings.Join(alts, `|`) + `)`)
+			} else { // a single word in braces is taken literally
+				sb.WriteString(`\{` + globToRegexp(group) + `\}`)
+			}
+			idx = end
+
+		default:
+			sb.WriteString(regexp.QuoteMeta(aGlob[idx : idx+1]))
+		}
+	}
+
+	return sb.String()
+} // globToRegexp()
+
+// `editorConfigMatch()` checks whether `aPath` matches the glob
+// `aGlob` of an EditorConfig section header.
+//
+// A glob without a slash matches the file's basename in any directory
+// while a glob containing a slash is relative to the directory of the
+// `.editorconfig` file.
+//
+// Parameters:
+// - `aGlob` The section's glob pattern.
+// - `aPath` The slash separated path relative to the `.editorconfig`.
+//
+// Returns:
+// - `bool`: `true` if `aPath` matches `aGlob`, `false` otherwise.
+func editorConfigMatch(aGlob, aPath string) bool {
+	var pattern string
+	if strings.Contains(aGlob, "/") {
+		pattern = `^` + globToRegexp(strings.TrimPrefix(aGlob, "/")) + `$`
+	} else {
+		pattern = `^(?:.*/)?` + globToRegexp(aGlob) + `$`
+	}
+	re, err := regexp.Compile(pattern)
+	if nil != err {
+		return false
+	}
+
+	return re.MatchString(aPath)
+} // editorConfigMatch()
+
+// `IsEditorConfigRoot()` checks whether the list's preamble (i.e. its
+// default section) contains `root = true`.
+//
+// Returns:
+// - `bool`: `true` if the list is a root EditorConfig, `false` otherwise.
+func (sl *TSectionList) IsEditorConfigRoot() bool {
+	value, _ := sl.AsString("", "root")
+
+	return strings.EqualFold("true", value)
+} // IsEditorConfigRoot()
+
+// `ResolveFor()` returns the EditorConfig properties which apply to
+// the file `aPath`.
+//
+// All sections whose glob pattern matches `aPath` are merged in the
+// order they appear in the list, so later sections take precedence.
+// The preamble (e.g. `root = true`) is not part of the result.
+// An absolute `aPath` is taken relative to the directory of the list's
+// file (see `SetFilename()`) while a relative `aPath` is expected to be
+// relative to that directory already.
+//
+// Parameters:
+// - `aPath` The name of the file to get the properties for.
+//
+// Returns:
+// - `*TSection`: The properties for `aPath` (possibly empty).
+func (sl *TSectionList) ResolveFor(aPath string) *TSection {
+	result := NewSection()
+
+	if filepath.IsAbs(aPath) && ("" != sl.fName) {
+		dir, err := filepath.Abs(filepath.Dir(sl.fName))
+		if nil != err {
+			return result
+		}
+		if aPath, err = filepath.Rel(dir, aPath); nil != err {
+			return result
+		}
+		if (".." == aPath) || strings.HasPrefix(aPath, ".."+string(filepath.Separator)) {
+			return result // outside of the list's directory
+		}
+	}
+	aPath = strings.TrimPrefix(filepath.ToSlash(aPath), "./")
+
+	for _, ns := range sl.OrderedSections() {
+		if ns.Name == sl.defSect {
+			continue
+		}
+		if editorConfigMatch(ns.Name, aPath) {
+			result.Merge(ns.Section)
+		}
+	}
+
+	return result
+} // ResolveFor()
+
+// `ResolveEditorConfig()` returns the EditorConfig properties which
+// apply to the file `aPath`.
+//
+// Starting in the directory of `aPath` all `.editorconfig` files up to
+// the filesystem's root are read until one contains `root = true`.
+// The properties of files closer to `aPath` take precedence.
+//
+// Parameters:
+// - `aPath` The name of the file to get the properties for.
+//
+// Returns:
+// - `*TSection`: The properties for `aPath` (possibly empty).
+// - `error`: A possible error reading an `.editorconfig` file.
+func ResolveEditorConfig(aPath string) (*TSection, error) {
+	path, err := filepath.Abs(aPath)
+	if nil != err {
+		return nil, err
+	}
+
+	var found []*TSection // nearest first
+	for dir := filepath.Dir(path); ; {
+		fName := filepath.Join(dir, EditorConfigFilename)
+		list, err := NewSectionList().
+			SetDialect(DialectEditorConfig).
+			SetFilename(fName).
+			Load()
+		if nil == err {
+			found = append(found, list.ResolveFor(path))
+			if list.IsEditorConfigRoot() {
+				break
+			}
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	result := NewSection()
+	for idx := len(found) - 1; 0 <= idx; idx-- {
+		result.Merge(found[idx])
+	}
+
+	return result, nil
+} // ResolveEditorConfig()
+
+/* _EoF_ */
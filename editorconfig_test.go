@@ -0,0 +1,133 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_editorConfigMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		glob string
+		path string
+		want bool
+	}{
+		{"1", "*", "main.go", true},
+		{"2", "*", "cmd/app/main.go", true},
+		{"3", "*.go", "cmd/main.go", true},
+		{"4", "*.{js,py}", "lib/x.py", true},
+		{"5", "*.{js,py}", "lib/x.go", false},
+		{"6", "lib/*.js", "lib/x.js", true},
+		{"7", "lib/*.js", "lib/sub/x.js", false},
+		{"8", "lib/**.js", "lib/sub/x.js", true},
+		{"9", "/Makefile", "Makefile", true},
+		{"10", "/Makefile", "sub/Makefile", false},
+		{"11", "*.[ch]", "x.h", true},
+		{"12", "*.[!ch]", "x.h", false},
+		{"13", "file{1..3}.txt", "file2.txt", true},
+		{"14", "file{1..3}.txt", "file4.txt", false},
+		{"15", "{single}.txt", "{single}.txt", true},
+		{"16", "a?c", "abc", true},
+		{"17", "a?c", "a/c", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := editorConfigMatch(tt.glob, tt.path); got != tt.want {
+				t.Errorf("editorConfigMatch(%q, %q) = %v, want %v",
+					tt.glob, tt.path, got, tt.want)
+			}
+		})
+	}
+} // Test_editorConfigMatch()
+
+func TestTSectionList_ResolveFor(t *testing.T) {
+	src := `root = true
+
+[*]
+indent_style = space
+indent_size = 4
+
+[*.{go,mod}]
+indent_style = tab
+
+[Makefile]
+indent_style = tab
+
+[docs/**.[mM][dD]]
+indent_size = 2
+`
+	sl := NewSectionList().SetDialect(DialectEditorConfig)
+	if _, err := sl.read(sl.newScanner(context.Background(), strings.NewReader(src))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+	if !sl.IsEditorConfigRoot() {
+		t.Error("IsEditorConfigRoot() = false, want true")
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		style  string
+		indent string
+	}{
+		{"1", "main.go", "tab", "4"},
+		{"2", "README.txt", "space", "4"},
+		{"3", "sub/Makefile", "tab", "4"},
+		{"4", "docs/api/index.MD", "space", "2"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kl := sl.ResolveFor(tt.path)
+			if got, _ := kl.AsString("indent_style"); got != tt.style {
+				t.Errorf("ResolveFor(%q) indent_style = %q, want %q", tt.path, got, tt.style)
+			}
+			if got, _ := kl.AsString("indent_size"); got != tt.indent {
+				t.Errorf("ResolveFor(%q) indent_size = %q, want %q", tt.path, got, tt.indent)
+			}
+			if kl.HasKey("root") {
+				t.Errorf("ResolveFor(%q) contains the preamble", tt.path)
+			}
+		})
+	}
+} // TestTSectionList_ResolveFor()
+
+func TestResolveEditorConfig(t *testing.T) {
+	top := t.TempDir()
+	proj := filepath.Join(top, "proj")
+	sub := filepath.Join(proj, "sub")
+	_ = os.MkdirAll(sub, 0700)
+	_ = os.WriteFile(filepath.Join(top, EditorConfigFilename),
+		[]byte("[*]\ncharset = latin1\n"), 0600)
+	_ = os.WriteFile(filepath.Join(proj, EditorConfigFilename),
+		[]byte("root = true\n[*]\ncharset = utf-8\nindent_size = 4\n"), 0600)
+	_ = os.WriteFile(filepath.Join(sub, EditorConfigFilename),
+		[]byte("[*.go]\nindent_size = 8\n"), 0600)
+
+	kl, err := ResolveEditorConfig(filepath.Join(sub, "main.go"))
+	if nil != err {
+		t.Fatalf("ResolveEditorConfig() error = %v", err)
+	}
+	for key, want := range map[string]string{
+		"charset":     "utf-8", // the root file hides the top one
+		"indent_size": "8",     // the nearest file takes precedence
+	} {
+		if got, _ := kl.AsString(key); got != want {
+			t.Errorf("ResolveEditorConfig() %s = %q, want %q", key, got, want)
+		}
+	}
+} // TestResolveEditorConfig()
+
+/* _EoF_ */
@@ -0,0 +1,168 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TCompareOptions` determine how `Equal()` compares two lists.
+	//
+	// The zero value compares the sections and keys regardless of
+	// their order (like `CompareTo()`).
+	TCompareOptions struct {
+		DefaultSection bool          // compare the default sections' names
+		IgnoreCase     bool          // compare section names and keys case-insensitively
+		IgnoreKeys     []TSectionKey // keys to skip (an empty section matches all sections)
+		IgnoreSections []string      // sections to skip
+		Order          bool          // compare the order of sections and keys
+	}
+
+	// `tCompareSection` is a section prepared for comparison.
+	tCompareSection struct {
+		name string
+		data []TKeyVal
+	}
+)
+
+// `ignores()` reports whether `aKey` in `aSection` is to be skipped.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `bool`: `true` if the key is to be skipped, `false` otherwise.
+func (co *TCompareOptions) ignores(aSection, aKey string) bool {
+	for _, sk := range co.IgnoreKeys {
+		if co.same(sk.Key, aKey) && (("" == sk.Section) || co.same(sk.Section, aSection)) {
+			return true
+		}
+	}
+
+	return false
+} // ignores()
+
+// `name()` returns `aName` prepared for comparison.
+//
+// Parameters:
+// - `aName` The section's or key's name.
+//
+// Returns:
+// - `string`: The name to compare.
+func (co *TCompareOptions) name(aName string) string {
+	if co.IgnoreCase {
+		return strings.ToLower(aName)
+	}
+
+	return aName
+} // name()
+
+// `same()` reports whether both names are equal.
+//
+// Parameters:
+// - `aName1` The first name to compare.
+// - `aName2` The second name to compare.
+//
+// Returns:
+// - `bool`: `true` if the names are equal, `false` otherwise.
+func (co *TCompareOptions) same(aName1, aName2 string) bool {
+	if co.IgnoreCase {
+		return strings.EqualFold(aName1, aName2)
+	}
+
+	return aName1 == aName2
+} // same()
+
+// --------------------------------------------------------------------------
+
+// `compareView()` returns the list's sections and keys prepared for
+// comparison observing `aOptions`.
+//
+// Parameters:
+// - `aOptions` The comparison's options.
+//
+// Returns:
+// - `[]tCompareSection`: The sections to compare.
+func (sl *TSectionList) compareView(aOptions *TCompareOptions) []tCompareSection {
+	sections := sl.OrderedSections()
+	result := make([]tCompareSection, 0, len(sections))
+	for _, ns := range sections {
+		name, kl := ns.Name, ns.Section
+		if slices.ContainsFunc(aOptions.IgnoreSections, func(aName string) bool {
+			return aOptions.same(aName, name)
+		}) {
+			continue
+		}
+
+		section := tCompareSection{name: aOptions.name(name)}
+		for _, kv := range kl.KeyVals() {
+			if !aOptions.ignores(name, kv.Key) {
+				section.data = append(section.data, TKeyVal{aOptions.name(kv.Key), kv.Value})
+			}
+		}
+		if !aOptions.Order {
+			sort.SliceStable(section.data, func(i, j int) bool {
+				return section.data[i].Key < section.data[j].Key
+			})
+		}
+		result = append(result, section)
+	}
+	if !aOptions.Order {
+		sort.SliceStable(result, func(i, j int) bool {
+			return result[i].name < result[j].name
+		})
+	}
+
+	return result
+} // compareView()
+
+// `Equal()` reports whether the current list and `aINI` hold the same
+// sections and key/value pairs observing `aOptions`.
+//
+// Other than `CompareTo()` this method can take the order of sections
+// and keys into account, compare names case-insensitively, and skip
+// sections and keys which are expected to differ (e.g. timestamps):
+//
+//	equal := sl.Equal(other, ini.TCompareOptions{
+//		IgnoreKeys: []ini.TSectionKey{{Key: "lastRun"}},
+//		Order:      true,
+//	})
+//
+// The values are always compared case-sensitively; comments are
+// ignored.
+//
+// Parameters:
+// - `aINI` The list to compare with the current one.
+// - `aOptions` The comparison's options.
+//
+// Returns:
+// - `bool`: `true` if both lists are equal, `false` otherwise.
+func (sl *TSectionList) Equal(aINI *TSectionList, aOptions TCompareOptions) bool {
+	if nil == aINI {
+		return false
+	}
+	if sl == aINI {
+		return true
+	}
+	if aOptions.DefaultSection && !aOptions.same(sl.defSect, aINI.defSect) {
+		return false
+	}
+
+	return slices.EqualFunc(sl.compareView(&aOptions), aINI.compareView(&aOptions),
+		func(aSection1, aSection2 tCompareSection) bool {
+			return (aSection1.name == aSection2.name) &&
+				slices.Equal(aSection1.data, aSection2.data)
+		})
+} // Equal()
+
+/* _EoF_ */
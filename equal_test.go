@@ -0,0 +1,72 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "testing"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Equal(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("app", "name", "myApp")
+	sl.AddSectionKey("app", "lastRun", "2024-01-01")
+	sl.AddSectionKey("db", "host", "localhost")
+
+	reordered := NewSectionList()
+	reordered.AddSectionKey("db", "host", "localhost")
+	reordered.AddSectionKey("app", "lastRun", "2024-01-01")
+	reordered.AddSectionKey("app", "name", "myApp")
+
+	other := NewSectionList()
+	other.AddSectionKey("APP", "Name", "myApp")
+	other.AddSectionKey("APP", "lastRun", "2024-12-31")
+	other.AddSectionKey("DB", "host", "localhost")
+	other.AddSectionKey("cache", "size", "10")
+
+	tests := []struct {
+		name    string
+		list    *TSectionList
+		options TCompareOptions
+		want    bool
+	}{
+		{"1", sl, TCompareOptions{}, true},
+		{"2", reordered, TCompareOptions{}, true},
+		{"3", reordered, TCompareOptions{Order: true}, false},
+		{"4", sl.copyList(), TCompareOptions{Order: true}, true},
+		{"5", other, TCompareOptions{}, false},
+		{"6", other, TCompareOptions{IgnoreCase: true}, false},
+		{"7", other, TCompareOptions{
+			IgnoreCase:     true,
+			IgnoreKeys:     []TSectionKey{{Key: "lastrun"}},
+			IgnoreSections: []string{"cache"},
+		}, true},
+		{"8", other, TCompareOptions{
+			IgnoreKeys:     []TSectionKey{{Section: "APP", Key: "lastRun"}},
+			IgnoreSections: []string{"cache"},
+		}, false},
+		{"9", nil, TCompareOptions{}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.Equal(tt.list, tt.options); got != tt.want {
+				t.Errorf("%q: TSectionList.Equal() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	empty, renamed := NewSectionList(), NewSectionList().SetDefaultSection("other")
+	if !empty.Equal(renamed, TCompareOptions{}) {
+		t.Error("TSectionList.Equal() = false, want true")
+	}
+	if empty.Equal(renamed, TCompareOptions{DefaultSection: true}) {
+		t.Error("TSectionList.Equal() = true for different default sections")
+	}
+} // TestTSectionList_Equal()
+
+/* _EoF_ */
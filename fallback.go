@@ -0,0 +1,70 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `AsStringFallback()` returns the value of `aKey` in `aSection` or,
+// if `aKey` is missing in that section, the value of `aKey` in the
+// default section.
+//
+// This implements the semantics of Python's ConfigParser `[DEFAULT]`
+// section for a single lookup; see `SetDefaultFallback()` to apply it
+// to all lookups.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsStringFallback(aSection, aKey string) (string, bool) {
+	_, value, err := sl.lookupFallback(aSection, aKey, true)
+
+	return value, (nil == err)
+} // AsStringFallback()
+
+// `DefaultFallback()` reports whether keys missing in a section fall
+// back to the default section.
+//
+// Returns:
+// - `bool`: `true` if the fallback is enabled, `false` otherwise.
+func (sl *TSectionList) DefaultFallback() bool {
+	return sl.fallback
+} // DefaultFallback()
+
+// `SetDefaultFallback()` sets whether a key missing in an existing
+// section falls back to the default section's value.
+//
+// With this option enabled all the list's `AsXxx()` and `GetXxx()`
+// methods behave like Python's ConfigParser with its `[DEFAULT]`
+// section, i.e. the default section provides the values common to all
+// sections.
+//
+// Example:
+//
+//	; INI file
+//	timeout = 30s
+//	[server]
+//	port = 8080
+//
+//	sl.SetDefaultFallback(true)
+//	d, _ := sl.AsDuration("server", "timeout") // 30s
+//
+// Parameters:
+// - `aFallback` Whether to fall back to the default section.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetDefaultFallback(aFallback bool) *TSectionList {
+	sl.fallback = aFallback
+
+	return sl
+} // SetDefaultFallback()
+
+/* _EoF_ */
@@ -0,0 +1,77 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepFallbackList() *TSectionList {
+	sl := NewSectionList()
+	sl.AddSectionKey("", "timeout", "30s")
+	sl.AddSectionKey("", "host", "localhost")
+	sl.AddSectionKey("server", "host", "example.com")
+
+	return sl
+} // prepFallbackList()
+
+func TestTSectionList_AsStringFallback(t *testing.T) {
+	sl := prepFallbackList()
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    string
+		wantOK  bool
+	}{
+		{"own value", "server", "host", "example.com", true},
+		{"fallback", "server", "timeout", "30s", true},
+		{"default section", "", "timeout", "30s", true},
+		{"missing key", "server", "port", "", false},
+		{"missing section", "db", "timeout", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sl.AsStringFallback(tt.section, tt.key)
+			if (got != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("AsStringFallback() = %q, %v, want %q, %v",
+					got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	// without the mode flag the list's lookups don't fall back
+	if _, ok := sl.AsString("server", "timeout"); ok {
+		t.Error("AsString() fell back without SetDefaultFallback()")
+	}
+} // TestTSectionList_AsStringFallback()
+
+func TestTSectionList_SetDefaultFallback(t *testing.T) {
+	sl := prepFallbackList().SetDefaultFallback(true)
+	if !sl.DefaultFallback() {
+		t.Error("DefaultFallback() = false, want true")
+	}
+
+	if d, ok := sl.AsDuration("server", "timeout"); !ok || (30*time.Second != d) {
+		t.Errorf("AsDuration() = %v, %v, want 30s, true", d, ok)
+	}
+	if host, _ := sl.AsString("server", "host"); "example.com" != host {
+		t.Errorf("AsString() = %q, want %q", host, "example.com")
+	}
+
+	sl.SetDefaultFallback(false)
+	if _, ok := sl.AsDuration("server", "timeout"); ok {
+		t.Error("AsDuration() fell back after SetDefaultFallback(false)")
+	}
+} // TestTSectionList_SetDefaultFallback()
+
+/* _EoF_ */
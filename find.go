@@ -0,0 +1,84 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"path"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TMatch` is a key/value pair found by `Find()`.
+	TMatch struct {
+		Section string // name of the INI section
+		Key     string // name of the key
+		Value   string // the key's value
+	}
+)
+
+// `Find()` returns all section/key/value triples matching `aPattern`.
+//
+// The pattern consists of a section pattern and a key pattern
+// separated by the last slash, e.g. `server-*/port` or `*/loglevel`;
+// a pattern without a slash matches the keys of the default section.
+// Both parts use the wildcards of `path.Match()` (i.e. `*`, `?`, and
+// character classes like `[0-9]`).
+//
+// The matches are returned in the order of the sections and keys in
+// the INI file; the values are resolved like by `AsString()`.
+//
+// Example:
+//
+//	for _, m := range sl.Find("server-*/port") {
+//		fmt.Println(m.Section, m.Value)
+//	}
+//
+// Parameters:
+// - `aPattern` The pattern to match the section/key pairs against.
+//
+// Returns:
+// - `[]TMatch`: A list of the matches (`nil` for a malformed pattern).
+func (sl *TSectionList) Find(aPattern string) []TMatch {
+	secPattern, keyPattern := sl.defSect, strings.TrimSpace(aPattern)
+	if idx := strings.LastIndex(keyPattern, "/"); 0 <= idx {
+		secPattern, keyPattern = strings.TrimSpace(keyPattern[:idx]), strings.TrimSpace(keyPattern[idx+1:])
+		if "" == secPattern {
+			secPattern = sl.defSect
+		}
+	}
+	// check the patterns once for errors
+	if _, err := path.Match(secPattern, ""); nil != err {
+		return nil
+	}
+	if _, err := path.Match(keyPattern, ""); nil != err {
+		return nil
+	}
+
+	var result []TMatch
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		if ok, _ := path.Match(secPattern, name); !ok {
+			continue
+		}
+		for _, key := range kl.Keys() {
+			if ok, _ := path.Match(keyPattern, key); !ok {
+				continue
+			}
+			_, value, err := sl.resolveValue(name, key)
+			if nil != err {
+				value, _ = kl.AsString(key)
+			}
+			result = append(result, TMatch{Section: name, Key: key, Value: value})
+		}
+	}
+
+	return result
+} // Find()
+
+/* _EoF_ */
@@ -0,0 +1,52 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Find(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("", "loglevel", "info")
+	sl.AddSectionKey("server-1", "port", "8081")
+	sl.AddSectionKey("server-1", "loglevel", "debug")
+	sl.AddSectionKey("server-2", "port", "8082")
+	sl.AddSectionKey("db", "port", "5432")
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []TMatch
+	}{
+		{"instances", "server-*/port", []TMatch{
+			{"server-1", "port", "8081"},
+			{"server-2", "port", "8082"},
+		}},
+		{"all sections", "*/loglevel", []TMatch{
+			{DefSection, "loglevel", "info"},
+			{"server-1", "loglevel", "debug"},
+		}},
+		{"default section", "log*", []TMatch{{DefSection, "loglevel", "info"}}},
+		{"class", "server-[2-9]/*", []TMatch{{"server-2", "port", "8082"}}},
+		{"no match", "cache/*", nil},
+		{"bad pattern", "[/port", nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.Find(tt.pattern); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_Find()
+
+/* _EoF_ */
@@ -0,0 +1,261 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TFormatOptions` determine the layout of the INI data written by
+	// `String()`, `Store()`, and `WriteTo()`.
+	//
+	// The zero value produces the package's default layout:
+	//
+	//	[section]
+	//	key = value
+	//
+	// Lines end with a LF unless `CRLF` is set or the INI file read
+	// used mostly CR/LF line endings (and `LF` isn't set).
+	TFormatOptions struct {
+		AlignEquals       bool   // align the `=` of all keys in a section
+		CompactEquals     bool   // write `key=value` instead of `key = value`
+		CRLF              bool   // use CR/LF instead of LF line endings
+		Indent            string // prefix of key/value (and comment) lines
+		LF                bool   // use LF even if the INI file read used CR/LF
+		NoSectionGap      bool   // omit the blank line before section headers
+		NoTrailingNewline bool   // omit the line ending after the last line
+		SortKeys          bool   // emit the keys sorted by name
+	}
+)
+
+// `format()` writes the key/value pairs of the section to `aBuilder`.
+//
+// Parameters:
+// - `aBuilder` The builder to write to.
+// - `aOptions` The layout to use.
+// - `aDialect` The syntax variant to write.
+func (kl *TSection) format(aBuilder *strings.Builder, aOptions *TFormatOptions, aDialect TDialect) {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	data := kl.data
+	if aOptions.SortKeys {
+		data = *data.copy()
+		sort.SliceStable(data, func(i, j int) bool {
+			return data[i].Key < data[j].Key
+		})
+	}
+
+	width := 0
+	if aOptions.AlignEquals {
+		for _, kv := range data {
+			if width < len(kv.Key) {
+				width = len(kv.Key)
+			}
+		}
+	}
+	equals := " ="
+	if aOptions.CompactEquals {
+		equals = "="
+	}
+
+	for _, kv := range data {
+		for _, line := range kl.comments[kv.Key] {
+			if "" != line {
+				aBuilder.WriteString(aOptions.Indent)
+			}
+			aBuilder.WriteString(line)
+			aBuilder.WriteByte('\n')
+		}
+		values := []string{kv.Value}
+		if repeatsKey(aDialect, kv.Key) {
+			values = strings.Split(kv.Value, "\n")
+		}
+		for _, value := range values {
+			aBuilder.WriteString(aOptions.Indent)
+			aBuilder.WriteString(kv.Key)
+			if pad := width - len(kv.Key); 0 < pad {
+				aBuilder.WriteString(strings.Repeat(" ", pad))
+			}
+			aBuilder.WriteString(equals)
+			if "" != value {
+				if !aOptions.CompactEquals {
+					aBuilder.WriteByte(' ')
+				}
+				aBuilder.WriteString(blockValue(dialectValue(aDialect, value)))
+			}
+			aBuilder.WriteByte('\n')
+		}
+	}
+} // format()
+
+// --------------------------------------------------------------------------
+
+// `Format()` returns a string representation of the INI section list
+// using the given layout.
+//
+// The result is cached until the list is modified (see
+// `InvalidateCache()`).
+//
+// Parameters:
+// - `aOptions` The layout to use.
+//
+// Returns:
+// - `string`: The string representation of the INI section list.
+func (sl *TSectionList) Format(aOptions TFormatOptions) string {
+	defer sl.rlock()()
+
+	key, result, ok := sl.cached(&aOptions)
+	if ok {
+		return result
+	}
+
+	var sb strings.Builder
+	sb.Grow(sl.size(&aOptions))
+	if sl.compactEquals() {
+		aOptions.CompactEquals = true
+	}
+	if sl.crlf && !aOptions.LF {
+		aOptions.CRLF = true // keep the line endings of the file read
+	}
+
+	// use the secOrder list to determine the order of sections
+	for _, name := range sl.secOrder {
+		kl, exists := sl.sections[name]
+		if !exists {
+			continue
+		}
+		if !aOptions.NoSectionGap {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(commentString(sl.comments[name]))
+		sb.WriteString("[" + name + "]\n")
+		kl.format(&sb, &aOptions, sl.dialect)
+	}
+	if 0 < len(sl.trailer) {
+		sb.WriteString("\n" + commentString(sl.trailer))
+	}
+
+	result = sb.String()
+	if aOptions.NoTrailingNewline {
+		result = strings.TrimSuffix(result, "\n")
+	}
+	if aOptions.CRLF {
+		result = strings.ReplaceAll(result, "\n", "\r\n")
+	}
+	sl.keepCache(key, result)
+
+	return result
+} // Format()
+
+// `size()` returns the estimated number of bytes needed for the
+// string representation of the list.
+//
+// Parameters:
+// - `aOptions` The layout to use.
+//
+// Returns:
+// - `int`: The estimated size of `Format()`'s result.
+func (sl *TSectionList) size(aOptions *TFormatOptions) (rSize int) {
+	for name, kl := range sl.sections {
+		kl.mtx.RLock()
+		rSize += len(name) + 4 + kl.data.size() +
+			len(kl.data)*len(aOptions.Indent)
+		for _, lines := range kl.comments {
+			for _, line := range lines {
+				rSize += len(line) + 1
+			}
+		}
+		kl.mtx.RUnlock()
+	}
+
+	return
+} // size()
+
+// `FormatOptions()` returns the layout used by `String()`, `Store()`,
+// and `WriteTo()`.
+//
+// Returns:
+// - `TFormatOptions`: The list's current layout.
+func (sl *TSectionList) FormatOptions() TFormatOptions {
+	return sl.fmtOpts
+} // FormatOptions()
+
+// `SetFormatOptions()` sets the layout used by `String()`, `Store()`,
+// and `WriteTo()`.
+//
+// Parameters:
+// - `aOptions` The layout to use.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetFormatOptions(aOptions TFormatOptions) *TSectionList {
+	sl.fmtOpts = aOptions
+
+	return sl
+} // SetFormatOptions()
+
+// `MarshalText()` implements the `encoding.TextMarshaler` interface.
+//
+// The list is represented by its INI data using the list's layout
+// (see `SetFormatOptions()`).
+//
+// Returns:
+// - `[]byte`: The INI data of the list.
+// - `error`: Always `nil`.
+func (sl *TSectionList) MarshalText() ([]byte, error) {
+	return []byte(sl.String()), nil
+} // MarshalText()
+
+// `UnmarshalText()` implements the `encoding.TextUnmarshaler` interface.
+//
+// The current list's data is replaced by the INI data read from `aText`
+// using the list's settings (e.g. its dialect or parse limits).
+//
+// Parameters:
+// - `aText` The INI data to read.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) UnmarshalText(aText []byte) error {
+	if sl.readOnly {
+		return ErrReadOnly
+	}
+	if "" == sl.defSect {
+		sl.defSect = DefSection
+	}
+	sl.Clear()
+	_, err := sl.read(sl.newScanner(context.Background(), bytes.NewReader(aText)))
+
+	return err
+} // UnmarshalText()
+
+// `WriteTo()` writes the INI data to `aWriter` using the list's layout
+// (see `SetFormatOptions()`).
+//
+// This method implements the `io.WriterTo` interface.
+//
+// Parameters:
+// - `aWriter` The writer to use.
+//
+// Returns:
+// - `int64`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) WriteTo(aWriter io.Writer) (int64, error) {
+	n, err := io.WriteString(aWriter, sl.String())
+
+	return int64(n), err
+} // WriteTo()
+
+/* _EoF_ */
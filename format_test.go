@@ -0,0 +1,200 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepFormatList() *TSectionList {
+	sl := NewSectionList()
+	sl.AddSectionKey("one", "name", "value")
+	sl.AddSectionKey("one", "x", "")
+	sl.AddSectionKey("two", "longer", "2")
+
+	return sl
+} // prepFormatList()
+
+func TestTSectionList_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TFormatOptions
+		want string
+	}{
+		{"0", TFormatOptions{},
+			"\n[one]\nname = value\nx =\n\n[two]\nlonger = 2\n"},
+		{"1", TFormatOptions{CompactEquals: true},
+			"\n[one]\nname=value\nx=\n\n[two]\nlonger=2\n"},
+		{"2", TFormatOptions{AlignEquals: true},
+			"\n[one]\nname = value\nx    =\n\n[two]\nlonger = 2\n"},
+		{"3", TFormatOptions{Indent: "\t", NoSectionGap: true},
+			"[one]\n\tname = value\n\tx =\n[two]\n\tlonger = 2\n"},
+		{"4", TFormatOptions{NoTrailingNewline: true, CRLF: true},
+			"\r\n[one]\r\nname = value\r\nx =\r\n\r\n[two]\r\nlonger = 2"},
+		{"5", TFormatOptions{SortKeys: true, NoSectionGap: true},
+			"[one]\nname = value\nx =\n[two]\nlonger = 2\n"},
+		// TODO: Add test cases.
+	}
+	sl := prepFormatList()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.Format(tt.opts); got != tt.want {
+				t.Errorf("%q: TSectionList.Format() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_Format()
+
+func TestTSectionList_WriteTo(t *testing.T) {
+	sl := prepFormatList().SetFormatOptions(TFormatOptions{CompactEquals: true})
+	if got := sl.FormatOptions(); !got.CompactEquals {
+		t.Errorf("TSectionList.FormatOptions() = %+v", got)
+	}
+
+	var sb strings.Builder
+	n, err := sl.WriteTo(&sb)
+	if nil != err {
+		t.Fatalf("TSectionList.WriteTo() error = %v", err)
+	}
+	want := sl.String()
+	if (sb.String() != want) || (int64(len(want)) != n) {
+		t.Errorf("TSectionList.WriteTo() = %q (%d), want %q (%d)",
+			sb.String(), n, want, len(want))
+	}
+	if !strings.Contains(want, "name=value") {
+		t.Errorf("TSectionList.String() ignored format options: %q", want)
+	}
+} // TestTSectionList_WriteTo()
+
+func TestTSectionList_Format_lineEndings(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		opts TFormatOptions
+		want string
+	}{
+		{"lf", "[s]\nk = v\n", TFormatOptions{}, "\n[s]\nk = v\n"},
+		{"crlf", "[s]\r\nk = v\r\n", TFormatOptions{}, "\r\n[s]\r\nk = v\r\n"},
+		{"mostly crlf", "[s]\r\na = 1\r\nb = 2\n", TFormatOptions{},
+			"\r\n[s]\r\na = 1\r\nb = 2\r\n"},
+		{"forced lf", "[s]\r\nk = v\r\n", TFormatOptions{LF: true}, "\n[s]\nk = v\n"},
+		{"forced crlf", "[s]\nk = v\n", TFormatOptions{CRLF: true}, "\r\n[s]\r\nk = v\r\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList()
+			if _, err := sl.read(sl.newScanner(context.Background(), strings.NewReader(tt.data))); nil != err {
+				t.Fatalf("read() error = %v", err)
+			}
+			if got := sl.Format(tt.opts); got != tt.want {
+				t.Errorf("TSectionList.Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_Format_lineEndings()
+
+func TestTSectionList_MarshalText(t *testing.T) {
+	type tConfig struct {
+		Name string
+		INI  *TSectionList
+	}
+	cfg := tConfig{Name: "app", INI: prepFormatList()}
+
+	// `encoding/xml` uses the `encoding.TextMarshaler` interface:
+	data, err := xml.Marshal(cfg)
+	if nil != err {
+		t.Fatalf("xml.Marshal() error = %v", err)
+	}
+	var back tConfig
+	if err = xml.Unmarshal(data, &back); nil != err {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if !cfg.INI.CompareTo(back.INI) {
+		t.Errorf("TSectionList.UnmarshalText() =\n%s\nwant\n%s", back.INI, cfg.INI)
+	}
+
+	// the zero value is usable as well:
+	var sl TSectionList
+	if err = sl.UnmarshalText([]byte("[s]\nk = v\n")); nil != err {
+		t.Fatalf("TSectionList.UnmarshalText() error = %v", err)
+	}
+	if got, _ := sl.AsString("s", "k"); "v" != got {
+		t.Errorf("TSectionList.AsString() = %q, want %q", got, "v")
+	}
+} // TestTSectionList_MarshalText()
+
+func prepBenchList(aSections, aKeys int) *TSectionList {
+	sl := NewSectionList()
+	for s := 0; s < aSections; s++ {
+		section := fmt.Sprintf("section%03d", s)
+		for k := 0; k < aKeys; k++ {
+			sl.AddSectionKey(section, fmt.Sprintf("key%04d", k),
+				fmt.Sprintf("some value number %d", k))
+		}
+	}
+
+	return sl
+} // prepBenchList()
+
+// `concatString()` is the former string concatenating implementation
+// of `TSectionList.String()` used as a benchmark reference.
+func concatString(aList *TSectionList) (rString string) {
+	for _, name := range aList.secOrder {
+		if kl, exists := aList.sections[name]; exists {
+			rString += "\n" + commentString(aList.comments[name]) + "[" + name + "]\n"
+			for _, kv := range kl.data {
+				if "" == kv.Value {
+					rString += kv.Key + " =\n"
+				} else {
+					rString += kv.Key + " = " + blockValue(kv.Value) + "\n"
+				}
+			}
+		}
+	}
+
+	return
+} // concatString()
+
+func Benchmark_concatString(b *testing.B) {
+	sl := prepBenchList(50, 100)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = concatString(sl)
+	}
+} // Benchmark_concatString()
+
+func BenchmarkTSectionList_String(b *testing.B) {
+	sl := prepBenchList(50, 100)
+	if concatString(sl) != sl.String() {
+		b.Fatal("TSectionList.String() differs from reference")
+	}
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = sl.String()
+	}
+} // BenchmarkTSectionList_String()
+
+func Benchmark_tKeyValList_String(b *testing.B) {
+	kl := prepBenchList(1, 5000).GetSection("section000")
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = kl.data.String()
+	}
+} // Benchmark_tKeyValList_String()
+
+/* _EoF_ */
@@ -0,0 +1,296 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TFrozenConfig` is an immutable view of a section list.
+	//
+	// All values are resolved (i.e. decrypted, interpolated, and
+	// expanded as configured by the list) when the view is created by
+	// `Freeze()`; since the view never changes afterwards, it can be
+	// read concurrently without any locking.
+	TFrozenConfig struct {
+		aliases  tAliases                // deprecated names of keys
+		defSect  string                  // name of default section
+		fallback bool                    // missing keys fall back to the default section
+		keys     map[string]int          // number of keys per section
+		sections []string                // order of sections
+		values   map[string]tFrozenValue // resolved values by section/key
+	}
+
+	// `tFrozenValue` is a resolved value along with its pre-parsed
+	// representations.
+	tFrozenValue struct {
+		text    string        // the resolved value
+		asBool  bool          // the value as a boolean
+		isBool  bool          // whether the value is a valid boolean
+		asDur   time.Duration // the value as a duration
+		isDur   bool          // whether the value is a valid duration
+		asFloat float64       // the value as a floating point
+		isFloat bool          // whether the value is a valid float
+		asInt   int           // the value as an integer
+		isInt   bool          // whether the value is a valid integer
+	}
+)
+
+// `frozenValue()` returns the resolved `aValue` along with its
+// representations parsed like by the list's `AsXxx()` methods.
+//
+// Parameters:
+// - `aValue` The resolved value to parse.
+//
+// Returns:
+// - `tFrozenValue`: The parsed value.
+func (sl *TSectionList) frozenValue(aValue string) tFrozenValue {
+	result := tFrozenValue{text: aValue}
+	result.asBool, result.isBool = sl.parseBool(aValue)
+
+	if d, err := time.ParseDuration(aValue); nil == err {
+		result.asDur, result.isDur = d, true
+	}
+	if f64, err := strconv.ParseFloat(sl.floatNumber(aValue), 64); (nil == err) && (f64 == f64) {
+		result.asFloat, result.isFloat = f64, true
+	}
+	number, base := sl.intNumber(aValue)
+	if i64, err := strconv.ParseInt(number, base, 0); nil == err {
+		result.asInt, result.isInt = int(i64), true
+	}
+
+	return result
+} // frozenValue()
+
+// `value()` returns the resolved value of `aKey` in `aSection`.
+//
+// Like the list's lookups a key missing in `aSection` is looked up by
+// its deprecated name (see `TSectionList.RegisterAlias()`) and then,
+// if enabled for the list, in the default section.
+//
+// Parameters:
+// - `aSection` The name of the INI section (empty: default section).
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `tFrozenValue`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (fc *TFrozenConfig) value(aSection, aKey string) (tFrozenValue, bool) {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = fc.defSect
+	}
+	aKey = strings.TrimSpace(aKey)
+	if result, ok := fc.values[originID(aSection, aKey)]; ok {
+		return result, true
+	}
+	if old, ok := fc.aliases[originID(aSection, aKey)]; ok {
+		if result, ok := fc.values[originID(old.Section, old.Key)]; ok {
+			return result, true
+		}
+	}
+	if fc.fallback && (aSection != fc.defSect) {
+		result, ok := fc.values[originID(fc.defSect, aKey)]
+		return result, ok
+	}
+
+	return tFrozenValue{}, false
+} // value()
+
+// `AsBool()` returns the value of `aKey` in `aSection` as a boolean value.
+//
+// See `TSectionList.AsBool()` for the accepted values; the frozen
+// list's boolean vocabulary and dialect are observed.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `bool`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found and valid, `false` otherwise.
+func (fc *TFrozenConfig) AsBool(aSection, aKey string) (bool, bool) {
+	if value, ok := fc.value(aSection, aKey); ok {
+		return value.asBool, value.isBool
+	}
+
+	return false, false
+} // AsBool()
+
+// `AsDuration()` returns the value of `aKey` in `aSection` as a time
+// duration.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `time.Duration`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found and valid, `false` otherwise.
+func (fc *TFrozenConfig) AsDuration(aSection, aKey string) (time.Duration, bool) {
+	if value, ok := fc.value(aSection, aKey); ok {
+		return value.asDur, value.isDur
+	}
+
+	return time.Duration(0), false
+} // AsDuration()
+
+// `AsFloat64()` returns the value of `aKey` in `aSection` as a 64bit
+// floating point.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float64`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found and valid, `false` otherwise.
+func (fc *TFrozenConfig) AsFloat64(aSection, aKey string) (float64, bool) {
+	if value, ok := fc.value(aSection, aKey); ok {
+		return value.asFloat, value.isFloat
+	}
+
+	return float64(0), false
+} // AsFloat64()
+
+// `AsInt()` returns the value of `aKey` in `aSection` as an integer.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found and valid, `false` otherwise.
+func (fc *TFrozenConfig) AsInt(aSection, aKey string) (int, bool) {
+	if value, ok := fc.value(aSection, aKey); ok {
+		return value.asInt, value.isInt
+	}
+
+	return 0, false
+} // AsInt()
+
+// `AsString()` returns the value of `aKey` in `aSection` as a string.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (fc *TFrozenConfig) AsString(aSection, aKey string) (string, bool) {
+	value, ok := fc.value(aSection, aKey)
+
+	return value.text, ok
+} // AsString()
+
+// `HasSection()` checks whether `aSection` exists.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+//
+// Returns:
+// - `bool`: `true` if `aSection` exists, `false` otherwise.
+func (fc *TFrozenConfig) HasSection(aSection string) bool {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = fc.defSect
+	}
+	_, ok := fc.keys[aSection]
+
+	return ok
+} // HasSection()
+
+// `HasSectionKey()` checks whether `aKey` exists in `aSection`.
+//
+// Like `TSectionList.HasSectionKey()` neither deprecated key names
+// nor the default section are considered.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `bool`: `true` if `aKey` exists, `false` otherwise.
+func (fc *TFrozenConfig) HasSectionKey(aSection, aKey string) bool {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = fc.defSect
+	}
+	_, ok := fc.values[originID(aSection, strings.TrimSpace(aKey))]
+
+	return ok
+} // HasSectionKey()
+
+// `Len()` returns the number of sections.
+//
+// Returns:
+// - `int`: The number of sections.
+func (fc *TFrozenConfig) Len() int {
+	return len(fc.sections)
+} // Len()
+
+// `Sections()` returns the section names in their original order.
+//
+// Returns:
+// - `[]string`: A copy of the list of section names.
+func (fc *TFrozenConfig) Sections() []string {
+	return append([]string(nil), fc.sections...)
+} // Sections()
+
+// --------------------------------------------------------------------------
+
+// `Freeze()` returns an immutable view of the list's current data.
+//
+// All values are resolved and parsed once by the same means the list's
+// `AsXxx()` methods use (observing e.g. the list's boolean vocabulary
+// and dialect, or its number format); values which can't be resolved
+// (e.g. because of a missing interpolation reference) keep their raw
+// value. Looking up a value falls back to deprecated key names and the
+// default section like the list does (see `RegisterAlias()` and
+// `SetDefaultFallback()`) but without calling the list's hooks. Later
+// modifications of the list don't affect the returned view.
+//
+// Returns:
+// - `*TFrozenConfig`: The immutable view of the list.
+func (sl *TSectionList) Freeze() *TFrozenConfig {
+	sections := sl.OrderedSections()
+	result := &TFrozenConfig{
+		aliases:  maps.Clone(sl.aliases),
+		defSect:  sl.defSect,
+		fallback: sl.fallback,
+		keys:     make(map[string]int, len(sections)),
+		sections: make([]string, 0, len(sections)),
+		values:   make(map[string]tFrozenValue),
+	}
+
+	for _, ns := range sections {
+		name, kl := ns.Name, ns.Section
+		result.sections = append(result.sections, name)
+
+		kl.mtx.RLock()
+		raw := kl.data.copy()
+		kl.mtx.RUnlock()
+
+		result.keys[name] = len(*raw)
+		for _, kv := range *raw {
+			value := kv.Value
+			if _, resolved, err := sl.resolveValue(name, kv.Key); nil == err {
+				value = resolved
+			}
+			result.values[originID(name, kv.Key)] = sl.frozenValue(value)
+		}
+	}
+
+	return result
+} // Freeze()
+
+/* _EoF_ */
@@ -0,0 +1,149 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Freeze(t *testing.T) {
+	sl := NewSectionList().SetInterpolate(true)
+	sl.AddSectionKey("", "name", "myApp")
+	sl.AddSectionKey("server", "port", "8080")
+	sl.AddSectionKey("server", "debug", "yes")
+	sl.AddSectionKey("server", "timeout", "1m30s")
+	sl.AddSectionKey("server", "ratio", "0.75")
+	sl.AddSectionKey("server", "title", "%(name)s server")
+
+	fc := sl.Freeze()
+	sl.UpdateSectKeyStr("server", "port", "9090") // not seen by fc
+
+	if port, ok := fc.AsInt("server", "port"); !ok || (8080 != port) {
+		t.Errorf("AsInt() = %d, %v, want 8080, true", port, ok)
+	}
+	if debug, ok := fc.AsBool("server", "debug"); !ok || !debug {
+		t.Errorf("AsBool() = %v, %v, want true, true", debug, ok)
+	}
+	if d, ok := fc.AsDuration("server", "timeout"); !ok || (90*time.Second != d) {
+		t.Errorf("AsDuration() = %v, %v, want 1m30s, true", d, ok)
+	}
+	if f, ok := fc.AsFloat64("server", "ratio"); !ok || (0.75 != f) {
+		t.Errorf("AsFloat64() = %v, %v, want 0.75, true", f, ok)
+	}
+	if s, _ := fc.AsString("server", "title"); "myApp server" != s {
+		t.Errorf("AsString() = %q, want %q", s, "myApp server")
+	}
+	if s, _ := fc.AsString("", "name"); "myApp" != s {
+		t.Errorf("AsString() = %q, want %q", s, "myApp")
+	}
+	if fc.HasSectionKey("server", "missing") || !fc.HasSection("server") {
+		t.Error("HasSectionKey()/HasSection() returned wrong results")
+	}
+	if 2 != fc.Len() {
+		t.Errorf("Len() = %d, want 2", fc.Len())
+	}
+
+	// concurrent reads need no locking
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_, _ = fc.AsInt("server", "port")
+			}
+		}()
+	}
+	wg.Wait()
+} // TestTSectionList_Freeze()
+
+func TestTSectionList_Freeze_fallback(t *testing.T) {
+	sl := NewSectionList().SetDefaultFallback(true)
+	sl.AddSectionKey("", "timeout", "30s")
+	sl.AddSectionKey("server", "port", "8080")
+	sl.AddSectionKey("old", "host", "localhost")
+	sl.RegisterAlias("old", "host", "server", "host")
+
+	fc := sl.Freeze()
+	for _, tt := range []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"port", "8080", true},
+		{"timeout", "30s", true},
+		{"host", "localhost", true},
+		{"missing", "", false},
+		// TODO: Add test cases.
+	} {
+		want, wantOK := sl.AsString("server", tt.key)
+		if (want != tt.want) || (wantOK != tt.ok) {
+			t.Fatalf("TSectionList.AsString(%q) = %q, %v", tt.key, want, wantOK)
+		}
+		if got, ok := fc.AsString("server", tt.key); (got != want) || (ok != wantOK) {
+			t.Errorf("TFrozenConfig.AsString(%q) = %q, %v, want %q, %v",
+				tt.key, got, ok, want, wantOK)
+		}
+	}
+	if fc.HasSectionKey("server", "timeout") || fc.HasSectionKey("server", "host") {
+		t.Error("TFrozenConfig.HasSectionKey() considered the fallback")
+	}
+} // TestTSectionList_Freeze_fallback()
+
+func TestTSectionList_Freeze_bool(t *testing.T) {
+	sl := NewSectionList().SetBoolVocabulary([]string{"ja"}, []string{"nein"})
+	sl.AddSectionKey("", "on", "ja")
+	sl.AddSectionKey("", "off", "nein")
+	sl.AddSectionKey("", "legacy", "talisman")
+
+	fc := sl.Freeze()
+	if b, ok := fc.AsBool("", "on"); !ok || !b {
+		t.Errorf("AsBool(on) = %v, %v, want true, true", b, ok)
+	}
+	if b, ok := fc.AsBool("", "off"); !ok || b {
+		t.Errorf("AsBool(off) = %v, %v, want false, true", b, ok)
+	}
+	if b, ok := fc.AsBool("", "legacy"); ok {
+		t.Errorf("AsBool(legacy) = %v, %v, want false, false", b, ok)
+	}
+
+	sl = NewSectionList().SetDialect(DialectPHP)
+	sl.AddSectionKey("", "engine", "")
+	if b, ok := sl.Freeze().AsBool("", "engine"); !ok || b {
+		t.Errorf("AsBool(engine) = %v, %v, want false, true", b, ok)
+	}
+} // TestTSectionList_Freeze_bool()
+
+func TestTSectionList_Freeze_numbers(t *testing.T) {
+	sl := NewSectionList().SetLenientNumbers(true)
+	sl.AddSectionKey("", "hex", "0x1F")
+	sl.AddSectionKey("", "big", "1_000")
+	sl.AddSectionKey("", "ratio", "1.234,5")
+
+	fc := sl.Freeze()
+	if i, ok := fc.AsInt("", "hex"); !ok || (31 != i) {
+		t.Errorf("AsInt(hex) = %d, %v, want 31, true", i, ok)
+	}
+	if i, ok := fc.AsInt("", "big"); !ok || (1000 != i) {
+		t.Errorf("AsInt(big) = %d, %v, want 1000, true", i, ok)
+	}
+	if f, ok := fc.AsFloat64("", "ratio"); !ok || (1234.5 != f) {
+		t.Errorf("AsFloat64(ratio) = %v, %v, want 1234.5, true", f, ok)
+	}
+
+	sl = NewSectionList().SetDialect(DialectPHP)
+	sl.AddSectionKey("", "error_reporting", "E_ALL & ~E_DEPRECATED")
+	if i, ok := sl.Freeze().AsInt("", "error_reporting"); !ok || (32767&^8192 != i) {
+		t.Errorf("AsInt(error_reporting) = %d, %v, want %d, true", i, ok, 32767&^8192)
+	}
+} // TestTSectionList_Freeze_numbers()
+
+/* _EoF_ */
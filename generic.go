@@ -0,0 +1,160 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `Get()` returns the value of `aKey` in `aSection` converted to the
+// type `T`.
+//
+// Converters registered by `RegisterType()` take precedence. Otherwise
+// booleans, base64 encoded byte slices, all integer and floating point
+// types, strings, `time.Duration`, and `time.Time` are handled by the
+// respective `GetXxx()` methods of `aList`. Types implementing the
+// `encoding.TextUnmarshaler` interface parse the value themselves,
+// and other named types are converted according to their underlying
+// kind (e.g. a `type Port uint16` like an `uint16`).
+//
+//	port, err := ini.Get[uint16](sl, "server", "port")
+//
+// Parameters:
+// - `aList` The section list to use.
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `T`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`,
+// `ErrUnsupportedType`, or `nil`.
+func Get[T any](aList *TSectionList, aSection, aKey string) (T, error) {
+	var (
+		err    error
+		result T
+	)
+	target := reflect.ValueOf(&result).Elem()
+	if _, registered := converterFor(target.Type()); registered {
+		err = getConverted(aList, aSection, aKey, target)
+	} else {
+		err = getBuiltin(aList, aSection, aKey, &result)
+	}
+	if nil != err {
+		var zero T
+		return zero, err
+	}
+
+	return result, nil
+} // Get()
+
+// `getBuiltin()` converts the value of `aKey` in `aSection` by the
+// list's respective `GetXxx()` method for the type `aTarget` points to.
+//
+// Parameters:
+// - `aList` The section list to use.
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` A pointer to the variable to update.
+//
+// Returns:
+// - `error`: A possible lookup or conversion error.
+func getBuiltin(aList *TSectionList, aSection, aKey string, aTarget any) (err error) {
+	switch ptr := aTarget.(type) {
+	case *bool:
+		*ptr, err = aList.GetBool(aSection, aKey)
+	case *[]byte:
+		*ptr, err = aList.GetBytes(aSection, aKey)
+	case *time.Duration:
+		*ptr, err = aList.GetDuration(aSection, aKey)
+	case *float32:
+		*ptr, err = aList.GetFloat32(aSection, aKey)
+	case *float64:
+		*ptr, err = aList.GetFloat64(aSection, aKey)
+	case *int:
+		*ptr, err = aList.GetInt(aSection, aKey)
+	case *int8:
+		*ptr, err = aList.GetInt8(aSection, aKey)
+	case *int16:
+		*ptr, err = aList.GetInt16(aSection, aKey)
+	case *int32:
+		*ptr, err = aList.GetInt32(aSection, aKey)
+	case *int64:
+		*ptr, err = aList.GetInt64(aSection, aKey)
+	case *string:
+		*ptr, err = aList.GetString(aSection, aKey)
+	case *time.Time:
+		*ptr, err = aList.GetTime(aSection, aKey)
+	case *uint:
+		*ptr, err = aList.GetUInt(aSection, aKey)
+	case *uint8:
+		*ptr, err = aList.GetUInt8(aSection, aKey)
+	case *uint16:
+		*ptr, err = aList.GetUInt16(aSection, aKey)
+	case *uint32:
+		*ptr, err = aList.GetUInt32(aSection, aKey)
+	case *uint64:
+		*ptr, err = aList.GetUInt64(aSection, aKey)
+	default:
+		err = getConverted(aList, aSection, aKey, reflect.ValueOf(ptr).Elem())
+	}
+
+	return
+} // getBuiltin()
+
+// `getConverted()` converts the value of `aKey` in `aSection` by a
+// registered converter or the conversions used by `Unmarshal()`.
+//
+// Parameters:
+// - `aList` The section list to use.
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` The (settable) value to update.
+//
+// Returns:
+// - `error`: A possible lookup or conversion error.
+func getConverted(aList *TSectionList, aSection, aKey string, aTarget reflect.Value) error {
+	section, value, err := aList.lookup(aSection, aKey)
+	if nil != err {
+		return err
+	}
+	if err = setFieldValue(aList, aTarget, value); (nil != err) &&
+		!errors.Is(err, ErrUnsupportedType) {
+		err = parseError(section, aKey, value, err)
+	}
+
+	return err
+} // getConverted()
+
+// `GetOr()` returns the value of `aKey` in `aSection` converted to the
+// type `T` or `aDefault` if the key doesn't exist or its value can't
+// be converted.
+//
+// See `Get()` for the supported types.
+//
+//	timeout := ini.GetOr(sl, "server", "timeout", 30*time.Second)
+//
+// Parameters:
+// - `aList` The section list to use.
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aDefault` The value to return in case of errors.
+//
+// Returns:
+// - `T`: The value associated with `aKey` or `aDefault`.
+func GetOr[T any](aList *TSectionList, aSection, aKey string, aDefault T) T {
+	if result, err := Get[T](aList, aSection, aKey); nil == err {
+		return result
+	}
+
+	return aDefault
+} // GetOr()
+
+/* _EoF_ */
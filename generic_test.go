@@ -0,0 +1,87 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepGenericList() *TSectionList {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("s", "bool", "yes")
+	_ = sl.AddSectionKey("s", "int", "-42")
+	_ = sl.AddSectionKey("s", "uint", "300")
+	_ = sl.AddSectionKey("s", "float", "1.5")
+	_ = sl.AddSectionKey("s", "duration", "1m30s")
+	_ = sl.AddSectionKey("s", "time", "2024-05-01T12:00:00Z")
+	_ = sl.AddSectionKey("s", "ip", "10.0.0.1")
+	_ = sl.AddSectionKey("s", "level", "high")
+
+	return sl
+} // prepGenericList()
+
+func TestGet(t *testing.T) {
+	sl := prepGenericList()
+
+	if got, err := Get[bool](sl, "s", "bool"); (nil != err) || !got {
+		t.Errorf("Get[bool]() = %v, %v", got, err)
+	}
+	if got, err := Get[int](sl, "s", "int"); (nil != err) || (-42 != got) {
+		t.Errorf("Get[int]() = %v, %v", got, err)
+	}
+	if got, err := Get[uint8](sl, "s", "uint"); !errors.Is(err, ErrParseValue) || (0 != got) {
+		t.Errorf("Get[uint8]() = %v, %v", got, err)
+	}
+	if got, err := Get[uint16](sl, "s", "uint"); (nil != err) || (300 != got) {
+		t.Errorf("Get[uint16]() = %v, %v", got, err)
+	}
+	if got, err := Get[float32](sl, "s", "float"); (nil != err) || (1.5 != got) {
+		t.Errorf("Get[float32]() = %v, %v", got, err)
+	}
+	if got, err := Get[time.Duration](sl, "s", "duration"); (nil != err) || (90*time.Second != got) {
+		t.Errorf("Get[time.Duration]() = %v, %v", got, err)
+	}
+	if got, err := Get[time.Time](sl, "s", "time"); (nil != err) || (2024 != got.Year()) {
+		t.Errorf("Get[time.Time]() = %v, %v", got, err)
+	}
+	if got, err := Get[net.IP](sl, "s", "ip"); (nil != err) || ("10.0.0.1" != got.String()) {
+		t.Errorf("Get[net.IP]() = %v, %v", got, err)
+	}
+	if got, err := Get[tTestLevel](sl, "s", "level"); (nil != err) || (1 != got) {
+		t.Errorf("Get[tTestLevel]() = %v, %v", got, err)
+	}
+	if got, err := Get[tTestLevel](sl, "s", "int"); !errors.Is(err, ErrParseValue) {
+		t.Errorf("Get[tTestLevel]() = %v, %v", got, err)
+	}
+	if _, err := Get[[]int](sl, "s", "int"); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Get[[]int]() error = %v, want %v", err, ErrUnsupportedType)
+	}
+	if _, err := Get[string](sl, "s", "n.a."); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get[string]() error = %v, want %v", err, ErrKeyNotFound)
+	}
+} // TestGet()
+
+func TestGetOr(t *testing.T) {
+	sl := prepGenericList()
+
+	if got := GetOr(sl, "s", "int", 7); -42 != got {
+		t.Errorf("GetOr() = %v, want %v", got, -42)
+	}
+	if got := GetOr(sl, "s", "n.a.", 7); 7 != got {
+		t.Errorf("GetOr() = %v, want %v", got, 7)
+	}
+	if got := GetOr(sl, "s", "bool", 30*time.Second); 30*time.Second != got {
+		t.Errorf("GetOr() = %v, want %v", got, 30*time.Second)
+	}
+} // TestGetOr()
+
+/* _EoF_ */
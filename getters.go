@@ -0,0 +1,663 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrSectionNotFound` is returned if the requested INI section
+	// doesn't exist.
+	ErrSectionNotFound = errors.New("ini: section not found")
+
+	// `ErrKeyNotFound` is returned if the requested key doesn't exist
+	// in the INI section.
+	ErrKeyNotFound = errors.New("ini: key not found")
+
+	// `ErrParseValue` is returned if a key's value can't be converted
+	// to the requested data type. It usually wraps the error returned
+	// by the `strconv` package.
+	ErrParseValue = errors.New("ini: invalid value")
+)
+
+// `parseError()` returns an `ErrParseValue` error for `aKey` in
+// `aSection` wrapping the given `aErr`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The value that couldn't be converted.
+// - `aErr` The conversion error (may be `nil`).
+//
+// Returns:
+// - `error`: The annotated error.
+func parseError(aSection, aKey, aValue string, aErr error) error {
+	if nil == aErr {
+		return fmt.Errorf("[%s] %s = %q: %w", aSection, aKey, aValue, ErrParseValue)
+	}
+
+	return fmt.Errorf("[%s] %s = %q: %w: %w", aSection, aKey, aValue, ErrParseValue, aErr)
+} // parseError()
+
+// `expandValue()` returns the raw `aValue` of `aKey` in `aSection`
+// decrypted, resolved, and expanded as configured (e.g. by
+// `SetCipher()`, `SetResolveSecrets()`, `SetInterpolate()`, or
+// `SetExpandEnv()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section the value was found in.
+// - `aKey` The name of the key.
+// - `aValue` The key's raw value.
+//
+// Returns:
+// - `string`: The name of the INI section.
+// - `string`: The expanded value.
+// - `error`: Either `nil` or a decryption, a secret reference, a
+// placeholder, or an interpolation error.
+func (sl *TSectionList) expandValue(aSection, aKey, aValue string) (string, string, error) {
+	var err error
+	if aValue, err = sl.decryptValue(aSection, aKey, aValue); nil != err {
+		return aSection, "", err
+	}
+	if aValue, err = sl.resolveSecret(aSection, aKey, aValue); nil != err {
+		return aSection, "", err
+	}
+	if aValue, err = sl.resolvePlaceholders(aSection, aKey, aValue); nil != err {
+		return aSection, "", err
+	}
+	if sl.interpolate {
+		if aValue, err = sl.interpolateValue(aSection, aKey, aValue, nil); nil != err {
+			return aSection, "", err
+		}
+	}
+	if sl.expandEnv {
+		aValue = expandEnv(aValue)
+	}
+
+	return aSection, aValue, nil
+} // expandValue()
+
+// `floatNumber()` prepares `aValue` for being parsed as a floating
+// point number observing the list's number format (see
+// `SetLenientNumbers()`).
+//
+// Parameters:
+// - `aValue` The value to prepare.
+//
+// Returns:
+// - `string`: The number to parse.
+func (sl *TSectionList) floatNumber(aValue string) string {
+	if sl.lenientNum {
+		return lenientFloat(aValue)
+	}
+
+	return aValue
+} // floatNumber()
+
+// `intNumber()` prepares `aValue` for being parsed as an integer
+// observing the list's dialect (see `PHPConstants`) and number format
+// (see `SetLenientNumbers()`).
+//
+// Parameters:
+// - `aValue` The value to prepare.
+//
+// Returns:
+// - `string`: The number to parse.
+// - `int`: The number's base.
+func (sl *TSectionList) intNumber(aValue string) (string, int) {
+	if DialectPHP == sl.dialect {
+		if i64, ok := evalPHPConstants(aValue); ok {
+			aValue = strconv.FormatInt(i64, 10)
+		}
+	}
+	if sl.lenientNum {
+		return lenientInt(aValue)
+	}
+
+	return aValue, 10
+} // intNumber()
+
+// `lookup()` returns the value of `aKey` in `aSection` or an error
+// stating why it can't be returned.
+//
+// If `aSection` is empty the default section is used.
+//
+// All the list's `AsXxx()` and `GetXxx()` methods use this method to
+// retrieve the raw value which is then decrypted, resolved, and
+// expanded (see `expandValue()`). The lookup is reported to the access
+// hooks (see `OnRead()`, `OnMiss()`) and recorded as read (see
+// `UnreadKeys()`); the list's internal lookups use `resolveValue()`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The (resolved) name of the INI section.
+// - `string`: The value associated with `aKey`.
+// - `error`: Either `nil`, `ErrSectionNotFound`, `ErrKeyNotFound`,
+// a decryption, a secret reference, or an interpolation error.
+func (sl *TSectionList) lookup(aSection, aKey string) (string, string, error) {
+	return sl.lookupFallback(aSection, aKey, sl.fallback)
+} // lookup()
+
+// `lookupFallback()` returns the value of `aKey` in `aSection` or an
+// error stating why it can't be returned.
+//
+// See `lookup()` for details.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aFallback` Whether to use the default section's value if `aKey`
+// is missing in `aSection`.
+//
+// Returns:
+// - `string`: The (resolved) name of the INI section.
+// - `string`: The value associated with `aKey`.
+// - `error`: See `lookup()`.
+func (sl *TSectionList) lookupFallback(aSection, aKey string, aFallback bool) (string, string, error) {
+	section, value, err := sl.rawValueFallback(aSection, aKey, aFallback)
+	sl.noteAccess(aSection, aKey, err)
+	if nil != err {
+		return section, "", err
+	}
+	sl.readKeys.add(section, strings.TrimSpace(aKey))
+
+	return sl.expandValue(section, aKey, value)
+} // lookupFallback()
+
+// `resolveValue()` returns the value of `aKey` in `aSection` like
+// `lookup()` does but without reporting the lookup to the access hooks
+// or recording the key as read.
+//
+// It's used by the list's methods looking up values on their own
+// behalf (e.g. `Freeze()` or `Validate()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The (resolved) name of the INI section.
+// - `string`: The value associated with `aKey`.
+// - `error`: See `lookup()`.
+func (sl *TSectionList) resolveValue(aSection, aKey string) (string, string, error) {
+	section, value, err := sl.rawValue(aSection, aKey)
+	if nil != err {
+		return section, "", err
+	}
+
+	return sl.expandValue(section, aKey, value)
+} // resolveValue()
+
+// `rawValue()` returns the unexpanded value of `aKey` in `aSection`
+// or an error stating why it can't be returned.
+//
+// If `aSection` is empty the default section is used.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The (resolved) name of the INI section.
+// - `string`: The value associated with `aKey`.
+// - `error`: Either `nil`, `ErrSectionNotFound` or `ErrKeyNotFound`.
+func (sl *TSectionList) rawValue(aSection, aKey string) (string, string, error) {
+	return sl.rawValueFallback(aSection, aKey, sl.fallback)
+} // rawValue()
+
+// `rawValueFallback()` returns the unexpanded value of `aKey` in
+// `aSection` or an error stating why it can't be returned.
+//
+// A key missing in `aSection` is looked up by its deprecated name
+// (see `RegisterAlias()`) and then, with `aFallback` set, in the
+// default section.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aFallback` Whether to use the default section's value if `aKey`
+// is missing in `aSection`.
+//
+// Returns:
+// - `string`: The name of the INI section the value was found in.
+// - `string`: The value associated with `aKey`.
+// - `error`: Either `nil`, `ErrSectionNotFound` or `ErrKeyNotFound`.
+func (sl *TSectionList) rawValueFallback(aSection, aKey string, aFallback bool) (string, string, error) {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+
+	aKey = strings.TrimSpace(aKey)
+	kl, exists := sl.section(aSection)
+	if !exists {
+		if section, value, ok := sl.aliasValue(aSection, aKey); ok {
+			return section, value, nil
+		}
+		return aSection, "", fmt.Errorf("[%s]: %w", aSection, ErrSectionNotFound)
+	}
+
+	value, exists := kl.AsString(aKey)
+	if !exists {
+		if section, value, ok := sl.aliasValue(aSection, aKey); ok {
+			return section, value, nil
+		}
+		if aFallback && (aSection != sl.defSect) {
+			if def, ok := sl.section(sl.defSect); ok {
+				if value, exists = def.AsString(aKey); exists {
+					return sl.defSect, value, nil
+				}
+			}
+		}
+		return aSection, "", fmt.Errorf("[%s] %s: %w", aSection, aKey, ErrKeyNotFound)
+	}
+
+	return aSection, value, nil
+} // rawValueFallback()
+
+// --------------------------------------------------------------------------
+
+// `GetBool()` returns the value of `aKey` in `aSection` as a boolean value.
+//
+// See `AsBool()` for the accepted values.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `bool`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetBool(aSection, aKey string) (bool, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return false, err
+	}
+	if result, ok := sl.parseBool(value); ok {
+		return result, nil
+	}
+
+	return false, parseError(section, aKey, value, nil)
+} // GetBool()
+
+// `GetBytes()` returns the base64 decoded value of `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `[]byte`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetBytes(aSection, aKey string) ([]byte, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return nil, err
+	}
+	result, err := parseBytes(value)
+	if nil != err {
+		return nil, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetBytes()
+
+// `GetDuration()` returns the value of `aKey` in `aSection` as a time
+// duration.
+//
+// The value is parsed by `time.ParseDuration()`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `time.Duration`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetDuration(aSection, aKey string) (time.Duration, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return time.Duration(0), err
+	}
+	result, err := time.ParseDuration(value)
+	if nil != err {
+		return time.Duration(0), parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetDuration()
+
+// `GetFloat32()` returns the value of `aKey` in `aSection` as a 32bit
+// floating point.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float32`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetFloat32(aSection, aKey string) (float32, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return float32(0), err
+	}
+	number := sl.floatNumber(value)
+	f64, err := strconv.ParseFloat(number, 32)
+	if nil != err {
+		return float32(0), parseError(section, aKey, value, err)
+	}
+	if f64 != f64 { // NaN
+		return float32(0), parseError(section, aKey, value, nil)
+	}
+
+	return float32(f64), nil
+} // GetFloat32()
+
+// `GetFloat64()` returns the value of `aKey` in `aSection` as a 64bit
+// floating point.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetFloat64(aSection, aKey string) (float64, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return float64(0), err
+	}
+	number := sl.floatNumber(value)
+	f64, err := strconv.ParseFloat(number, 64)
+	if nil != err {
+		return float64(0), parseError(section, aKey, value, err)
+	}
+	if f64 != f64 { // NaN
+		return float64(0), parseError(section, aKey, value, nil)
+	}
+
+	return f64, nil
+} // GetFloat64()
+
+// `GetInt()` returns the value of `aKey` in `aSection` as an integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetInt(aSection, aKey string) (int, error) {
+	i64, err := sl.getInt(aSection, aKey, 0)
+
+	return int(i64), err
+} // GetInt()
+
+// `GetInt8()` returns the value of `aKey` in `aSection` as an 8bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int8`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetInt8(aSection, aKey string) (int8, error) {
+	i64, err := sl.getInt(aSection, aKey, 8)
+
+	return int8(i64), err
+} // GetInt8()
+
+// `GetInt16()` returns the value of `aKey` in `aSection` as a 16bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int16`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetInt16(aSection, aKey string) (int16, error) {
+	i64, err := sl.getInt(aSection, aKey, 16)
+
+	return int16(i64), err
+} // GetInt16()
+
+// `GetInt32()` returns the value of `aKey` in `aSection` as a 32bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int32`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetInt32(aSection, aKey string) (int32, error) {
+	i64, err := sl.getInt(aSection, aKey, 32)
+
+	return int32(i64), err
+} // GetInt32()
+
+// `GetInt64()` returns the value of `aKey` in `aSection` as a 64bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetInt64(aSection, aKey string) (int64, error) {
+	return sl.getInt(aSection, aKey, 64)
+} // GetInt64()
+
+// `getInt()` returns the value of `aKey` in `aSection` as an integer
+// of the given `aBitSize`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aBitSize` The integer type's size (`0` for `int`).
+//
+// Returns:
+// - `int64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) getInt(aSection, aKey string, aBitSize int) (int64, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return 0, err
+	}
+	number, base := sl.intNumber(value)
+	i64, err := strconv.ParseInt(number, base, aBitSize)
+	if nil != err {
+		return 0, parseError(section, aKey, value, err)
+	}
+
+	return i64, nil
+} // getInt()
+
+// `GetPercent()` returns the value of `aKey` in `aSection` as a fraction
+// between `0.0` and `1.0`.
+//
+// See `AsPercent()` for the accepted values.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetPercent(aSection, aKey string) (float64, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return float64(0), err
+	}
+	if result, ok := parsePercent(value); ok {
+		return result, nil
+	}
+
+	return float64(0), parseError(section, aKey, value, nil)
+} // GetPercent()
+
+// `GetString()` returns the value of `aKey` in `aSection` as a string.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, or `nil`.
+func (sl *TSectionList) GetString(aSection, aKey string) (string, error) {
+	_, value, err := sl.lookup(aSection, aKey)
+
+	return value, err
+} // GetString()
+
+// `GetTime()` returns the value of `aKey` in `aSection` as a time value.
+//
+// The value is parsed using the `time.RFC3339` layout first and then
+// the given `aLayouts` in turn until one of them matches.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aLayouts` Optional additional layouts to try (see `time.Parse()`).
+//
+// Returns:
+// - `time.Time`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetTime(aSection, aKey string, aLayouts ...string) (time.Time, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return time.Time{}, err
+	}
+	result, err := parseTime(value, aLayouts)
+	if nil != err {
+		return time.Time{}, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetTime()
+
+// `GetUInt()` returns the value of `aKey` in `aSection` as an
+// unsigned integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetUInt(aSection, aKey string) (uint, error) {
+	ui64, err := sl.getUInt(aSection, aKey, 0)
+
+	return uint(ui64), err
+} // GetUInt()
+
+// `GetUInt8()` returns the value of `aKey` in `aSection` as an
+// unsigned 8bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint8`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetUInt8(aSection, aKey string) (uint8, error) {
+	ui64, err := sl.getUInt(aSection, aKey, 8)
+
+	return uint8(ui64), err
+} // GetUInt8()
+
+// `GetUInt16()` returns the value of `aKey` in `aSection` as an
+// unsigned 16bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint16`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetUInt16(aSection, aKey string) (uint16, error) {
+	ui64, err := sl.getUInt(aSection, aKey, 16)
+
+	return uint16(ui64), err
+} // GetUInt16()
+
+// `GetUInt32()` returns the value of `aKey` in `aSection` as an
+// unsigned 32bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint32`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetUInt32(aSection, aKey string) (uint32, error) {
+	ui64, err := sl.getUInt(aSection, aKey, 32)
+
+	return uint32(ui64), err
+} // GetUInt32()
+
+// `GetUInt64()` returns the value of `aKey` in `aSection` as an
+// unsigned 64bit integer.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetUInt64(aSection, aKey string) (uint64, error) {
+	return sl.getUInt(aSection, aKey, 64)
+} // GetUInt64()
+
+// `getUInt()` returns the value of `aKey` in `aSection` as an unsigned
+// integer of the given `aBitSize`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aBitSize` The integer type's size (`0` for `uint`).
+//
+// Returns:
+// - `uint64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) getUInt(aSection, aKey string, aBitSize int) (uint64, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return 0, err
+	}
+	number, base := sl.intNumber(value)
+	ui64, err := strconv.ParseUint(number, base, aBitSize)
+	if nil != err {
+		return 0, parseError(section, aKey, value, err)
+	}
+
+	return ui64, nil
+} // getUInt()
+
+/* _EoF_ */
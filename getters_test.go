@@ -0,0 +1,250 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepGetterList() *TSectionList {
+	sl := prepSectionList()
+	sl.AddSectionKey("", "bool", "yes")
+	sl.AddSectionKey("", "float", "1.5")
+	sl.AddSectionKey("", "int", "-300")
+	sl.AddSectionKey("", "nan", "NaN")
+	sl.AddSectionKey("", "uint", "300")
+
+	return sl
+} // prepGetterList()
+
+type tGetArgs struct {
+	aSection string
+	aKey     string
+}
+
+func TestTSectionList_GetBool(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    bool
+		wantErr error
+	}{
+		{"0", tGetArgs{"", ""}, false, ErrKeyNotFound},
+		{"1", tGetArgs{"", "bool"}, true, nil},
+		{"2", tGetArgs{"", "uint"}, false, ErrParseValue},
+		{"3", tGetArgs{"n.a.", "bool"}, false, ErrSectionNotFound},
+		{"4", tGetArgs{"s2", "bool"}, false, ErrKeyNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetBool(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetBool() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetBool() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetBool()
+
+func TestTSectionList_GetFloat64(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    float64
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "float"}, 1.5, nil},
+		{"1", tGetArgs{"s2", "float"}, 12345.6789, nil},
+		{"2", tGetArgs{"", "nan"}, 0, ErrParseValue},
+		{"3", tGetArgs{"s1", "bool"}, 0, ErrParseValue},
+		{"4", tGetArgs{"n.a.", "float"}, 0, ErrSectionNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetFloat64(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetFloat64() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetFloat64() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetFloat64()
+
+func TestTSectionList_GetInt(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    int
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "int"}, -300, nil},
+		{"1", tGetArgs{"s3", "int"}, -12345, nil},
+		{"2", tGetArgs{"", "float"}, 0, ErrParseValue},
+		{"3", tGetArgs{"", "n.a."}, 0, ErrKeyNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetInt(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetInt() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetInt() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetInt()
+
+func TestTSectionList_GetInt8(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    int8
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "int"}, 0, ErrParseValue}, // out of range
+		{"1", tGetArgs{"", "key0"}, 0, ErrParseValue},
+		{"2", tGetArgs{"n.a.", "int"}, 0, ErrSectionNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetInt8(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetInt8() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetInt8() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetInt8()
+
+func TestTSectionList_GetPercent(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("s", "ratio", "75%")
+	sl.AddSectionKey("s", "bad", "lots")
+
+	if got, err := sl.GetPercent("s", "ratio"); (nil != err) || (0.75 != got) {
+		t.Errorf("TSectionList.GetPercent() = %v, %v, want 0.75, nil", got, err)
+	}
+	if _, err := sl.GetPercent("s", "bad"); !errors.Is(err, ErrParseValue) {
+		t.Errorf("TSectionList.GetPercent() error = %v, want %v", err, ErrParseValue)
+	}
+	if _, err := sl.GetPercent("s", "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TSectionList.GetPercent() error = %v, want %v", err, ErrKeyNotFound)
+	}
+} // TestTSectionList_GetPercent()
+
+func TestTSectionList_GetString(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    string
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "key0"}, "", nil},
+		{"1", tGetArgs{"s1", "bool"}, "nada", nil},
+		{"2", tGetArgs{"s1", "n.a."}, "", ErrKeyNotFound},
+		{"3", tGetArgs{"n.a.", "bool"}, "", ErrSectionNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetString(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetString() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetString() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetString()
+
+func TestTSectionList_GetUInt8(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    uint8
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "uint"}, 0, ErrParseValue}, // out of range
+		{"1", tGetArgs{"", "int"}, 0, ErrParseValue},
+		{"2", tGetArgs{"s4", "n.a."}, 0, ErrKeyNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetUInt8(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetUInt8() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetUInt8() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetUInt8()
+
+func TestTSectionList_GetUInt64(t *testing.T) {
+	sl := prepGetterList()
+	tests := []struct {
+		name    string
+		args    tGetArgs
+		want    uint64
+		wantErr error
+	}{
+		{"0", tGetArgs{"", "uint"}, 300, nil},
+		{"1", tGetArgs{"s4", "uint"}, 1234567890, nil},
+		{"2", tGetArgs{"", "int"}, 0, ErrParseValue},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetUInt64(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.GetUInt64() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetUInt64() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetUInt64()
+
+/* _EoF_ */
@@ -0,0 +1,132 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tGobSection` is the snapshot of a single INI section.
+	tGobSection struct {
+		Comments map[string][]string
+		Data     []TKeyVal
+		Name     string
+	}
+
+	// `tGobSnapshot` is the snapshot of a list written by `EncodeGob()`.
+	tGobSnapshot struct {
+		Comments map[string][]string
+		DefSect  string
+		Sections []tGobSection
+		Trailer  []string
+		Version  int
+	}
+)
+
+const (
+	// `gobVersion` is the version of the snapshot format.
+	gobVersion = 1
+)
+
+var (
+	// `ErrSnapshot` is returned by `DecodeGob()` for a snapshot of
+	// an unknown format.
+	ErrSnapshot = errors.New("ini: incompatible snapshot")
+)
+
+// `EncodeGob()` writes a binary snapshot of the list to `aWriter`.
+//
+// The snapshot holds the sections, key/value pairs, and comments of the
+// list; reading it back by `DecodeGob()` is much faster than parsing
+// the INI data again, so it's suitable to cache the parse results of
+// very large INI files between process runs. The list's settings
+// (e.g. its filename or dialect) are not part of the snapshot.
+//
+// Parameters:
+// - `aWriter` The writer to write the snapshot to.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) EncodeGob(aWriter io.Writer) error {
+	unlock := sl.rlock()
+	snapshot := tGobSnapshot{
+		Comments: sl.comments,
+		DefSect:  sl.defSect,
+		Sections: make([]tGobSection, 0, len(sl.secOrder)),
+		Trailer:  sl.trailer,
+		Version:  gobVersion,
+	}
+	for _, name := range sl.secOrder {
+		kl, exists := sl.sections[name]
+		if !exists {
+			continue
+		}
+		kl.mtx.RLock()
+		snapshot.Sections = append(snapshot.Sections, tGobSection{
+			Comments: maps.Clone(kl.comments),
+			Data:     append([]TKeyVal(nil), kl.data...),
+			Name:     name,
+		})
+		kl.mtx.RUnlock()
+	}
+	unlock()
+
+	return gob.NewEncoder(aWriter).Encode(&snapshot)
+} // EncodeGob()
+
+// `DecodeGob()` replaces the list's data by the snapshot read from
+// `aReader` (see `EncodeGob()`).
+//
+// Parameters:
+// - `aReader` The reader to read the snapshot from.
+//
+// Returns:
+// - `error`: `ErrSnapshot` for an incompatible snapshot or another
+// possible error condition.
+func (sl *TSectionList) DecodeGob(aReader io.Reader) error {
+	var snapshot tGobSnapshot
+	if err := gob.NewDecoder(aReader).Decode(&snapshot); nil != err {
+		return err
+	}
+	if gobVersion != snapshot.Version {
+		return fmt.Errorf("%w: version %d", ErrSnapshot, snapshot.Version)
+	}
+
+	if sl.readOnly {
+		return ErrReadOnly
+	}
+	sl.Clear()
+	defer sl.InvalidateCache()
+	defer sl.lock()()
+
+	sl.defSect = snapshot.DefSect
+	if "" == sl.defSect {
+		sl.defSect = DefSection
+	}
+	sl.comments = snapshot.Comments
+	sl.trailer = snapshot.Trailer
+	for _, section := range snapshot.Sections {
+		kl := NewSection()
+		for _, kv := range section.Data {
+			kl.AddKey(kv.Key, kv.Value)
+		}
+		kl.comments = section.Comments
+		sl.secOrder = append(sl.secOrder, section.Name)
+		sl.sections[section.Name] = kl
+	}
+
+	return nil
+} // DecodeGob()
+
+/* _EoF_ */
@@ -0,0 +1,67 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_EncodeGob(t *testing.T) {
+	src := `; the header
+global = 1
+
+; the server
+[server]
+; the port
+port = 8080
+host = localhost
+
+[empty]
+
+; the end
+`
+	sl := NewSectionList()
+	if err := sl.UnmarshalText([]byte(src)); nil != err {
+		t.Fatalf("TSectionList.UnmarshalText() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := sl.EncodeGob(&buf); nil != err {
+		t.Fatalf("TSectionList.EncodeGob() error = %v", err)
+	}
+	back := NewSectionList()
+	if err := back.DecodeGob(&buf); nil != err {
+		t.Fatalf("TSectionList.DecodeGob() error = %v", err)
+	}
+	if want, got := sl.String(), back.String(); want != got {
+		t.Errorf("TSectionList.DecodeGob() =\n%s\nwant\n%s", got, want)
+	}
+	if got, _ := back.AsInt("server", "port"); 8080 != got {
+		t.Errorf("TSectionList.AsInt() = %d, want 8080", got)
+	}
+} // TestTSectionList_EncodeGob()
+
+func TestTSectionList_DecodeGob(t *testing.T) {
+	var buf bytes.Buffer
+	_ = gob.NewEncoder(&buf).Encode(&tGobSnapshot{Version: gobVersion + 1})
+
+	sl := NewSectionList()
+	if err := sl.DecodeGob(&buf); !errors.Is(err, ErrSnapshot) {
+		t.Errorf("TSectionList.DecodeGob() error = %v, want %v", err, ErrSnapshot)
+	}
+	if err := sl.DecodeGob(strings.NewReader("no gob")); nil == err {
+		t.Error("TSectionList.DecodeGob() expected an error")
+	}
+} // TestTSectionList_DecodeGob()
+
+/* _EoF_ */
@@ -0,0 +1,147 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"io"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tKeyHelp` maps the section/key pairs to their help texts.
+	tKeyHelp map[string]string
+)
+
+// `KeyHelp()` returns the help text of `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `string`: The key's help text (empty if there's none).
+func (sl *TSectionList) KeyHelp(aSection, aKey string) string {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+
+	return sl.help[originID(aSection, strings.TrimSpace(aKey))]
+} // KeyHelp()
+
+// `SetKeyHelp()` sets the help text of `aKey` in `aSection` which is
+// emitted by `WriteCommentedTemplate()`.
+//
+// The help text is metadata only: it's neither read from nor written
+// to the INI file by `Load()` or `Store()`. An empty `aHelp` removes
+// a previously set help text.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key.
+// - `aHelp` The key's help text (where `\n` separates lines).
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetKeyHelp(aSection, aKey, aHelp string) *TSectionList {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return sl
+	}
+	id := originID(aSection, aKey)
+	if aHelp = strings.TrimSpace(aHelp); "" == aHelp {
+		delete(sl.help, id)
+		return sl
+	}
+
+	if nil == sl.help {
+		sl.help = make(tKeyHelp)
+	}
+	sl.help[id] = aHelp
+
+	return sl
+} // SetKeyHelp()
+
+// `template()` writes the given key/value pair commented out to
+// `aBuilder` preceded by the key's help text (if any).
+//
+// Parameters:
+// - `aBuilder` The builder to write to.
+// - `aSection` The name of the INI section.
+// - `aKeyVal` The key/value pair to write.
+// - `aOptions` The layout to use.
+func (sl *TSectionList) template(aBuilder *strings.Builder, aSection string, aKeyVal TKeyVal, aOptions *TFormatOptions) {
+	if help := sl.help[originID(aSection, aKeyVal.Key)]; "" != help {
+		for _, line := range strings.Split(help, "\n") {
+			aBuilder.WriteString(strings.TrimSpace("; " + strings.TrimSpace(line)))
+			aBuilder.WriteByte('\n')
+		}
+	}
+
+	var sb strings.Builder
+	kl := NewSection()
+	kl.insert(aKeyVal)
+	kl.format(&sb, aOptions, sl.dialect)
+	for _, line := range strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n") {
+		aBuilder.WriteString(";" + line + "\n")
+	}
+} // template()
+
+// `WriteCommentedTemplate()` writes an example configuration to
+// `aWriter` holding all keys of the list commented out.
+//
+// Each key is preceded by its help text (see `SetKeyHelp()`) and
+// written with its current value as the default, e.g.:
+//
+//	[server]
+//	; The port to listen on
+//	;port = 8080
+//
+// The section comments (see `SetSectionComment()`) are kept while the
+// keys' comments read from an INI file are replaced by their help
+// texts. The list's layout (see `SetFormatOptions()`) is used except
+// for the line endings which are always LF.
+//
+// Parameters:
+// - `aWriter` The writer to use.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) WriteCommentedTemplate(aWriter io.Writer) error {
+	options := sl.fmtOpts
+	if sl.compactEquals() {
+		options.CompactEquals = true
+	}
+	options.AlignEquals = false // every key is formatted on its own
+
+	var sb strings.Builder
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		if !options.NoSectionGap {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(commentString(sl.sectionComments(name)))
+		sb.WriteString("[" + name + "]\n")
+
+		data := kl.KeyVals()
+		if options.SortKeys {
+			data = (&TSection{data: data}).Sort().data
+		}
+		for _, kv := range data {
+			sl.template(&sb, name, kv, &options)
+		}
+	}
+
+	_, err := io.WriteString(aWriter, sb.String())
+
+	return err
+} // WriteCommentedTemplate()
+
+/* _EoF_ */
@@ -0,0 +1,89 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetKeyHelp(t *testing.T) {
+	sl := NewSectionList()
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		help    string
+		want    string
+	}{
+		{"1", "server", "port", " The port to listen on ", "The port to listen on"},
+		{"2", "", "name", "The application's name", "The application's name"},
+		{"3", "server", "", "no key", ""},
+		{"4", "server", "port", "", ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.SetKeyHelp(tt.section, tt.key, tt.help).KeyHelp(tt.section, tt.key); got != tt.want {
+				t.Errorf("%q: TSectionList.KeyHelp() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_SetKeyHelp()
+
+func TestTSectionList_WriteCommentedTemplate(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("", "name", "myApp")
+	sl.AddSectionKey("server", "port", "8080")
+	sl.AddSectionKey("server", "host", "")
+	sl.SetSectionComment("server", "HTTP server")
+	sl.SetKeyHelp("", "name", "The application's name")
+	sl.SetKeyHelp("server", "port", "The port to listen on\nDefault: 8080")
+
+	tests := []struct {
+		name    string
+		options TFormatOptions
+		want    string
+	}{
+		{"1", TFormatOptions{},
+			"\n[Default]\n; The application's name\n;name = myApp\n" +
+				"\n; HTTP server\n[server]\n; The port to listen on\n; Default: 8080\n;port = 8080\n;host =\n"},
+		{"2", TFormatOptions{CompactEquals: true, NoSectionGap: true, SortKeys: true},
+			"[Default]\n; The application's name\n;name=myApp\n" +
+				"; HTTP server\n[server]\n;host=\n; The port to listen on\n; Default: 8080\n;port=8080\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := sl.SetFormatOptions(tt.options).WriteCommentedTemplate(&sb); nil != err {
+				t.Fatal(err)
+			}
+			if got := sb.String(); got != tt.want {
+				t.Errorf("%q: TSectionList.WriteCommentedTemplate() =\n%q\nwant\n%q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// the template's keys are all commented out:
+	var sb strings.Builder
+	_ = sl.WriteCommentedTemplate(&sb)
+	tl := NewSectionList()
+	if err := tl.UnmarshalText([]byte(sb.String())); nil != err {
+		t.Fatal(err)
+	}
+	if tl.HasSectionKey("server", "port") {
+		t.Error("template's key `port` isn't commented out")
+	}
+} // TestTSectionList_WriteCommentedTemplate()
+
+/* _EoF_ */
@@ -7,6 +7,10 @@ Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
 package ini
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -15,6 +19,70 @@ import (
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
 
+// `IniFlagName` is the name of the commandline option (without leading
+// dashes) used by `ReadIniData()` to get the name of an additional
+// INI file. The option may be given as `-ini path`, `-ini=path`,
+// `--ini path`, or `--ini=path`.
+var IniFlagName = `ini`
+
+type (
+	// `TReadOptions` configures the INI files read by `ReadIniDataWith()`.
+	TReadOptions struct {
+		// The INI files to read in order, i.e. later files take
+		// precedence over earlier ones; `nil` means the files returned
+		// by `ConfigSearchPaths()`.
+		Paths []string
+
+		// The name of the commandline option (without leading dashes)
+		// naming an additional INI file; empty disables the option.
+		CLIFlag string
+
+		// Whether to read the INI file named by the environment variable
+		// `<NAME>_INI` (e.g. `MYAPP_INI` for the application `myApp`).
+		Env bool
+	}
+)
+
+// `iniArgument()` returns the value of the commandline option `aFlag`.
+//
+// Both single and double leading dashes are accepted, and the value may
+// be given either as the following argument or appended by an equal
+// sign. Parsing stops at a `--` argument.
+//
+// Parameters:
+// - `aArgs` The commandline arguments (without the program name).
+// - `aFlag` The name of the option (without leading dashes).
+//
+// Returns:
+// - `string`: The option's value.
+// - `bool`: `true` if the option was found with a value, `false` otherwise.
+func iniArgument(aArgs []string, aFlag string) (string, bool) {
+	if aFlag = strings.TrimLeft(strings.TrimSpace(aFlag), "-"); "" == aFlag {
+		return "", false
+	}
+
+	for idx, arg := range aArgs {
+		if "--" == arg {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimPrefix(arg[1:], "-")
+		if value, found := strings.CutPrefix(name, aFlag+"="); found {
+			return value, ("" != value)
+		}
+		if aFlag == name {
+			if idx+1 < len(aArgs) {
+				return aArgs[idx+1], true
+			}
+			break
+		}
+	}
+
+	return "", false
+} // iniArgument()
+
 // `NewIni()` reads the given `aFilename` returning the data structure read
 // from that INI file and a possible error condition.
 //
@@ -42,14 +110,106 @@ func NewIni(aFilename string) (*TSectionList, error) {
 	return result.load()
 } // New()
 
+// `NewFS()` reads the INI file `aName` from the file system `aFS`
+// returning the data structure read and a possible error condition.
+//
+// This allows to read INI files from e.g. an `embed.FS`, a zip archive
+// or a `fstest.MapFS` without accessing the real filesystem.
+// The returned list's filename is set to `aName`; note that `Store()`
+// writes to the real filesystem.
+//
+// Parameters:
+// - `aFS` The file system to read from.
+// - `aName` The name of the INI file within `aFS`.
+//
+// Returns:
+// - `*TSectionList`: The list of sections of the INI file.
+// - `error`: A possible error condition.
+func NewFS(aFS fs.FS, aName string) (*TSectionList, error) {
+	result := NewSectionList().SetFilename(aName)
+	if nil == aFS {
+		return result, fs.ErrInvalid
+	}
+
+	file, err := aFS.Open(aName)
+	if nil != err {
+		return result, err
+	}
+	defer file.Close()
+
+	_, err = result.read(result.newScanner(context.Background(), file))
+
+	return result, err
+} // NewFS()
+
+// `ParseBytes()` returns the INI data read from `aData`.
+//
+// This function is meant for untrusted input (and `go test -fuzz`):
+// it never panics whatever `aData` holds. The returned list is never
+// `nil` and holds the data read up to a possible error. The error
+// contract is:
+//
+//   - `nil`: the data was read; malformed lines were skipped and can
+//     be retrieved by `ParseWarnings()`,
+//   - `bufio.ErrTooLong`: a line exceeds `DefMaxLineLength`,
+//   - `ErrInternal`: the data couldn't be read due to an internal
+//     failure (which is a bug to be reported).
+//
+// Parameters:
+// - `aData` The INI data to read.
+//
+// Returns:
+// - `*TSectionList`: The list of sections read.
+// - `error`: A possible error condition.
+func ParseBytes(aData []byte) (rList *TSectionList, rErr error) {
+	rList = NewSectionList()
+	defer func() {
+		if r := recover(); nil != r {
+			rErr = fmt.Errorf("%w: %v", ErrInternal, r)
+		}
+	}()
+
+	_, rErr = rList.read(rList.newScanner(context.Background(), bytes.NewReader(aData)))
+
+	return
+} // ParseBytes()
+
+// `LoadWithDefaults()` reads the given `aDefaults` INI data and then
+// overlays the data read from `aFilename`.
+//
+// This allows an application to always start with a complete, known-good
+// configuration (e.g. embedded by a `//go:embed` directive) even if the
+// user's INI file is only partial or missing altogether.
+//
+// A missing `aFilename` is not considered an error; the returned list
+// then holds just the default values. The returned list's filename is
+// set to `aFilename` so that a later `Store()` writes to the user's file.
+// See `NewWithDefaults()` for embedded default data.
+//
+// Parameters:
+//
+//	`aDefaults` The default INI data to start with.
+//	`aFilename` The name of the INI file to overlay the defaults.
+//
+// Returns:
+//
+//	*TSectionList: The list of sections of the merged INI data.
+//	error: A possible error condition.
+func LoadWithDefaults(aDefaults string, aFilename string) (*TSectionList, error) {
+	return NewWithDefaults([]byte(aDefaults), aFilename)
+} // LoadWithDefaults()
+
 // `ReadIniData()` returns the config values read from INI file(s).
 //
 //	The steps here are:
-//	(1) read the local `./.aName.ini`,
-//	(2) read the global `/etc/aName.ini`,
+//	(1) read the local `./aName.ini`,
+//	(2) read the global `/etc/aName.ini` (or `%PROGRAMDATA%\aName\aName.ini`),
 //	(3) read the user-local `~/.aName.ini`,
-//	(4) read the user-local `~/.config/aName.ini`,
-//	(5) read the `-ini` commandline argument.
+//	(4) read the user-local `~/.config/aName.ini` (or `%APPDATA%\aName.ini`),
+//	(5) read the `-ini` commandline argument (see `IniFlagName`).
+//
+// See `ConfigSearchPaths()` for the complete list of files and
+// `ReadIniDataWith()` to configure these steps.
 //
 // This utility function returns the `Default` section of the INI files.
 // It is intended for applications that only use the single default section
@@ -62,6 +222,8 @@ func NewIni(aFilename string) (*TSectionList, error) {
 //
 // The function returns a pointer to the 'Default' section
 // of the first INI file that contains it.
+// The returned list's `Origin()` method tells which file (and line)
+// a value was read from.
 //
 // Parameters:
 //
@@ -74,63 +236,186 @@ func NewIni(aFilename string) (*TSectionList, error) {
 // *TSection: The default section of the INI file.
 // *TSectionList: The list of sections of the INI file.
 func ReadIniData(aName string) (*TSection, *TSectionList) {
-	var (
-		confDir    string
-		err        error
-		ini1, ini2 *TSectionList
-	)
-	// (1) ./
+	stack, _ := readIniStack(aName, defReadOptions())
+
+	return iniData(stack, aName)
+} // ReadIniData()
+
+// `ReadIniDataE()` returns the config values read from INI file(s)
+// like `ReadIniData()` does, but reports the problems found.
+//
+// Missing INI files are not considered an error, while all other
+// problems (e.g. missing permissions or syntax errors in strict mode)
+// are joined into the returned error (see `errors.Join()`). The INI
+// files which can't be read are skipped; the returned section and list
+// are never `nil`.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+//
+// Returns:
+// - `*TSection`: The default section of the INI file.
+// - `*TSectionList`: The list of sections of the INI file.
+// - `error`: The problems found or `nil`.
+func ReadIniDataE(aName string) (*TSection, *TSectionList, error) {
+	return ReadIniDataWith(aName, defReadOptions())
+} // ReadIniDataE()
+
+// `ReadIniDataWith()` returns the config values read from the INI
+// file(s) configured by `aOptions`.
+//
+// This allows non-standard layouts (e.g. snap or flatpak packages,
+// or container mounts) to replace the fixed search steps used by
+// `ReadIniData()`:
+//
+//	_, iniList, err := ini.ReadIniDataWith("myApp", ini.TReadOptions{
+//		Paths:   []string{"/etc/myApp/defaults.ini", "/config/myApp.ini"},
+//		CLIFlag: "config",
+//	})
+//
+// Missing INI files are skipped; see `ReadIniDataE()` for the errors
+// returned.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+// - `aOptions` The INI files to read.
+//
+// Returns:
+// - `*TSection`: The default section of the INI file.
+// - `*TSectionList`: The list of sections of the INI file.
+// - `error`: The problems found or `nil`.
+func ReadIniDataWith(aName string, aOptions TReadOptions) (*TSection, *TSectionList, error) {
+	stack, err := readIniStack(aName, aOptions)
+	section, list := iniData(stack, aName)
+
+	return section, list, err
+} // ReadIniDataWith()
+
+// `iniData()` returns the default section and the flattened list of
+// the INI files read by `readIniStack()`.
+//
+// Parameters:
+// - `aStack` The stack of INI files read.
+// - `aName` The application's name used as the INI file name.
+//
+// Returns:
+// - `*TSection`: The default section of the INI file.
+// - `*TSectionList`: The list of sections of the INI file.
+func iniData(aStack *TConfigStack, aName string) (*TSection, *TSectionList) {
+	result := aStack.Flatten()
+
 	fName, _ := filepath.Abs(`./` + aName + `.ini`)
-	if ini1, err = NewIni(fName); nil == err {
-		ini1.AddSectionKey("", `iniFile`, fName)
+	result.SetFilename(fName)
+	if layers := aStack.Layers(); 0 < len(layers) {
+		result.AddSectionKey("", `iniFile`, layers[len(layers)-1])
 	}
 
-	// (2) /etc/
-	fName = `/etc/` + aName + `.ini`
-	if ini2, err = NewIni(fName); nil == err {
-		ini1.Merge(ini2)
-		ini1.AddSectionKey("", `iniFile`, fName)
-	}
+	return result.GetSection(""), result
+} // iniData()
 
-	// (3) ~user/
-	fName, err = os.UserHomeDir()
-	if (nil == err) && (0 < len(fName)) {
-		fName, _ = filepath.Abs(filepath.Join(fName, `.`+aName+`.ini`))
-		if ini2, err = NewIni(fName); nil == err {
-			ini1.Merge(ini2)
-			ini1.AddSectionKey("", `iniFile`, fName)
+// `ReadIniStack()` returns the INI file(s) read for `aName` as
+// a configuration stack.
+//
+// The files are searched in the same order as by `ReadIniData()`;
+// each file found is pushed as a new layer named by its filename so
+// that `TConfigStack.Explain()` can tell which file supplied a value.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+//
+// Returns:
+// - `*TConfigStack`: The stack of INI files read.
+func ReadIniStack(aName string) *TConfigStack {
+	result, _ := readIniStack(aName, defReadOptions())
+
+	return result
+} // ReadIniStack()
+
+// `defReadOptions()` returns the options used by `ReadIniData()`.
+//
+// Returns:
+// - `TReadOptions`: The search steps described by `ReadIniData()`.
+func defReadOptions() TReadOptions {
+	return TReadOptions{CLIFlag: IniFlagName}
+} // defReadOptions()
+
+// `iniEnvName()` returns the name of the environment variable naming
+// an INI file of the application `aName`.
+//
+// Parameters:
+// - `aName` The application's name.
+//
+// Returns:
+// - `string`: The variable's name, e.g. `MYAPP_INI`.
+func iniEnvName(aName string) string {
+	return strings.Map(func(aRune rune) rune {
+		switch {
+		case ('a' <= aRune) && ('z' >= aRune):
+			return aRune - 'a' + 'A'
+		case (('A' <= aRune) && ('Z' >= aRune)) || (('0' <= aRune) && ('9' >= aRune)):
+			return aRune
+		}
+		return '_'
+	}, aName) + `_INI`
+} // iniEnvName()
+
+// `readIniStack()` returns the INI file(s) read for `aName` as
+// a configuration stack along with the problems found.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+// - `aOptions` The INI files to read.
+//
+// Returns:
+// - `*TConfigStack`: The stack of INI files read.
+// - `error`: The joined errors of the files which couldn't be read.
+func readIniStack(aName string, aOptions TReadOptions) (*TConfigStack, error) {
+	var errs []error
+	result := NewConfigStack()
+	push := func(aFilename string) {
+		ini, err := NewIni(aFilename)
+		switch {
+		case nil == err:
+			result.Push(aFilename, ini)
+		case errors.Is(err, fs.ErrNotExist):
+			// a missing INI file is fine
+		default:
+			var pathErr *fs.PathError
+			if !errors.As(err, &pathErr) {
+				err = fmt.Errorf("%s: %w", aFilename, err)
+			}
+			errs = append(errs, err)
 		}
 	}
 
-	// (4) ~/.config/
-	if confDir, err = os.UserConfigDir(); nil == err {
-		fName, _ = filepath.Abs(filepath.Join(confDir, aName+`.ini`))
-		if ini2, err = NewIni(fName); nil == err {
-			ini1.Merge(ini2)
-			ini1.AddSectionKey("", `iniFile`, fName)
+	// (1) - (4)
+	paths := aOptions.Paths
+	if nil == paths {
+		paths = ConfigSearchPaths(aName)
+	}
+	for _, fName := range paths {
+		push(fName)
+	}
+
+	// environment
+	if aOptions.Env {
+		if env := os.Getenv(iniEnvName(aName)); "" != env {
+			fName, _ := filepath.Abs(env)
+			push(fName)
 		}
 	}
 
 	// (5) cmdline
-	aLen := len(os.Args)
-	for i := 1; i < aLen; i++ {
-		if `-ini` == os.Args[i] {
-			//XXX Note that this works only if `-ini` and
-			// filename are two separate arguments. It will
-			// fail if it's given in the form `-ini=filename`.
-			i++
-			if i < aLen {
-				fName, _ = filepath.Abs(os.Args[i])
-				if ini2, err = NewIni(fName); nil == err {
-					ini1.Merge(ini2)
-					ini1.AddSectionKey("", `iniFile`, fName)
-				}
-			}
-			break
-		}
+	if arg, ok := iniArgument(os.Args[1:], aOptions.CLIFlag); ok {
+		fName, _ := filepath.Abs(arg)
+		push(fName)
 	}
 
-	return ini1.GetSection(""), ini1
-} // ReadIniData()
+	return result, errors.Join(errs...)
+} // readIniStack()
 
 /* _EoF_ */
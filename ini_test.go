@@ -7,8 +7,14 @@ Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
 package ini
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -38,6 +44,203 @@ func TestNewIni(t *testing.T) {
 	}
 } // TestNewIni()
 
+func TestNewFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/app.ini": &fstest.MapFile{Data: []byte("key = value\n\n[sect]\nnum = 42\n")},
+	}
+	tests := []struct {
+		name    string
+		fsys    fs.FS
+		file    string
+		wantLen int
+		wantErr bool
+	}{
+		{"0", nil, "conf/app.ini", 0, true},
+		{"1", fsys, "conf/app.ini", 2, false},
+		{"2", fsys, "n.a.ini", 0, true},
+		{"3", os.DirFS("."), "testIn.ini", 11, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFS(tt.fsys, tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: NewFS() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if got.Len() != tt.wantLen {
+				t.Errorf("%q: NewFS() len = %d, want %d",
+					tt.name, got.Len(), tt.wantLen)
+			}
+		})
+	}
+} // TestNewFS()
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{"1", "[s]\nkey = value\n", "value", false},
+		{"2", "key = value", "value", false},
+		{"3", "[s\nkey = value\n", "", false},
+		{"4", "[s]\nkey = " + strings.Repeat("x", DefMaxLineLength+1), "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl, err := ParseBytes([]byte(tt.data))
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: ParseBytes() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if nil == sl {
+				t.Fatalf("%q: ParseBytes() = nil", tt.name)
+			}
+			section := "s"
+			if !strings.HasPrefix(tt.data, "[s") {
+				section = ""
+			}
+			if got, _ := sl.GetString(section, "key"); got != tt.want {
+				t.Errorf("%q: ParseBytes() key = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestParseBytes()
+
+func FuzzParseBytes(f *testing.F) {
+	// edge cases of the supported INI dialects:
+	for _, seed := range []string{
+		"",
+		"\n\n",
+		"[]",
+		"[",
+		"]",
+		"=",
+		"=value",
+		"key=",
+		"key",
+		";comment\n#comment\n",
+		"\ufeff[s]\nkey = value\n",
+		"[s]\r\nkey = value\r\n",
+		"[s]\nkey = \"quoted value\"\n",
+		"[s]\nkey = 'single'\n",
+		"[s]\nkey = \"\n",
+		"[s]\nkey = value \\\n  continued\n",
+		"[s]\nkey = \\",
+		"\\\n\\\n",
+		"[s]\nkey = \"\"\"\nblock\n\"\"\"\n",
+		"[s]\nkey = \"\"\"",
+		"[s]\nkey = \"\"\"value\"\"\"\n",
+		"[s]\nkey[] = one\nkey[] = two\n",
+		"[s]\nkey = ${s:other}\nother = ${s:key}\n",
+		"[s]\nkey = %PATH% $HOME\n",
+		"[s]\n  indented = value\n",
+		"[a]\n[a]\nk = 1\nk = 2\n",
+		"[s] ; trailing comment\nkey = value ; comment\n",
+		"[Desktop Entry]\nName[de] = Wert\n",
+		"[Unit]\nAfter=a\nAfter=\nAfter=b\n",
+		"; checksum sha256:00\n",
+		"\x00\xff\xfe",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, aData []byte) {
+		sl, err := ParseBytes(aData)
+		if nil == sl {
+			t.Fatal("ParseBytes() = nil")
+		}
+		if errors.Is(err, ErrInternal) {
+			t.Fatalf("ParseBytes() error = %v", err)
+		}
+		if nil != err {
+			return
+		}
+		// the data written has to be readable again:
+		if _, err = ParseBytes([]byte(sl.String())); nil != err {
+			t.Errorf("ParseBytes(String()) error = %v", err)
+		}
+	})
+} // FuzzParseBytes()
+
+func Test_iniArgument(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		flag   string
+		want   string
+		wantOK bool
+	}{
+		{"0", nil, "ini", "", false},
+		{"1", []string{"-ini", "a.ini"}, "ini", "a.ini", true},
+		{"2", []string{"-ini=b.ini"}, "ini", "b.ini", true},
+		{"3", []string{"-v", "--ini=c.ini"}, "ini", "c.ini", true},
+		{"4", []string{"--ini", "d.ini", "-x"}, "ini", "d.ini", true},
+		{"5", []string{"-ini"}, "ini", "", false},
+		{"6", []string{"-ini="}, "ini", "", false},
+		{"7", []string{"--", "-ini", "e.ini"}, "ini", "", false},
+		{"8", []string{"-inifile", "f.ini"}, "ini", "", false},
+		{"9", []string{"--config=g.ini"}, "config", "g.ini", true},
+		{"10", []string{"-ini", "h.ini"}, "", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOK := iniArgument(tt.args, tt.flag)
+			if (got != tt.want) || (gotOK != tt.wantOK) {
+				t.Errorf("%q: iniArgument() = %q, %v, want %q, %v",
+					tt.name, got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+} // Test_iniArgument()
+
+func TestLoadWithDefaults(t *testing.T) {
+	defaults := "[Default]\nach jeh = default\nonlyDefault = yes\n\n[general]\nloglevel = 3\n"
+
+	type tArgs struct {
+		defaults string
+		filename string
+	}
+	type tWant struct {
+		section string
+		key     string
+		value   string
+	}
+	tests := []struct {
+		name    string
+		args    tArgs
+		want    tWant
+		wantErr bool
+	}{
+		{"0", tArgs{defaults, ""}, tWant{"", "ach jeh", "default"}, false},
+		{"1", tArgs{defaults, "n.a.ini"}, tWant{"general", "loglevel", "3"}, false},
+		{"2", tArgs{defaults, "testIn.ini"}, tWant{"", "ach jeh", "macht nix"}, false},
+		{"3", tArgs{defaults, "testIn.ini"}, tWant{"", "onlyDefault", "yes"}, false},
+		{"4", tArgs{defaults, "testIn.ini"}, tWant{"general", "loglevel", "8"}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadWithDefaults(tt.args.defaults, tt.args.filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: LoadWithDefaults() error = %q, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if val, _ := got.AsString(tt.want.section, tt.want.key); val != tt.want.value {
+				t.Errorf("%q: LoadWithDefaults() value = %q, want %q",
+					tt.name, val, tt.want.value)
+			}
+		})
+	}
+} // TestLoadWithDefaults()
+
 const cmpstring = "qwertzuiopü+#äölkjhgfdsa<yxcvbnm,.-^1234567890ß´qwertzuiop"
 
 func Benchmark_compare1(b *testing.B) {
@@ -60,4 +263,93 @@ func Benchmark_compare2(b *testing.B) {
 	}
 } // Benchmark_compare2()
 
+func TestReadIniDataE(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("XDG directories are not used on Windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_CONFIG_DIRS", filepath.Join(home, "xdg"))
+
+	// nothing found at all:
+	section, list, err := ReadIniDataE("noSuchApp")
+	if nil != err {
+		t.Errorf("ReadIniDataE() error = %v", err)
+	}
+	if (nil == section) || (nil == list) {
+		t.Fatal("ReadIniDataE() returned nil")
+	}
+
+	_ = os.MkdirAll(filepath.Join(home, ".config"), 0700)
+	_ = os.WriteFile(filepath.Join(home, ".config", "noSuchApp.ini"), []byte("key = value\n"), 0600)
+	// a directory can't be read as an INI file:
+	_ = os.Mkdir(filepath.Join(home, ".noSuchApp.ini"), 0700)
+
+	section, _, err = ReadIniDataE("noSuchApp")
+	if nil == err {
+		t.Error("ReadIniDataE() expected an error")
+	}
+	if got, _ := section.AsString("key"); "value" != got {
+		t.Errorf("ReadIniDataE() key = %q, want %q", got, "value")
+	}
+} // TestReadIniDataE()
+
+func Test_iniEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"1", "myApp", "MYAPP_INI"},
+		{"2", "my-app.v2", "MY_APP_V2_INI"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := iniEnvName(tt.args); got != tt.want {
+				t.Errorf("%q: iniEnvName() = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_iniEnvName()
+
+func TestReadIniDataWith(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.ini")
+	second := filepath.Join(dir, "second.ini")
+	byEnv := filepath.Join(dir, "env.ini")
+	_ = os.WriteFile(first, []byte("key = first\nother = 1\n"), 0600)
+	_ = os.WriteFile(second, []byte("key = second\n"), 0600)
+	_ = os.WriteFile(byEnv, []byte("other = 2\n"), 0600)
+	t.Setenv("MYAPP_INI", byEnv)
+
+	tests := []struct {
+		name      string
+		opts      TReadOptions
+		wantKey   string
+		wantOther string
+	}{
+		{"1", TReadOptions{Paths: []string{first, second}}, "second", "1"},
+		{"2", TReadOptions{Paths: []string{second, first}}, "first", "1"},
+		{"3", TReadOptions{Paths: []string{first, second}, Env: true}, "second", "2"},
+		{"4", TReadOptions{Paths: []string{}, Env: true}, "", "2"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, _, err := ReadIniDataWith("myApp", tt.opts)
+			if nil != err {
+				t.Fatalf("%q: ReadIniDataWith() error = %v", tt.name, err)
+			}
+			if got, _ := section.AsString("key"); got != tt.wantKey {
+				t.Errorf("%q: ReadIniDataWith() key = %q, want %q", tt.name, got, tt.wantKey)
+			}
+			if got, _ := section.AsString("other"); got != tt.wantOther {
+				t.Errorf("%q: ReadIniDataWith() other = %q, want %q", tt.name, got, tt.wantOther)
+			}
+		})
+	}
+} // TestReadIniDataWith()
+
 /* _EoF_ */
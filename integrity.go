@@ -0,0 +1,139 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tChecksum` holds the integrity footer found while reading.
+	tChecksum struct {
+		data  hash.Hash // the checksum of the lines read so far
+		extra bool      // there are data following the footer
+		found string    // the checksum given by the footer
+		sum   string    // the checksum of the data preceding the footer
+	}
+)
+
+var (
+	// `ErrIntegrity` is returned by `VerifyIntegrity()` if the INI data
+	// don't match their checksum footer.
+	ErrIntegrity = errors.New("ini: integrity check failed")
+
+	// match: ; sha256: <hex>
+	isChecksumRE = regexp.MustCompile(`^;\s*sha256:\s*([0-9a-fA-F]{64})\s*$`)
+)
+
+// `add()` adds the raw line `aLine` to the checksum.
+//
+// Parameters:
+// - `aLine` The line read (incl. its line ending).
+func (cs *tChecksum) add(aLine []byte) {
+	text := strings.TrimSpace(string(aLine))
+	if matches := isChecksumRE.FindStringSubmatch(text); nil != matches {
+		cs.found = strings.ToLower(matches[1])
+		cs.sum = hex.EncodeToString(cs.data.Sum(nil))
+		cs.extra = false
+		return
+	}
+	if ("" != cs.found) && ("" != text) {
+		cs.extra = true
+	}
+	cs.data.Write(aLine)
+} // add()
+
+// `dropChecksum()` returns `aComments` without integrity footers.
+//
+// Parameters:
+// - `aComments` The comment lines to check.
+//
+// Returns:
+// - `[]string`: The comment lines without footers.
+func dropChecksum(aComments []string) []string {
+	result := aComments[:0:0]
+	for _, line := range aComments {
+		if !isChecksumRE.MatchString(strings.TrimSpace(line)) {
+			result = append(result, line)
+		}
+	}
+
+	return result
+} // dropChecksum()
+
+// `integrityText()` returns `aText` followed by its checksum footer
+// if enabled by `SetIntegrity()`.
+//
+// Parameters:
+// - `aText` The INI data to write.
+//
+// Returns:
+// - `string`: The INI data to write.
+func (sl *TSectionList) integrityText(aText string) string {
+	if !sl.integrity {
+		return aText
+	}
+	eol := "\n"
+	if strings.Contains(aText, "\r\n") {
+		eol = "\r\n"
+	}
+	if ("" != aText) && !strings.HasSuffix(aText, "\n") {
+		aText += eol
+	}
+	sum := sha256.Sum256([]byte(aText))
+
+	return aText + "; sha256: " + hex.EncodeToString(sum[:]) + eol
+} // integrityText()
+
+// `SetIntegrity()` determines whether the INI data written by `Store()`
+// and its siblings end with a checksum footer like
+//
+//	; sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//
+// which allows to detect the tampering or truncation of machine-managed
+// INI files by `VerifyIntegrity()`. The footer is removed while reading,
+// so it's never part of the list's comments.
+//
+// Parameters:
+// - `aEnabled` Whether to write the checksum footer.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetIntegrity(aEnabled bool) *TSectionList {
+	sl.integrity = aEnabled
+
+	return sl
+} // SetIntegrity()
+
+// `VerifyIntegrity()` checks the INI data read against their checksum
+// footer (see `SetIntegrity()`).
+//
+// Returns:
+// - `error`: `nil` if the data match their footer, or `ErrIntegrity`
+// if the footer is missing, doesn't match, or isn't the last line.
+func (sl *TSectionList) VerifyIntegrity() error {
+	switch {
+	case "" == sl.checksum.found:
+		return fmt.Errorf("%w: no checksum", ErrIntegrity)
+	case sl.checksum.extra:
+		return fmt.Errorf("%w: data following the checksum", ErrIntegrity)
+	case sl.checksum.found != sl.checksum.sum:
+		return fmt.Errorf("%w: checksum mismatch", ErrIntegrity)
+	}
+
+	return nil
+} // VerifyIntegrity()
+
+/* _EoF_ */
@@ -0,0 +1,73 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_VerifyIntegrity(t *testing.T) {
+	sl := NewSectionList().SetIntegrity(true)
+	sl.AddSectionKey("server", "host", "localhost")
+	sl.AddSectionKey("server", "port", "8080")
+
+	var buf bytes.Buffer
+	if _, err := sl.StoreTo(&buf); nil != err {
+		t.Fatalf("TSectionList.StoreTo() error = %v", err)
+	}
+	signed := buf.String()
+	if !strings.Contains(signed, "\n; sha256: ") {
+		t.Fatalf("TSectionList.StoreTo() wrote no checksum: %q", signed)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"1", signed, false},
+		{"2", signed + "\n\n", false},
+		{"3", strings.Replace(signed, "8080", "8081", 1), true},
+		{"4", signed[:strings.Index(signed, "port")], true},
+		{"5", signed + "key = value\n", true},
+		{"6", "[server]\nport = 8080\n", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList()
+			if _, err := sl.read(sl.newScanner(context.Background(), strings.NewReader(tt.data))); nil != err {
+				t.Fatalf("TSectionList.read() error = %v", err)
+			}
+			err := sl.VerifyIntegrity()
+			if (nil != err) != tt.wantErr {
+				t.Errorf("TSectionList.VerifyIntegrity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrIntegrity) {
+				t.Errorf("TSectionList.VerifyIntegrity() error = %v, want %v", err, ErrIntegrity)
+			}
+		})
+	}
+
+	// the footer isn't kept as a comment, so it's not written twice:
+	back := NewSectionList()
+	_, _ = back.read(back.newScanner(context.Background(), strings.NewReader(signed)))
+	back.SetIntegrity(true)
+	buf.Reset()
+	_, _ = back.StoreTo(&buf)
+	if got := buf.String(); signed != got {
+		t.Errorf("TSectionList.StoreTo() =\n%q\nwant\n%q", got, signed)
+	}
+} // TestTSectionList_VerifyIntegrity()
+
+/* _EoF_ */
@@ -0,0 +1,133 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrInterpolation` is returned if a reference in a value can't
+	// be resolved.
+	ErrInterpolation = errors.New("ini: interpolation failed")
+
+	// `ErrInterpolationCycle` is returned if the references in a value
+	// (directly or indirectly) refer to the value itself.
+	ErrInterpolationCycle = errors.New("ini: interpolation cycle")
+
+	// match: %(key)s or ${section:key}
+	isReferenceRE = regexp.MustCompile(`%\(([^)]+)\)s|\$\{([^}:]*):([^}]+)\}`)
+)
+
+// `interpolateValue()` replaces all references to other keys in
+// `aValue` by those keys' values.
+//
+// A reference like `%(key)s` is looked up in `aSection` first and
+// then in the default section, while a reference like `${section:key}`
+// is looked up in the named section (an empty section name denotes
+// the default section). The referenced values are interpolated
+// recursively.
+//
+// Parameters:
+// - `aSection` The name of the INI section `aValue` belongs to.
+// - `aKey` The name of the key `aValue` belongs to.
+// - `aValue` The value to interpolate.
+// - `aChain` The references already followed (used to detect cycles).
+//
+// Returns:
+// - `string`: The interpolated value.
+// - `error`: `ErrInterpolation`, `ErrInterpolationCycle`, or `nil`.
+func (sl *TSectionList) interpolateValue(aSection, aKey, aValue string, aChain []string) (string, error) {
+	if !strings.Contains(aValue, `%(`) && !strings.Contains(aValue, `${`) {
+		return aValue, nil
+	}
+
+	ref := "[" + aSection + "] " + aKey
+	for _, link := range aChain {
+		if link == ref {
+			return "", fmt.Errorf("%w: %s -> %s",
+				ErrInterpolationCycle, strings.Join(aChain, " -> "), ref)
+		}
+	}
+	aChain = append(aChain, ref)
+
+	var rErr error
+	result := isReferenceRE.ReplaceAllStringFunc(aValue, func(aMatch string) string {
+		if nil != rErr {
+			return aMatch
+		}
+		matches := isReferenceRE.FindStringSubmatch(aMatch)
+
+		var (
+			err          error
+			section, val string
+		)
+		if "" != matches[1] { // %(key)s
+			key := strings.TrimSpace(matches[1])
+			section, val, err = sl.rawValue(aSection, key)
+			if nil != err {
+				section, val, err = sl.rawValue(sl.defSect, key)
+			}
+			if nil == err {
+				val, err = sl.interpolateValue(section, key, val, aChain)
+			}
+		} else { // ${section:key}
+			key := strings.TrimSpace(matches[3])
+			section, val, err = sl.rawValue(matches[2], key)
+			if nil == err {
+				val, err = sl.interpolateValue(section, key, val, aChain)
+			}
+		}
+		if nil != err {
+			if errors.Is(err, ErrInterpolationCycle) || errors.Is(err, ErrInterpolation) {
+				rErr = err
+			} else {
+				rErr = fmt.Errorf("%s: %w: %w", ref, ErrInterpolation, err)
+			}
+			return aMatch
+		}
+
+		return val
+	})
+	if nil != rErr {
+		return "", rErr
+	}
+
+	return result, nil
+} // interpolateValue()
+
+// `SetInterpolate()` sets whether references to other keys in the INI
+// values should be resolved.
+//
+// If enabled, values like `%(basedir)s/app.log` (referring to a key
+// in the same section or the default section) or `${Default:basedir}/app.log`
+// (referring to a key in the named section) are resolved whenever they
+// are retrieved by one of the list's `AsXxx()` or `GetXxx()` methods.
+// The stored values themselves are not changed.
+//
+// References that can't be resolved or that form a cycle cause the
+// `GetXxx()` methods to return `ErrInterpolation` or
+// `ErrInterpolationCycle` respectively (and the `AsXxx()` methods to
+// return `false`).
+//
+// Parameters:
+// - `aInterpolate` Whether to resolve references.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetInterpolate(aInterpolate bool) *TSectionList {
+	sl.interpolate = aInterpolate
+
+	return sl
+} // SetInterpolate()
+
+/* _EoF_ */
@@ -0,0 +1,65 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetInterpolate(t *testing.T) {
+	sl := NewSectionList().SetInterpolate(true)
+	sl.AddSectionKey("", "basedir", "/var/lib/app")
+	sl.AddSectionKey("", "name", "app")
+	sl.AddSectionKey("log", "logdir", "%(basedir)s/log")
+	sl.AddSectionKey("log", "logfile", "%(logdir)s/%(name)s.log")
+	sl.AddSectionKey("log", "other", "${Default:basedir}/other")
+	sl.AddSectionKey("log", "short", "${:name}")
+	sl.AddSectionKey("loop", "a", "%(b)s")
+	sl.AddSectionKey("loop", "b", "${loop:a}")
+	sl.AddSectionKey("loop", "c", "%(n.a.)s")
+
+	type tArgs struct {
+		aSection string
+		aKey     string
+	}
+	tests := []struct {
+		name    string
+		args    tArgs
+		want    string
+		wantErr error
+	}{
+		{"1", tArgs{"log", "logdir"}, "/var/lib/app/log", nil},
+		{"2", tArgs{"log", "logfile"}, "/var/lib/app/log/app.log", nil},
+		{"3", tArgs{"log", "other"}, "/var/lib/app/other", nil},
+		{"4", tArgs{"log", "short"}, "app", nil},
+		{"5", tArgs{"loop", "a"}, "", ErrInterpolationCycle},
+		{"6", tArgs{"loop", "c"}, "", ErrInterpolation},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sl.GetString(tt.args.aSection, tt.args.aKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: TSectionList.SetInterpolate() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.SetInterpolate() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	if got, _ := sl.SetInterpolate(false).AsString("log", "logdir"); "%(basedir)s/log" != got {
+		t.Errorf("TSectionList.SetInterpolate(false) = %q", got)
+	}
+} // TestTSectionList_SetInterpolate()
+
+/* _EoF_ */
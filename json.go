@@ -0,0 +1,221 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrInvalidJSON` is returned if the JSON data doesn't represent
+	// an INI structure.
+	ErrInvalidJSON = errors.New("ini: invalid JSON data")
+)
+
+// `jsonValue()` returns the INI value of the given JSON token.
+//
+// Parameters:
+// - `aToken` The JSON token to convert.
+//
+// Returns:
+// - `string`: The token's value as a string.
+// - `error`: `ErrInvalidJSON` if the token isn't a scalar value.
+func jsonValue(aToken json.Token) (string, error) {
+	switch val := aToken.(type) {
+	case string:
+		return val, nil
+	case json.Number:
+		return val.String(), nil
+	case bool:
+		if val {
+			return "true", nil
+		}
+		return "false", nil
+	case nil:
+		return "", nil
+	}
+
+	return "", fmt.Errorf("%w: unexpected %v", ErrInvalidJSON, aToken)
+} // jsonValue()
+
+// `readJSONSection()` reads the key/value pairs of a JSON object into
+// `aSection` of the list.
+//
+// The opening delimiter of the object must already be consumed.
+//
+// Parameters:
+// - `aDecoder` The JSON decoder to read from.
+// - `aSection` The name of the INI section to fill.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) readJSONSection(aDecoder *json.Decoder, aSection string) error {
+	sl.addSection(aSection)
+	for aDecoder.More() {
+		token, err := aDecoder.Token()
+		if nil != err {
+			return err
+		}
+		key, _ := token.(string)
+		if token, err = aDecoder.Token(); nil != err {
+			return err
+		}
+		value, err := jsonValue(token)
+		if nil != err {
+			return fmt.Errorf("%w in section %q, key %q", err, aSection, key)
+		}
+		sl.AddSectionKey(aSection, key, value)
+	}
+
+	// consume the closing delimiter:
+	_, err := aDecoder.Token()
+
+	return err
+} // readJSONSection()
+
+// `MarshalJSON()` implements the `json.Marshaler` interface.
+//
+// Each section becomes a JSON object whose members are the section's
+// key/value pairs as string fields. Both the sections and the keys
+// keep their order.
+//
+// Returns:
+// - `[]byte`: The JSON representation of the list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for idx, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		if 0 < idx {
+			buf.WriteByte(',')
+		}
+		jName, _ := json.Marshal(name)
+		buf.Write(jName)
+		buf.WriteString(":{")
+		kl.mtx.RLock()
+		for i, kv := range kl.data {
+			if 0 < i {
+				buf.WriteByte(',')
+			}
+			jKey, _ := json.Marshal(kv.Key)
+			jVal, _ := json.Marshal(kv.Value)
+			buf.Write(jKey)
+			buf.WriteByte(':')
+			buf.Write(jVal)
+		}
+		kl.mtx.RUnlock()
+		buf.WriteByte('}')
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+} // MarshalJSON()
+
+// `ToJSON()` returns the JSON representation of the list.
+//
+// See `MarshalJSON()` for details.
+//
+// Returns:
+// - `[]byte`: The JSON representation of the list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) ToJSON() ([]byte, error) {
+	return sl.MarshalJSON()
+} // ToJSON()
+
+// `UnmarshalJSON()` implements the `json.Unmarshaler` interface.
+//
+// The current list's data is replaced by the sections read from
+// `aData` which must be a JSON object whose members are objects
+// holding a section's key/value pairs. Numbers, booleans, and `null`
+// are accepted as values and converted to strings. Scalar members at
+// the top level are stored in the default section.
+//
+// Parameters:
+// - `aData` The JSON data to read.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) UnmarshalJSON(aData []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(aData))
+	decoder.UseNumber()
+
+	token, err := decoder.Token()
+	if nil != err {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || ('{' != delim) {
+		return fmt.Errorf("%w: expected an object", ErrInvalidJSON)
+	}
+
+	if sl.readOnly {
+		return ErrReadOnly
+	}
+	if "" == sl.defSect {
+		sl.defSect = DefSection
+	}
+	sl.Clear()
+	for decoder.More() {
+		if token, err = decoder.Token(); nil != err {
+			return err
+		}
+		name, _ := token.(string)
+		if token, err = decoder.Token(); nil != err {
+			return err
+		}
+		if delim, ok := token.(json.Delim); ok {
+			if '{' != delim {
+				return fmt.Errorf("%w: section %q is no object", ErrInvalidJSON, name)
+			}
+			if "" == name {
+				name = sl.defSect
+			}
+			if err = sl.readJSONSection(decoder, name); nil != err {
+				return err
+			}
+			continue
+		}
+
+		value, err := jsonValue(token)
+		if nil != err {
+			return err
+		}
+		sl.AddSectionKey(sl.defSect, name, value)
+	}
+
+	return nil
+} // UnmarshalJSON()
+
+// --------------------------------------------------------------------------
+
+// `FromJSON()` returns a new list with the sections read from the
+// given JSON data.
+//
+// See `TSectionList.UnmarshalJSON()` for details.
+//
+// Parameters:
+// - `aData` The JSON data to read.
+//
+// Returns:
+// - `*TSectionList`: The list read from `aData`.
+// - `error`: A possible error condition.
+func FromJSON(aData []byte) (*TSectionList, error) {
+	result := NewSectionList()
+	if err := result.UnmarshalJSON(aData); nil != err {
+		return nil, err
+	}
+
+	return result, nil
+} // FromJSON()
+
+/* _EoF_ */
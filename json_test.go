@@ -0,0 +1,92 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_MarshalJSON(t *testing.T) {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("general", "name", `say "hi"`)
+	_ = sl.AddSectionKey("general", "level", "8")
+	_ = sl.AddSectionKey("sql", "host", "localhost")
+	sl.addSection("empty")
+
+	want := `{"general":{"name":"say \"hi\"","level":"8"},"sql":{"host":"localhost"},"empty":{}}`
+	got, err := sl.ToJSON()
+	if nil != err {
+		t.Fatalf("TSectionList.ToJSON() error = %v", err)
+	}
+	if want != string(got) {
+		t.Errorf("TSectionList.ToJSON() = %s, want %s", got, want)
+	}
+
+	// and through the `json` package:
+	wrapped, err := json.Marshal(map[string]any{"ini": sl})
+	if nil != err {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if w := `{"ini":` + want + `}`; w != string(wrapped) {
+		t.Errorf("json.Marshal() = %s, want %s", wrapped, w)
+	}
+} // TestTSectionList_MarshalJSON()
+
+func TestFromJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		wantErr error
+	}{
+		{"1", `{"general":{"level":8,"debug":true,"name":"x"},"top":"level"}`, nil},
+		{"2", `[]`, ErrInvalidJSON},
+		{"3", `{"general":[1,2]}`, ErrInvalidJSON},
+		{"4", `{"general":{"nested":{}}}`, ErrInvalidJSON},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromJSON([]byte(tt.args))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%q: FromJSON() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+			if nil != err {
+				return
+			}
+			if val, _ := got.AsInt("general", "level"); 8 != val {
+				t.Errorf("%q: FromJSON() level = %d, want 8", tt.name, val)
+			}
+			if val, _ := got.AsBool("general", "debug"); !val {
+				t.Errorf("%q: FromJSON() debug = %v, want true", tt.name, val)
+			}
+			if val, _ := got.AsString("", "top"); "level" != val {
+				t.Errorf("%q: FromJSON() top = %q, want %q", tt.name, val, "level")
+			}
+		})
+	}
+} // TestFromJSON()
+
+func TestTSectionList_UnmarshalJSON(t *testing.T) {
+	sl := prepSectionList()
+	data, _ := json.Marshal(sl)
+
+	var got TSectionList
+	if err := json.Unmarshal(data, &got); nil != err {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !sl.CompareTo(&got) {
+		t.Errorf("TSectionList.UnmarshalJSON() round trip mismatch:\n%s\n%s",
+			sl.String(), got.String())
+	}
+} // TestTSectionList_UnmarshalJSON()
+
+/* _EoF_ */
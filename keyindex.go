@@ -0,0 +1,100 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tKeyIndex` maps key names to their positions in a `tKeyValList`.
+type tKeyIndex map[string]int
+
+// The methods below expect the caller to hold the section's lock.
+
+// `insert()` adds or updates the given key/value pair keeping the
+// section's index up to date.
+//
+// Parameters:
+// - `aKeyVal` The key/value pair to add.
+//
+// Returns:
+// - `bool`: `true` if `aKeyVal` was added successfully, `false` otherwise.
+func (kl *TSection) insert(aKeyVal TKeyVal) bool {
+	if "" == aKeyVal.Key {
+		return false
+	}
+	kl.edits++
+	if !kl.indexed() {
+		kl.reindex()
+	}
+
+	if idx, ok := kl.index[aKeyVal.Key]; ok {
+		kl.data[idx].Value = aKeyVal.Value
+		return true
+	}
+	kl.index[aKeyVal.Key] = len(kl.data)
+	kl.data = append(kl.data, aKeyVal)
+
+	return true
+} // insert()
+
+// `indexed()` reports whether the section's index matches its data.
+//
+// Returns:
+// - `bool`: `true` if the index can be used, `false` otherwise.
+func (kl *TSection) indexed() bool {
+	return (nil != kl.index) && (len(kl.index) == len(kl.data))
+} // indexed()
+
+// `position()` returns the position of `aKey` in the section's data.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The position of `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) position(aKey string) (int, bool) {
+	if kl.indexed() {
+		idx, ok := kl.index[aKey]
+		return idx, ok
+	}
+
+	for idx, kv := range kl.data {
+		if aKey == kv.Key {
+			return idx, true
+		}
+	}
+
+	return -1, false
+} // position()
+
+// `reindex()` rebuilds the section's index after the data were
+// reordered or shrunk.
+func (kl *TSection) reindex() {
+	kl.edits++
+	kl.index = make(tKeyIndex, len(kl.data))
+	for idx, kv := range kl.data {
+		kl.index[kv.Key] = idx
+	}
+} // reindex()
+
+// `value()` returns the value of `aKey`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) value(aKey string) (string, bool) {
+	if idx, ok := kl.position(aKey); ok {
+		return kl.data[idx].Value, true
+	}
+
+	return "", false
+} // value()
+
+/* _EoF_ */
@@ -0,0 +1,97 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"fmt"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSection_index(t *testing.T) {
+	kl := NewSection()
+	for i := 0; i < 10; i++ {
+		kl.AddKey(fmt.Sprintf("key%d", 9-i), fmt.Sprintf("%d", 9-i))
+	}
+	kl.RemoveKey("key5")
+	kl.UpdateKey("key3", "three")
+	kl.Sort()
+	kl.AddKey("new", "n")
+	kl.Merge(kl.Copy())
+
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"key0", "0", true},
+		{"key3", "three", true},
+		{"key5", "", false},
+		{"key9", "9", true},
+		{"new", "n", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := kl.AsString(tt.key)
+			if (got != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("TSection.AsString(%q) = %q, %v, want %q, %v",
+					tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	if !kl.indexed() {
+		t.Fatal("TSection index out of sync")
+	}
+	for key, idx := range kl.index {
+		if kl.data[idx].Key != key {
+			t.Errorf("TSection.index[%q] = %d, pointing to %q",
+				key, idx, kl.data[idx].Key)
+		}
+	}
+
+	// sections created without the constructor work as well:
+	literal := &TSection{data: tKeyValList{{"a", "1"}, {"b", "2"}}}
+	if got, ok := literal.AsString("b"); ("2" != got) || !ok {
+		t.Errorf("TSection.AsString() = %q, %v, want %q, true", got, ok, "2")
+	}
+	literal.AddKey("c", "3")
+	if got, _ := literal.AsString("a"); "1" != got {
+		t.Errorf("TSection.AsString() = %q, want %q", got, "1")
+	}
+} // TestTSection_index()
+
+func prepBenchSection(aKeys int) *TSection {
+	kl := NewSection()
+	for i := 0; i < aKeys; i++ {
+		kl.AddKey(fmt.Sprintf("key%05d", i), fmt.Sprintf("%d", i))
+	}
+
+	return kl
+} // prepBenchSection()
+
+func BenchmarkTSection_AsString(b *testing.B) {
+	kl := prepBenchSection(5000)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_, _ = kl.AsString("key04999")
+	}
+} // BenchmarkTSection_AsString()
+
+func Benchmark_tKeyValList_value(b *testing.B) {
+	kl := prepBenchSection(5000)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_, _ = kl.data.value("key04999")
+	}
+} // Benchmark_tKeyValList_value()
+
+/* _EoF_ */
@@ -0,0 +1,238 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TParseLimits` restricts the INI data accepted while reading,
+	// e.g. to harden an application against hostile input.
+	//
+	// A zero (or negative) value means "no limit" except for
+	// `MaxLineLength` which then defaults to `DefMaxLineLength`.
+	TParseLimits struct {
+		MaxFileSize   int64 // maximum number of bytes to read
+		MaxKeys       int   // maximum number of keys (in all sections)
+		MaxLineLength int   // maximum length of a single line
+		MaxSections   int   // maximum number of sections
+	}
+
+	// `tLimitReader` stops reading when its context is done or
+	// after a maximum number of bytes.
+	tLimitReader struct {
+		ctx    context.Context
+		reader io.Reader
+		left   int64 // remaining bytes (negative: unlimited)
+	}
+)
+
+const (
+	// `DefMaxLineLength` is the default maximum length of a line.
+	DefMaxLineLength = 1 << 20
+)
+
+var (
+	// `ErrLimitExceeded` is returned if the INI data exceed one of
+	// the configured `TParseLimits`.
+	ErrLimitExceeded = errors.New("ini: limit exceeded")
+)
+
+// `Read()` implements the `io.Reader` interface.
+func (lr *tLimitReader) Read(aBuffer []byte) (int, error) {
+	if err := lr.ctx.Err(); nil != err {
+		return 0, err
+	}
+	if 0 <= lr.left {
+		if 0 == lr.left {
+			// check whether there's more data than allowed
+			var probe [1]byte
+			if n, _ := lr.reader.Read(probe[:]); 0 < n {
+				return 0, fmt.Errorf("%w: file size", ErrLimitExceeded)
+			}
+			return 0, io.EOF
+		}
+		if int64(len(aBuffer)) > lr.left {
+			aBuffer = aBuffer[:lr.left]
+		}
+	}
+
+	n, err := lr.reader.Read(aBuffer)
+	if 0 <= lr.left {
+		lr.left -= int64(n)
+	}
+
+	return n, err
+} // Read()
+
+// --------------------------------------------------------------------------
+
+// `checkLimits()` returns an error if the list exceeds its section
+// or key limits.
+//
+// Parameters:
+// - `aKeys` The number of keys read so far.
+//
+// Returns:
+// - `error`: `ErrLimitExceeded` or `nil`.
+func (sl *TSectionList) checkLimits(aKeys int) error {
+	if (0 < sl.limits.MaxSections) && (sl.limits.MaxSections < sl.Len()) {
+		return fmt.Errorf("%w: more than %d sections",
+			ErrLimitExceeded, sl.limits.MaxSections)
+	}
+	if (0 < sl.limits.MaxKeys) && (sl.limits.MaxKeys < aKeys) {
+		return fmt.Errorf("%w: more than %d keys",
+			ErrLimitExceeded, sl.limits.MaxKeys)
+	}
+
+	return nil
+} // checkLimits()
+
+// `loadContext()` reads the configured INI file observing `aCtx`
+// and the list's limits.
+//
+// Parameters:
+// - `aCtx` The context to cancel reading.
+//
+// Returns:
+// - `*TSectionList`: The loaded INI list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) loadContext(aCtx context.Context) (*TSectionList, error) {
+	file, err := os.Open(sl.fName)
+	if nil != err {
+		return sl, err
+	}
+	defer file.Close()
+
+	_, err = sl.read(sl.newScanner(aCtx, file))
+
+	return sl, err
+} // loadContext()
+
+// `LoadContext()` reads the configured INI file like `Load()` but
+// stops reading when `aCtx` is done.
+//
+// Parameters:
+// - `aCtx` The context to cancel reading.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) LoadContext(aCtx context.Context) (*TSectionList, error) {
+	return sl.loadContext(aCtx)
+} // LoadContext()
+
+// `newScanner()` returns a line scanner reading from `aReader` which
+// observes `aCtx` and the list's limits and encoding.
+//
+// The scanner notes whether the data use mostly CR/LF line endings
+// and checks a possible integrity footer (see `VerifyIntegrity()`).
+//
+// Parameters:
+// - `aCtx` The context to cancel reading.
+// - `aReader` The source of the INI data.
+//
+// Returns:
+// - `*bufio.Scanner`: The scanner to use.
+func (sl *TSectionList) newScanner(aCtx context.Context, aReader io.Reader) *bufio.Scanner {
+	left := int64(-1)
+	if 0 < sl.limits.MaxFileSize {
+		left = sl.limits.MaxFileSize
+	}
+	maxLen := DefMaxLineLength
+	if 0 < sl.limits.MaxLineLength {
+		maxLen = sl.limits.MaxLineLength
+	}
+
+	result := bufio.NewScanner(sl.decoder(&tLimitReader{aCtx, aReader, left}))
+	result.Buffer(make([]byte, 0, min(maxLen, bufio.MaxScanTokenSize)), maxLen)
+
+	// count the line endings to write them back the same way
+	// and compute the checksum to verify the integrity footer:
+	crlf, lf := 0, 0
+	sl.checksum = tChecksum{data: sha256.New()}
+	result.Split(func(aData []byte, aAtEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(aData, aAtEOF)
+		if 0 < advance {
+			sl.checksum.add(aData[:advance])
+		}
+		if (0 < advance) && ('\n' == aData[advance-1]) {
+			if (1 < advance) && ('\r' == aData[advance-2]) {
+				crlf++
+			} else {
+				lf++
+			}
+			sl.crlf = (crlf > lf)
+		}
+
+		return advance, token, err
+	})
+
+	return result
+} // newScanner()
+
+// `ParseLimits()` returns the limits observed while reading.
+//
+// Returns:
+// - `TParseLimits`: The list's current limits.
+func (sl *TSectionList) ParseLimits() TParseLimits {
+	return sl.limits
+} // ParseLimits()
+
+// `SetParseLimits()` sets the limits observed while reading INI data.
+//
+// Since the limits are applied while reading, they have to be set
+// before the INI data is loaded (see `Load()`).
+//
+// Parameters:
+// - `aLimits` The limits to observe.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetParseLimits(aLimits TParseLimits) *TSectionList {
+	sl.limits = aLimits
+
+	return sl
+} // SetParseLimits()
+
+// --------------------------------------------------------------------------
+
+// `NewWithContext()` reads the given `aFilename` observing `aLimits`
+// and stopping when `aCtx` is done.
+//
+// Lines longer than the maximum line length make reading fail with
+// `bufio.ErrTooLong` while exceeding one of the other limits results
+// in an `ErrLimitExceeded` error.
+//
+// Parameters:
+// - `aCtx` The context to cancel reading.
+// - `aFilename` The name of the INI file to read.
+// - `aLimits` The limits to observe.
+//
+// Returns:
+// - `*TSectionList`: The list of sections of the INI file.
+// - `error`: A possible error condition.
+func NewWithContext(aCtx context.Context, aFilename string, aLimits TParseLimits) (*TSectionList, error) {
+	result := NewSectionList().SetParseLimits(aLimits)
+	if aFilename = strings.TrimSpace(aFilename); "" == aFilename {
+		return result, os.ErrNotExist
+	}
+
+	return result.SetFilename(aFilename).loadContext(aCtx)
+} // NewWithContext()
+
+/* _EoF_ */
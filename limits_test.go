@@ -0,0 +1,67 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestNewWithContext(t *testing.T) {
+	dir := t.TempDir()
+	longFile := filepath.Join(dir, "long.ini")
+	longValue := strings.Repeat("x", 100000)
+	_ = os.WriteFile(longFile, []byte("[s]\nkey = "+longValue+"\n"), 0600)
+	smallFile := filepath.Join(dir, "small.ini")
+	_ = os.WriteFile(smallFile, []byte("[a]\nk1 = 1\nk2 = 2\n[b]\nk3 = 3\n"), 0600)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		file    string
+		limits  TParseLimits
+		wantErr error
+	}{
+		{"0", context.Background(), "", TParseLimits{}, os.ErrNotExist},
+		{"1", context.Background(), longFile, TParseLimits{}, nil},
+		{"2", context.Background(), longFile, TParseLimits{MaxLineLength: 1024}, bufio.ErrTooLong},
+		{"3", context.Background(), smallFile, TParseLimits{MaxFileSize: 10}, ErrLimitExceeded},
+		{"4", context.Background(), smallFile, TParseLimits{MaxFileSize: 100}, nil},
+		{"5", context.Background(), smallFile, TParseLimits{MaxSections: 1}, ErrLimitExceeded},
+		{"6", context.Background(), smallFile, TParseLimits{MaxKeys: 2}, ErrLimitExceeded},
+		{"7", context.Background(), smallFile, TParseLimits{MaxSections: 2, MaxKeys: 3}, nil},
+		{"8", cancelled, smallFile, TParseLimits{}, context.Canceled},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithContext(tt.ctx, tt.file, tt.limits)
+			if (nil == tt.wantErr) != (nil == err) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("%q: NewWithContext() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+
+	sl, _ := NewIni(longFile)
+	if got, _ := sl.AsString("s", "key"); got != longValue {
+		t.Errorf("NewIni() long value has length %d, want %d",
+			len(got), len(longValue))
+	}
+} // TestNewWithContext()
+
+/* _EoF_ */
@@ -0,0 +1,275 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tSubscription` is a receiver of configuration snapshots.
+	tSubscription struct {
+		ch      chan *TSectionList // channel to deliver the snapshots
+		closed  bool               // the subscription was cancelled
+		key     string             // key to watch (empty: all)
+		mtx     sync.Mutex         // protects the channel and `closed`
+		section string             // section of the key to watch
+	}
+
+	// `TManager` owns a configuration list which can be reloaded and
+	// shared safely between goroutines.
+	//
+	// Each reload publishes a new snapshot; snapshots are never
+	// modified by the manager, so they must be treated as read-only
+	// by their users as well.
+	TManager struct {
+		binds   []*tBinding            // structs bound to sections
+		current *TSectionList          // the latest snapshot
+		mtx     sync.RWMutex           // protects the fields
+		nextID  int                    // ID of the next subscription
+		pub     sync.Mutex             // serialises the publication of snapshots
+		subs    map[int]*tSubscription // active subscriptions
+	}
+)
+
+// `keyChanged()` tells whether the value of `aKey` in `aSection`
+// differs between `aOld` and `aNew`.
+//
+// Parameters:
+// - `aOld` The previous snapshot.
+// - `aNew` The new snapshot.
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `bool`: `true` if the value was added, removed, or modified.
+func keyChanged(aOld, aNew *TSectionList, aSection, aKey string) bool {
+	_, oldValue, oldErr := aOld.rawValue(aSection, aKey)
+	_, newValue, newErr := aNew.rawValue(aSection, aKey)
+
+	return ((nil == oldErr) != (nil == newErr)) || (oldValue != newValue)
+} // keyChanged()
+
+// `deliver()` passes `aList` to the subscription's channel.
+//
+// A snapshot not yet received is replaced by `aList` so that slow
+// receivers always get the latest snapshot without blocking the sender.
+//
+// Parameters:
+// - `aList` The snapshot to deliver.
+func (sub *tSubscription) deliver(aList *TSectionList) {
+	sub.mtx.Lock()
+	defer sub.mtx.Unlock()
+
+	if sub.closed {
+		return
+	}
+	for {
+		select {
+		case sub.ch <- aList:
+			return
+		default:
+			select {
+			case <-sub.ch: // drop the outdated snapshot
+			default:
+			}
+		}
+	}
+} // deliver()
+
+// --------------------------------------------------------------------------
+
+// `Current()` returns the latest configuration snapshot.
+//
+// Returns:
+// - `*TSectionList`: The current (read-only) snapshot.
+func (m *TManager) Current() *TSectionList {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return m.current
+} // Current()
+
+// `Reload()` reads the configuration file again and publishes the
+// result (see `Update()`).
+//
+// Returns:
+// - `error`: A possible error reading the file; the current snapshot
+// is kept in that case.
+func (m *TManager) Reload() error {
+	list, err := m.Current().reload()
+	if nil != err {
+		return err
+	}
+	m.Update(list)
+
+	return nil
+} // Reload()
+
+// `subscribe()` registers a new subscription.
+//
+// Parameters:
+// - `aSection` The section of the key to watch.
+// - `aKey` The key to watch (empty: all changes).
+//
+// Returns:
+// - `<-chan *TSectionList`: The channel delivering the snapshots.
+// - `func()`: The function to cancel the subscription.
+func (m *TManager) subscribe(aSection, aKey string) (<-chan *TSectionList, func()) {
+	sub := &tSubscription{
+		ch:      make(chan *TSectionList, 1),
+		key:     aKey,
+		section: aSection,
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	id := m.nextID
+	m.nextID++
+	m.subs[id] = sub
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			m.mtx.Lock()
+			delete(m.subs, id)
+			m.mtx.Unlock()
+
+			sub.mtx.Lock()
+			defer sub.mtx.Unlock()
+			sub.closed = true
+			close(sub.ch)
+		})
+	}
+} // subscribe()
+
+// `Subscribe()` returns a channel receiving each newly published
+// configuration snapshot.
+//
+// The channel buffers just the latest snapshot, i.e. a receiver
+// falling behind misses intermediate snapshots but never blocks the
+// manager. The returned function cancels the subscription and closes
+// the channel.
+//
+// Example:
+//
+//	ch, cancel := mgr.Subscribe()
+//	defer cancel()
+//	for list := range ch {
+//		applyConfig(list)
+//	}
+//
+// Returns:
+// - `<-chan *TSectionList`: The channel delivering the snapshots.
+// - `func()`: The function to cancel the subscription.
+func (m *TManager) Subscribe() (<-chan *TSectionList, func()) {
+	return m.subscribe("", "")
+} // Subscribe()
+
+// `SubscribeKey()` returns a channel receiving the newly published
+// configuration snapshots in which the value of `aKey` in `aSection`
+// was added, removed, or modified.
+//
+// See `Subscribe()` for details.
+//
+// Parameters:
+// - `aSection` The name of the INI section (empty: default section).
+// - `aKey` The name of the key to watch.
+//
+// Returns:
+// - `<-chan *TSectionList`: The channel delivering the snapshots.
+// - `func()`: The function to cancel the subscription.
+func (m *TManager) SubscribeKey(aSection, aKey string) (<-chan *TSectionList, func()) {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return m.Subscribe()
+	}
+
+	return m.subscribe(aSection, aKey)
+} // SubscribeKey()
+
+// `Update()` publishes `aList` as the new configuration snapshot
+// notifying the interested subscribers and re-binding the structs
+// bound by `BindSection()`.
+//
+// The manager takes ownership of `aList` which must not be modified
+// afterwards. The subscribers and bound structs are updated after the
+// manager's lock was released, so they may call the manager's methods
+// (e.g. `Current()`) while holding their own locks.
+//
+// Parameters:
+// - `aList` The new snapshot (`nil` is ignored).
+func (m *TManager) Update(aList *TSectionList) {
+	if nil == aList {
+		return
+	}
+
+	// keep concurrent updates from delivering in the wrong order:
+	m.pub.Lock()
+	defer m.pub.Unlock()
+
+	m.mtx.Lock()
+	old := m.current
+	m.current = aList
+	subs := make([]*tSubscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		subs = append(subs, sub)
+	}
+	binds := slices.Clone(m.binds)
+	m.mtx.Unlock()
+
+	for _, sub := range subs {
+		if ("" == sub.key) || keyChanged(old, aList, sub.section, sub.key) {
+			sub.deliver(aList)
+		}
+	}
+	for _, binding := range binds {
+		if sectionChanged(old, aList, binding.section) {
+			_ = binding.rebind(aList) // keep the target on error
+		}
+	}
+} // Update()
+
+// `Watch()` monitors the configuration file publishing a new snapshot
+// whenever the file was modified.
+//
+// This method blocks until `aCtx` is cancelled; so it's usually
+// called in a goroutine of its own.
+//
+// Parameters:
+// - `aCtx` The context to stop watching.
+//
+// Returns:
+// - `error`: See `TSectionList.Watch()`.
+func (m *TManager) Watch(aCtx context.Context) error {
+	return m.Current().Watch(aCtx, m.Update)
+} // Watch()
+
+// `NewManager()` returns a new manager owning `aList`.
+//
+// Parameters:
+// - `aList` The initial configuration (`nil`: an empty list).
+//
+// Returns:
+// - `*TManager`: The new manager.
+func NewManager(aList *TSectionList) *TManager {
+	if nil == aList {
+		aList = NewSectionList()
+	}
+
+	return &TManager{
+		current: aList,
+		subs:    make(map[int]*tSubscription),
+	}
+} // NewManager()
+
+/* _EoF_ */
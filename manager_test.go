@@ -0,0 +1,88 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTManager_Subscribe(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "manager.ini")
+	write := func(aData string) {
+		if err := os.WriteFile(fName, []byte(aData), 0644); nil != err {
+			t.Fatal(err)
+		}
+	}
+	write("[server]\nport = 8080\nhost = localhost\n")
+
+	sl, err := NewIni(fName)
+	if nil != err {
+		t.Fatal(err)
+	}
+	mgr := NewManager(sl)
+	all, cancelAll := mgr.Subscribe()
+	defer cancelAll()
+	port, cancelPort := mgr.SubscribeKey("server", "port")
+	defer cancelPort()
+
+	tests := []struct {
+		name     string
+		data     string
+		wantPort bool
+	}{
+		{"host changed", "[server]\nport = 8080\nhost = example.com\n", false},
+		{"port changed", "[server]\nport = 9090\nhost = example.com\n", true},
+		{"port removed", "[server]\nhost = example.com\n", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write(tt.data)
+			if err := mgr.Reload(); nil != err {
+				t.Fatalf("Reload() error = %v", err)
+			}
+			select {
+			case list := <-all:
+				if list != mgr.Current() {
+					t.Error("Subscribe() didn't deliver the current snapshot")
+				}
+			default:
+				t.Error("Subscribe() got no snapshot")
+			}
+			select {
+			case <-port:
+				if !tt.wantPort {
+					t.Error("SubscribeKey() got unexpected snapshot")
+				}
+			default:
+				if tt.wantPort {
+					t.Error("SubscribeKey() got no snapshot")
+				}
+			}
+		})
+	}
+
+	// a slow receiver gets the latest snapshot only
+	mgr.Update(NewSectionList())
+	latest := NewSectionList()
+	mgr.Update(latest)
+	if got := <-all; got != latest {
+		t.Error("Subscribe() didn't deliver the latest snapshot")
+	}
+
+	cancelAll()
+	cancelAll() // must not panic
+	if _, ok := <-all; ok {
+		t.Error("Subscribe() channel not closed after cancel")
+	}
+} // TestTManager_Subscribe()
+
+/* _EoF_ */
@@ -0,0 +1,452 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `iniTag` is the name of the struct tag used to name the INI
+	// sections and keys.
+	iniTag = `ini`
+)
+
+var (
+	// `ErrInvalidTarget` is returned if the argument to `Marshal()`
+	// or `Unmarshal()` is not a (pointer to a) struct.
+	ErrInvalidTarget = errors.New("ini: target must be a non-nil pointer to a struct")
+
+	// `ErrUnsupportedType` is returned if a struct field's type can't
+	// be mapped to an INI value.
+	ErrUnsupportedType = errors.New("ini: unsupported field type")
+
+	// the type of `time.Duration` fields
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// the interface types of values converting themselves
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// `fieldName()` returns the INI name of `aField` and whether the field
+// should be mapped at all.
+//
+// The name is taken from the field's `ini:"..."` tag or, if there's
+// no tag, the field's name. A tag of `ini:"-"` skips the field as do
+// unexported fields.
+//
+// Parameters:
+// - `aField` The struct field to inspect.
+//
+// Returns:
+// - `string`: The section or key name to use.
+// - `bool`: `true` if the field should be mapped, `false` otherwise.
+func fieldName(aField reflect.StructField) (string, bool) {
+	if !aField.IsExported() {
+		return "", false
+	}
+
+	tag := aField.Tag.Get(iniTag)
+	if `-` == tag {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, `,`); "" != strings.TrimSpace(name) {
+		return strings.TrimSpace(name), true
+	}
+
+	return aField.Name, true
+} // fieldName()
+
+// `isSectionField()` reports whether `aType` is mapped to an INI section
+// rather than to a key/value pair.
+//
+// Parameters:
+// - `aType` The type of the struct field to inspect.
+//
+// Returns:
+// - `bool`: `true` if `aType` is a (pointer to a) struct.
+func isSectionField(aType reflect.Type) bool {
+	if reflect.Pointer == aType.Kind() {
+		aType = aType.Elem()
+	}
+	if reflect.PointerTo(aType).Implements(textUnmarshalerType) {
+		return false // a value which parses itself
+	}
+	if _, registered := converterFor(aType); registered {
+		return false // a value with its own converter
+	}
+
+	return reflect.Struct == aType.Kind()
+} // isSectionField()
+
+// `textUnmarshaler()` returns the `encoding.TextUnmarshaler` of
+// `aField` (if any).
+//
+// Nil pointer fields are allocated if their type implements the
+// interface.
+//
+// Parameters:
+// - `aField` The (settable) struct field to inspect.
+//
+// Returns:
+// - `encoding.TextUnmarshaler`: The field's unmarshaler or `nil`.
+func textUnmarshaler(aField reflect.Value) encoding.TextUnmarshaler {
+	if (reflect.Pointer == aField.Kind()) && aField.Type().Implements(textUnmarshalerType) {
+		if aField.IsNil() {
+			aField.Set(reflect.New(aField.Type().Elem()))
+		}
+		return aField.Interface().(encoding.TextUnmarshaler)
+	}
+	if aField.CanAddr() && aField.Addr().Type().Implements(textUnmarshalerType) {
+		return aField.Addr().Interface().(encoding.TextUnmarshaler)
+	}
+
+	return nil
+} // textUnmarshaler()
+
+// `setFieldValue()` converts `aValue` to the type of `aField` and
+// assigns it.
+//
+// Converters registered by `RegisterType()` are used first, then
+// types implementing `encoding.TextUnmarshaler` parse the value
+// themselves. Boolean values and numbers are interpreted observing the
+// list's dialect, boolean vocabulary (see `SetBoolVocabulary()`), and
+// number format (see `SetLenientNumbers()`) like the typed getters do.
+//
+// Parameters:
+// - `aList` The section list the value was read from.
+// - `aField` The (settable) struct field to update.
+// - `aValue` The INI value to convert.
+//
+// Returns:
+// - `error`: A possible conversion error.
+func setFieldValue(aList *TSectionList, aField reflect.Value, aValue string) error {
+	if ok, err := convertValue(aField, aValue); ok {
+		return err
+	}
+	if tu := textUnmarshaler(aField); nil != tu {
+		return tu.UnmarshalText([]byte(aValue))
+	}
+	if durationType == aField.Type() {
+		d, err := time.ParseDuration(aValue)
+		if nil != err {
+			return err
+		}
+		aField.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch aField.Kind() {
+	case reflect.Bool:
+		b, ok := aList.parseBool(aValue)
+		if !ok {
+			return fmt.Errorf("invalid boolean value %q", aValue)
+		}
+		aField.SetBool(b)
+
+	case reflect.Float32, reflect.Float64:
+		f64, err := strconv.ParseFloat(aList.floatNumber(aValue), aField.Type().Bits())
+		if nil != err {
+			return err
+		}
+		aField.SetFloat(f64)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, base := aList.intNumber(aValue)
+		i64, err := strconv.ParseInt(number, base, aField.Type().Bits())
+		if nil != err {
+			return err
+		}
+		aField.SetInt(i64)
+
+	case reflect.String:
+		aField.SetString(aValue)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, base := aList.intNumber(aValue)
+		ui64, err := strconv.ParseUint(number, base, aField.Type().Bits())
+		if nil != err {
+			return err
+		}
+		aField.SetUint(ui64)
+
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, aField.Type())
+	}
+
+	return nil
+} // setFieldValue()
+
+// `fieldValueString()` returns the INI representation of `aField`.
+//
+// Converters registered by `RegisterType()` are used first, then
+// types implementing `encoding.TextMarshaler` format the value
+// themselves; a nil pointer results in an empty value.
+//
+// Parameters:
+// - `aField` The struct field to convert.
+//
+// Returns:
+// - `string`: The field's value as an INI value.
+// - `error`: A possible conversion error.
+func fieldValueString(aField reflect.Value) (string, error) {
+	if result, ok := formatValue(aField); ok {
+		return result, nil
+	}
+	if aField.Type().Implements(textMarshalerType) ||
+		(aField.CanAddr() && aField.Addr().Type().Implements(textMarshalerType)) {
+		if (reflect.Pointer == aField.Kind()) && aField.IsNil() {
+			return "", nil
+		}
+		if !aField.Type().Implements(textMarshalerType) {
+			aField = aField.Addr()
+		}
+		text, err := aField.Interface().(encoding.TextMarshaler).MarshalText()
+
+		return string(text), err
+	}
+	if durationType == aField.Type() {
+		return time.Duration(aField.Int()).String(), nil
+	}
+
+	switch aField.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(aField.Bool()), nil
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(aField.Float(), 'g', -1, aField.Type().Bits()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(aField.Int(), 10), nil
+
+	case reflect.String:
+		return aField.String(), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(aField.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, aField.Type())
+} // fieldValueString()
+
+// `marshalSection()` adds all key fields of `aStruct` to `aSection`
+// of `aList`.
+//
+// Parameters:
+// - `aList` The section list to update.
+// - `aSection` The name of the INI section to use.
+// - `aStruct` The struct value to read from.
+// - `aKeysOnly` If `true` fields of struct type are rejected.
+//
+// Returns:
+// - `error`: A possible conversion error.
+func marshalSection(aList *TSectionList, aSection string, aStruct reflect.Value, aKeysOnly bool) error {
+	sType := aStruct.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+		if isSectionField(field.Type) {
+			if aKeysOnly {
+				return fmt.Errorf("[%s] %s: %w: %s",
+					aSection, name, ErrUnsupportedType, field.Type)
+			}
+			continue // handled by the caller
+		}
+
+		value, err := fieldValueString(aStruct.Field(i))
+		if nil != err {
+			return fmt.Errorf("[%s] %s: %w", aSection, name, err)
+		}
+		aList.AddSectionKey(aSection, name, value)
+	}
+
+	return nil
+} // marshalSection()
+
+// `unmarshalSection()` copies the values of `aSection` in `aList`
+// into the key fields of `aStruct`.
+//
+// Fields without a corresponding key in `aSection` are left untouched.
+//
+// Parameters:
+// - `aList` The section list to read from.
+// - `aSection` The name of the INI section to use.
+// - `aStruct` The (settable) struct value to update.
+// - `aKeysOnly` If `true` fields of struct type are rejected.
+//
+// Returns:
+// - `error`: A possible conversion error.
+func unmarshalSection(aList *TSectionList, aSection string, aStruct reflect.Value, aKeysOnly bool) error {
+	sType := aStruct.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+		if isSectionField(field.Type) {
+			if aKeysOnly {
+				return fmt.Errorf("[%s] %s: %w: %s",
+					aSection, name, ErrUnsupportedType, field.Type)
+			}
+			continue // handled by the caller
+		}
+
+		value, err := aList.GetString(aSection, name)
+		if nil != err {
+			continue
+		}
+		if err := setFieldValue(aList, aStruct.Field(i), value); nil != err {
+			return fmt.Errorf("[%s] %s: %w", aSection, name, err)
+		}
+	}
+
+	return nil
+} // unmarshalSection()
+
+// --------------------------------------------------------------------------
+
+// `Marshal()` returns a new section list holding the data of the
+// given struct.
+//
+// Fields of struct type (or pointer to struct) become INI sections
+// whose fields in turn become the section's key/value pairs; all other
+// fields become key/value pairs of the default section.
+// The section and key names are taken from the fields' `ini:"..."`
+// tags or, if there's no tag, the fields' names. Fields tagged with
+// `ini:"-"` and unexported fields are skipped.
+//
+// Example:
+//
+//	type tConfig struct {
+//		Name   string `ini:"name"`
+//		Server struct {
+//			Port int `ini:"port"`
+//		} `ini:"server"`
+//	}
+//	sl, err := ini.Marshal(&cfg)
+//
+// Parameters:
+// - `aStruct` The struct (or pointer to struct) to convert.
+//
+// Returns:
+// - `*TSectionList`: The INI data of `aStruct`.
+// - `error`: A possible conversion error.
+func Marshal(aStruct any) (*TSectionList, error) {
+	result := NewSectionList()
+
+	sValue := reflect.ValueOf(aStruct)
+	if reflect.Pointer == sValue.Kind() {
+		if sValue.IsNil() {
+			return result, ErrInvalidTarget
+		}
+		sValue = sValue.Elem()
+	}
+	if reflect.Struct != sValue.Kind() {
+		return result, ErrInvalidTarget
+	}
+
+	// first the key/value pairs of the default section …
+	if err := marshalSection(result, result.defSect, sValue, false); nil != err {
+		return result, err
+	}
+
+	// … then the sections
+	sType := sValue.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if (!ok) || (!isSectionField(field.Type)) {
+			continue
+		}
+
+		fValue := sValue.Field(i)
+		if reflect.Pointer == fValue.Kind() {
+			if fValue.IsNil() {
+				continue
+			}
+			fValue = fValue.Elem()
+		}
+		result.addSection(name)
+		if err := marshalSection(result, name, fValue, true); nil != err {
+			return result, err
+		}
+	}
+
+	return result, nil
+} // Marshal()
+
+// `Unmarshal()` copies the INI data into the struct pointed to by
+// `aStruct`.
+//
+// Fields of struct type (or pointer to struct) are filled from the
+// INI section of the same name while all other fields are filled from
+// the default section. Fields without a corresponding INI section or
+// key are left untouched so they can hold default values.
+//
+// See `Marshal()` for the naming rules.
+//
+// Parameters:
+// - `aStruct` A pointer to the struct to fill.
+//
+// Returns:
+// - `error`: A possible conversion error.
+func (sl *TSectionList) Unmarshal(aStruct any) error {
+	sValue := reflect.ValueOf(aStruct)
+	if (reflect.Pointer != sValue.Kind()) || sValue.IsNil() {
+		return ErrInvalidTarget
+	}
+	if sValue = sValue.Elem(); reflect.Struct != sValue.Kind() {
+		return ErrInvalidTarget
+	}
+
+	if _, exists := sl.section(sl.defSect); exists {
+		if err := unmarshalSection(sl, sl.defSect, sValue, false); nil != err {
+			return err
+		}
+	}
+
+	sType := sValue.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if (!ok) || (!isSectionField(field.Type)) {
+			continue
+		}
+		if _, exists := sl.section(name); !exists {
+			continue
+		}
+
+		fValue := sValue.Field(i)
+		if reflect.Pointer == fValue.Kind() {
+			if fValue.IsNil() {
+				fValue.Set(reflect.New(field.Type.Elem()))
+			}
+			fValue = fValue.Elem()
+		}
+		if err := unmarshalSection(sl, name, fValue, true); nil != err {
+			return err
+		}
+	}
+
+	return nil
+} // Unmarshal()
+
+/* _EoF_ */
@@ -0,0 +1,239 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	tTestSQL struct {
+		Hostname string `ini:"hostname"`
+		Port     uint16 `ini:"port"`
+		Password string `ini:"-"`
+	}
+
+	tTestConfig struct {
+		Name    string        `ini:"ach jeh"`
+		Ratio   float64       `ini:"ratio"`
+		Debug   bool          `ini:"debug"`
+		Timeout time.Duration `ini:"timeout"`
+		General struct {
+			LogLevel   int8 `ini:"loglevel"`
+			MinMsgSize int  `ini:"minmsgsize"`
+		} `ini:"general"`
+		SQL     *tTestSQL `ini:"sql0"`
+		Missing *tTestSQL `ini:"n.a."`
+	}
+
+	tTestLevel int
+
+	tTestTextConfig struct {
+		Level tTestLevel `ini:"level"`
+		Addr  net.IP     `ini:"addr"`
+		Since time.Time  `ini:"since"`
+		Until *time.Time `ini:"until"`
+	}
+)
+
+func (l tTestLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 0:
+		return []byte("low"), nil
+	case 1:
+		return []byte("high"), nil
+	}
+
+	return nil, fmt.Errorf("invalid level %d", int(l))
+} // MarshalText()
+
+func (l *tTestLevel) UnmarshalText(aText []byte) error {
+	switch strings.ToLower(string(aText)) {
+	case "low":
+		*l = 0
+	case "high":
+		*l = 1
+	default:
+		return fmt.Errorf("invalid level %q", aText)
+	}
+
+	return nil
+} // UnmarshalText()
+
+func TestMarshal(t *testing.T) {
+	cfg := tTestConfig{Name: "macht nix", Ratio: 0.25, Debug: true, Timeout: 90 * time.Second}
+	cfg.General.LogLevel = 8
+	cfg.SQL = &tTestSQL{"localhost", 3306, "secret"}
+
+	wl := NewSectionList()
+	wl.AddSectionKey("", "ach jeh", "macht nix")
+	wl.AddSectionKey("", "ratio", "0.25")
+	wl.AddSectionKey("", "debug", "true")
+	wl.AddSectionKey("", "timeout", "1m30s")
+	wl.AddSectionKey("general", "loglevel", "8")
+	wl.AddSectionKey("general", "minmsgsize", "0")
+	wl.AddSectionKey("sql0", "hostname", "localhost")
+	wl.AddSectionKey("sql0", "port", "3306")
+
+	tests := []struct {
+		name    string
+		args    any
+		want    *TSectionList
+		wantErr bool
+	}{
+		{"0", nil, nil, true},
+		{"1", 1234, nil, true},
+		{"2", cfg, wl, false},
+		{"3", &cfg, wl, false},
+		{"4", &struct{ C complex64 }{}, nil, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: Marshal() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+				return
+			}
+			if (nil != tt.want) && !got.CompareTo(tt.want) {
+				t.Errorf("%q: Marshal() = {%v},\nwant {%v}",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestMarshal()
+
+func TestTSectionList_Unmarshal(t *testing.T) {
+	sl, _ := NewIni(inFileName)
+	bl := prepSectionList()
+	bl.AddSectionKey("general", "loglevel", "many")
+
+	var cfg tTestConfig
+	tests := []struct {
+		name    string
+		list    *TSectionList
+		args    any
+		wantErr error
+	}{
+		{"0", sl, nil, ErrInvalidTarget},
+		{"1", sl, cfg, ErrInvalidTarget},
+		{"2", sl, &cfg, nil},
+		{"3", bl, &cfg, errors.New("parse")},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.list.Unmarshal(tt.args)
+			if (nil == err) != (nil == tt.wantErr) {
+				t.Errorf("%q: TSectionList.Unmarshal() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if (nil != err) && (ErrInvalidTarget == tt.wantErr) && !errors.Is(err, ErrInvalidTarget) {
+				t.Errorf("%q: TSectionList.Unmarshal() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+		})
+	}
+
+	if ("macht nix" != cfg.Name) || (8 != cfg.General.LogLevel) ||
+		(32 != cfg.General.MinMsgSize) || (nil == cfg.SQL) ||
+		("localhost" != cfg.SQL.Hostname) || (0 != cfg.SQL.Port) ||
+		(nil != cfg.Missing) {
+		t.Errorf("TSectionList.Unmarshal() = %+v", cfg)
+	}
+} // TestTSectionList_Unmarshal()
+
+func TestTSectionList_Unmarshal_bool(t *testing.T) {
+	sl := NewSectionList().SetDialect(DialectPHP)
+	sl.AddSectionKey("", "debug", "Off")
+	sl.AddSectionKey("", "verbose", "yes")
+
+	var cfg struct {
+		Debug   bool `ini:"debug"`
+		Verbose bool `ini:"verbose"`
+	}
+	cfg.Debug = true
+	if err := sl.Unmarshal(&cfg); nil != err {
+		t.Fatalf("TSectionList.Unmarshal() error = %v", err)
+	}
+	if cfg.Debug || !cfg.Verbose {
+		t.Errorf("TSectionList.Unmarshal() = %+v, want {false true}", cfg)
+	}
+} // TestTSectionList_Unmarshal_bool()
+
+func TestTSectionList_Unmarshal_numbers(t *testing.T) {
+	sl := NewSectionList().SetLenientNumbers(true)
+	sl.AddSectionKey("", "mode", "0o755")
+	sl.AddSectionKey("", "size", "0x1F")
+	sl.AddSectionKey("", "ratio", "1,000.5")
+
+	var cfg struct {
+		Mode  uint32  `ini:"mode"`
+		Size  int     `ini:"size"`
+		Ratio float64 `ini:"ratio"`
+	}
+	if err := sl.Unmarshal(&cfg); nil != err {
+		t.Fatalf("TSectionList.Unmarshal() error = %v", err)
+	}
+	if (0755 != cfg.Mode) || (31 != cfg.Size) || (1000.5 != cfg.Ratio) {
+		t.Errorf("TSectionList.Unmarshal() = %+v", cfg)
+	}
+
+	var size int
+	if err := sl.AsType("", "size", &size); (nil != err) || (31 != size) {
+		t.Errorf("TSectionList.AsType() = %d, %v, want 31", size, err)
+	}
+} // TestTSectionList_Unmarshal_numbers()
+
+func TestMarshal_textMarshaler(t *testing.T) {
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cfg := tTestTextConfig{
+		Level: 1,
+		Addr:  net.ParseIP("192.168.1.1"),
+		Since: since,
+	}
+
+	sl, err := Marshal(&cfg)
+	if nil != err {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for key, want := range map[string]string{
+		"level": "high",
+		"addr":  "192.168.1.1",
+		"since": "2024-05-01T12:00:00Z",
+		"until": "",
+	} {
+		if got, _ := sl.AsString("", key); want != got {
+			t.Errorf("Marshal() %s = %q, want %q", key, got, want)
+		}
+	}
+
+	_ = sl.UpdateSectKeyStr("", "until", "2024-06-01T00:00:00Z")
+	var got tTestTextConfig
+	if err = sl.Unmarshal(&got); nil != err {
+		t.Fatalf("TSectionList.Unmarshal() error = %v", err)
+	}
+	if (1 != got.Level) || !got.Addr.Equal(cfg.Addr) || !got.Since.Equal(since) ||
+		(nil == got.Until) || (6 != got.Until.Month()) {
+		t.Errorf("TSectionList.Unmarshal() = %+v", got)
+	}
+
+	_ = sl.UpdateSectKeyStr("", "level", "medium")
+	if err = sl.Unmarshal(&got); nil == err {
+		t.Error("TSectionList.Unmarshal() expected an error")
+	}
+} // TestMarshal_textMarshaler()
+
+/* _EoF_ */
@@ -0,0 +1,162 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "maps"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TConflict` describes a key changed differently in both lists
+	// given to `Merge3()`.
+	//
+	// A value of a key missing in the respective list is empty.
+	TConflict struct {
+		Section string // name of the INI section
+		Key     string // name of the conflicting key
+		Base    string // the key's value in the common base
+		Ours    string // the key's value in the current list (kept)
+		Theirs  string // the key's value in the other list
+	}
+
+	// `tMergeValue` is a key's value and whether it exists at all.
+	tMergeValue struct {
+		value  string
+		exists bool
+	}
+)
+
+// `mergeValue()` returns the value of `aKey` in `aSection` of `aList`.
+//
+// Parameters:
+// - `aList` The list to lookup (may be `nil`).
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `tMergeValue`: The key's value and whether it exists.
+func mergeValue(aList *TSectionList, aSection, aKey string) tMergeValue {
+	if nil == aList {
+		return tMergeValue{}
+	}
+	kl, exists := aList.section(aSection)
+	if !exists {
+		return tMergeValue{}
+	}
+	value, exists := kl.AsString(aKey)
+
+	return tMergeValue{value, exists}
+} // mergeValue()
+
+// `copyList()` returns a deep copy of the list's sections, key/value
+// pairs, and comments using the list's settings.
+//
+// Returns:
+// - `*TSectionList`: The copy of the current list.
+func (sl *TSectionList) copyList() *TSectionList {
+	result := sl.copySettings(NewSectionList())
+	defer sl.rlock()()
+
+	result.comments = maps.Clone(sl.comments)
+	result.trailer = append([]string(nil), sl.trailer...)
+	for _, name := range sl.secOrder {
+		if kl, exists := sl.sections[name]; exists {
+			result.secOrder = append(result.secOrder, name)
+			result.sections[name] = kl.Copy()
+		}
+	}
+
+	return result
+} // copyList()
+
+// `Merge3()` performs a three-way merge of the current list (e.g. the
+// user's modified configuration) and `aTheirs` (e.g. a newly shipped
+// default configuration) based on their common ancestor `aBase`.
+//
+// Changes made in only one of the lists are taken over; keys changed
+// differently in both lists keep the current list's value and are
+// reported as conflicts. Both the current list and `aTheirs` are left
+// untouched while the returned list keeps the current list's comments
+// and filename.
+//
+// Parameters:
+// - `aBase` The common ancestor of both lists.
+// - `aTheirs` The other list to merge.
+//
+// Returns:
+// - `*TSectionList`: The merged list.
+// - `[]TConflict`: The conflicting keys (if any).
+func (sl *TSectionList) Merge3(aBase, aTheirs *TSectionList) (*TSectionList, []TConflict) {
+	var conflicts []TConflict
+	result := sl.copyList()
+
+	// collect all section and key names in a stable order:
+	var sections []string
+	seenSect := make(map[string]struct{})
+	keys := make(map[string][]string)
+	seenKey := make(tSeenKeys)
+	for _, list := range []*TSectionList{sl, aTheirs, aBase} {
+		if nil == list {
+			continue
+		}
+		for _, ns := range list.OrderedSections() {
+			name := ns.Name
+			if _, dup := seenSect[name]; !dup {
+				seenSect[name] = struct{}{}
+				sections = append(sections, name)
+			}
+			for _, kv := range ns.Section.KeyVals() {
+				id := originID(name, kv.Key)
+				if _, dup := seenKey[id]; !dup {
+					seenKey[id] = struct{}{}
+					keys[name] = append(keys[name], kv.Key)
+				}
+			}
+		}
+	}
+
+	for _, section := range sections {
+		for _, key := range keys[section] {
+			base := mergeValue(aBase, section, key)
+			ours := mergeValue(sl, section, key)
+			theirs := mergeValue(aTheirs, section, key)
+
+			switch {
+			case (ours == theirs) || (theirs == base):
+				continue // nothing to take over
+
+			case ours == base:
+				if theirs.exists {
+					result.updateSectKey(section, key, theirs.value)
+				} else {
+					result.RemoveSectionKey(section, key)
+				}
+
+			default:
+				conflicts = append(conflicts, TConflict{
+					Section: section,
+					Key:     key,
+					Base:    base.value,
+					Ours:    ours.value,
+					Theirs:  theirs.value,
+				})
+			}
+		}
+
+		// drop sections removed by `aTheirs` and now empty:
+		if kl, exists := result.sections[section]; exists && (0 == kl.Len()) {
+			if (nil != aBase) && aBase.HasSection(section) &&
+				(nil != aTheirs) && !aTheirs.HasSection(section) {
+				result.RemoveSection(section)
+			}
+		}
+	}
+
+	return result, conflicts
+} // Merge3()
+
+/* _EoF_ */
@@ -0,0 +1,92 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepMergeList(aData string) *TSectionList {
+	result := NewSectionList()
+	_, _ = result.read(bufio.NewScanner(strings.NewReader(aData)))
+
+	return result
+} // prepMergeList()
+
+func TestTSectionList_Merge3(t *testing.T) {
+	base := prepMergeList("[app]\nname = demo\ncolor = red\nsize = 1\nold = x\n\n[legacy]\nkey = 1\n")
+	ours := prepMergeList("[app]\n; my colour\nname = demo\ncolor = blue\nsize = 2\nold = x\nmine = yes\n\n[legacy]\nkey = 1\n")
+	theirs := prepMergeList("[app]\nname = demo2\ncolor = red\nsize = 3\nnew = added\n")
+
+	got, conflicts := ours.Merge3(base, theirs)
+
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		want    string
+		wantOK  bool
+	}{
+		{"1", "app", "name", "demo2", true}, // changed by them
+		{"2", "app", "color", "blue", true}, // changed by us
+		{"3", "app", "size", "2", true},     // conflict: ours kept
+		{"4", "app", "old", "", false},      // removed by them
+		{"5", "app", "mine", "yes", true},   // added by us
+		{"6", "app", "new", "added", true},  // added by them
+		{"7", "legacy", "key", "", false},   // section removed by them
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := got.AsString(tt.section, tt.key)
+			if (value != tt.want) || (ok != tt.wantOK) {
+				t.Errorf("%q: Merge3() [%s] %s = %q, %v, want %q, %v",
+					tt.name, tt.section, tt.key, value, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	want := TConflict{"app", "size", "1", "2", "3"}
+	if (1 != len(conflicts)) || (want != conflicts[0]) {
+		t.Errorf("Merge3() conflicts = %v, want [%v]", conflicts, want)
+	}
+	if got.HasSection("legacy") {
+		t.Error("Merge3() kept section removed by them")
+	}
+	if !strings.Contains(got.String(), "; my colour") {
+		t.Error("Merge3() lost our comments")
+	}
+	if v, _ := ours.AsString("app", "name"); "demo" != v {
+		t.Error("Merge3() modified the current list")
+	}
+} // TestTSectionList_Merge3()
+
+func TestTSectionList_Merge3_settings(t *testing.T) {
+	t.Setenv("INI_MERGE3_TEST", "expanded")
+	base := prepMergeList("[app]\npath = $INI_MERGE3_TEST\n")
+	ours := prepMergeList("[app]\npath = $INI_MERGE3_TEST\n").
+		SetExpandEnv(true).
+		SetLenientNumbers(true).
+		SetFileMode(0640, false)
+	ours.interpolate, ours.strict, ours.dupPolicy = true, true, KeepFirst
+
+	got, _ := ours.Merge3(base, base)
+	if v, _ := got.AsString("app", "path"); "expanded" != v {
+		t.Errorf("Merge3() AsString() = %q, want %q", v, "expanded")
+	}
+
+	if !got.lenientNum || (0640 != got.filePerm) || !got.forcePerm ||
+		!got.interpolate || !got.strict || (KeepFirst != got.dupPolicy) {
+		t.Error("Merge3() lost the current list's settings")
+	}
+} // TestTSectionList_Merge3_settings()
+
+/* _EoF_ */
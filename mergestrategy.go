@@ -0,0 +1,247 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TMergeStrategy` determines how keys existing in both lists
+	// (or sections) with different values are handled by `MergeWith()`.
+	TMergeStrategy int
+
+	// `TMergeResolver()` returns the value to use for `aKey` in
+	// `aSection` which has the value `aOld` in the current list (or
+	// section) and `aNew` in the one to merge.
+	//
+	// For sections `aSection` is empty.
+	//
+	// see `MergeFunc()`
+	TMergeResolver func(aSection, aKey, aOld, aNew string) string
+)
+
+const (
+	// `Overwrite` uses the value of the merged list (default).
+	Overwrite TMergeStrategy = iota
+
+	// `KeepExisting` keeps the current list's value.
+	KeepExisting
+
+	// `ErrorOnConflict` makes merging fail without modifying the list.
+	ErrorOnConflict
+)
+
+var (
+	// `ErrMergeConflict` is returned by the `ErrorOnConflict` strategy.
+	ErrMergeConflict = errors.New("ini: merge conflict")
+)
+
+// `String()` returns the name of the strategy.
+//
+// Returns:
+// - `string`: The strategy's name.
+func (ms TMergeStrategy) String() string {
+	switch ms {
+	case Overwrite:
+		return "Overwrite"
+	case KeepExisting:
+		return "KeepExisting"
+	case ErrorOnConflict:
+		return "ErrorOnConflict"
+	}
+
+	return fmt.Sprintf("TMergeStrategy(%d)", int(ms))
+} // String()
+
+// `keepExisting()` is the `TMergeResolver` of the `KeepExisting` strategy.
+func keepExisting(aSection, aKey, aOld, aNew string) string {
+	return aOld
+} // keepExisting()
+
+// --------------------------------------------------------------------------
+
+// `conflict()` returns the first key of `aSection` whose value differs
+// from the current one.
+//
+// Parameters:
+// - `aSection` The INI section to compare with.
+//
+// Returns:
+// - `string`: The conflicting key or an empty string if there's none.
+func (kl *TSection) conflict(aSection *TSection) string {
+	aSection.mtx.RLock()
+	defer aSection.mtx.RUnlock()
+
+	for _, kv := range aSection.data {
+		if old, ok := kl.value(kv.Key); ok && (old != kv.Value) {
+			return kv.Key
+		}
+	}
+
+	return ""
+} // conflict()
+
+// `MergeFunc()` merges all key/value pairs of `aSection` into this
+// section calling `aResolver` for each key existing in both sections
+// with different values.
+//
+// A `nil` resolver overwrites the existing values (like `Merge()`).
+//
+// Parameters:
+// - `aSection`: The INI section to merge with this section.
+// - `aResolver`: The function returning the value to use.
+//
+// Returns:
+// - `*TSection`: This section added/updated from `aSection`.
+func (kl *TSection) MergeFunc(aSection *TSection, aResolver TMergeResolver) *TSection {
+	if (nil == aSection) || (kl == aSection) {
+		return kl
+	}
+	aSection.mtx.RLock()
+	defer aSection.mtx.RUnlock()
+	kl.mtx.Lock()
+	defer kl.mtx.Unlock()
+
+	for _, kv := range aSection.data {
+		value := kv.Value
+		if old, ok := kl.value(kv.Key); ok && (old != value) && (nil != aResolver) {
+			value = aResolver("", kv.Key, old, value)
+		}
+		kl.insert(TKeyVal{kv.Key, value})
+	}
+
+	return kl
+} // MergeFunc()
+
+// `MergeWith()` merges all key/value pairs of `aSection` into this
+// section observing `aStrategy` for keys existing in both sections
+// with different values.
+//
+// Parameters:
+// - `aSection`: The INI section to merge with this section.
+// - `aStrategy`: The merge strategy to use.
+//
+// Returns:
+// - `error`: `ErrMergeConflict` with the `ErrorOnConflict` strategy.
+func (kl *TSection) MergeWith(aSection *TSection, aStrategy TMergeStrategy) error {
+	if (nil == aSection) || (kl == aSection) {
+		return nil
+	}
+
+	switch aStrategy {
+	case KeepExisting:
+		kl.MergeFunc(aSection, keepExisting)
+
+	case ErrorOnConflict:
+		kl.mtx.RLock()
+		key := kl.conflict(aSection)
+		kl.mtx.RUnlock()
+		if "" != key {
+			return fmt.Errorf("%w: %s", ErrMergeConflict, key)
+		}
+		kl.MergeFunc(aSection, nil)
+
+	default:
+		kl.MergeFunc(aSection, nil)
+	}
+
+	return nil
+} // MergeWith()
+
+// --------------------------------------------------------------------------
+
+// `MergeFunc()` merges all INI sections with all key/value pairs of
+// `aINI` into this list calling `aResolver` for each key existing in
+// both lists with different values.
+//
+// A `nil` resolver overwrites the existing values (like `Merge()`).
+//
+// Parameters:
+// - `aINI` The INI sections to merge with this list.
+// - `aResolver`: The function returning the value to use.
+//
+// Returns:
+// - `*TSectionList`: This sections list merged with the other one.
+func (sl *TSectionList) MergeFunc(aINI *TSectionList, aResolver TMergeResolver) *TSectionList {
+	if (nil == aINI) || (sl == aINI) || sl.readOnly {
+		return sl
+	}
+
+	for _, ns := range aINI.OrderedSections() {
+		name := ns.Name
+		for _, kv := range ns.Section.KeyVals() {
+			value := kv.Value
+			old, ok := sl.GetSection(name).AsString(kv.Key)
+			if ok && (old != value) && (nil != aResolver) {
+				value = aResolver(name, kv.Key, old, value)
+			}
+			if ok && (old == value) {
+				continue // keep the current value and its origin
+			}
+			sl.AddSectionKey(name, kv.Key, value)
+			if file, line, found := aINI.Origin(name, kv.Key); found && (value == kv.Value) {
+				sl.setOrigin(name, kv.Key, file, line)
+			}
+		}
+	}
+
+	return sl
+} // MergeFunc()
+
+// `MergeWith()` merges all INI sections with all key/value pairs of
+// `aINI` into this list observing `aStrategy` for keys existing in both
+// lists with different values.
+//
+// With the `ErrorOnConflict` strategy the list is left untouched if
+// there's any conflict.
+//
+// Parameters:
+// - `aINI` The INI sections to merge with this list.
+// - `aStrategy`: The merge strategy to use.
+//
+// Returns:
+// - `error`: `ErrMergeConflict` with the `ErrorOnConflict` strategy.
+func (sl *TSectionList) MergeWith(aINI *TSectionList, aStrategy TMergeStrategy) error {
+	if (nil == aINI) || (sl == aINI) {
+		return nil
+	}
+	if sl.readOnly {
+		return ErrReadOnly
+	}
+
+	switch aStrategy {
+	case KeepExisting:
+		sl.MergeFunc(aINI, keepExisting)
+
+	case ErrorOnConflict:
+		for _, ns := range aINI.OrderedSections() {
+			name, other := ns.Name, ns.Section
+			kl, exists := sl.section(name)
+			if !exists {
+				continue
+			}
+			kl.mtx.RLock()
+			key := kl.conflict(other)
+			kl.mtx.RUnlock()
+			if "" != key {
+				return fmt.Errorf("%w: [%s] %s", ErrMergeConflict, name, key)
+			}
+		}
+		sl.MergeFunc(aINI, nil)
+
+	default:
+		sl.MergeFunc(aINI, nil)
+	}
+
+	return nil
+} // MergeWith()
+
+/* _EoF_ */
@@ -0,0 +1,139 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTMergeStrategy_String(t *testing.T) {
+	tests := []struct {
+		ms   TMergeStrategy
+		want string
+	}{
+		{Overwrite, "Overwrite"},
+		{KeepExisting, "KeepExisting"},
+		{ErrorOnConflict, "ErrorOnConflict"},
+		{TMergeStrategy(9), "TMergeStrategy(9)"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.ms.String(); got != tt.want {
+				t.Errorf("TMergeStrategy.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+} // TestTMergeStrategy_String()
+
+func TestTSection_MergeWith(t *testing.T) {
+	prep := func() (*TSection, *TSection) {
+		kl := NewSection()
+		kl.AddKey("a", "1")
+		kl.AddKey("b", "2")
+		other := NewSection()
+		other.AddKey("b", "20")
+		other.AddKey("c", "30")
+		return kl, other
+	}
+	tests := []struct {
+		name     string
+		strategy TMergeStrategy
+		wantB    string
+		wantC    string
+		wantErr  bool
+	}{
+		{"1", Overwrite, "20", "30", false},
+		{"2", KeepExisting, "2", "30", false},
+		{"3", ErrorOnConflict, "2", "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kl, other := prep()
+			err := kl.MergeWith(other, tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: TSection.MergeWith() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			b, _ := kl.AsString("b")
+			c, _ := kl.AsString("c")
+			if (b != tt.wantB) || (c != tt.wantC) {
+				t.Errorf("%q: TSection.MergeWith() b, c = %q, %q, want %q, %q",
+					tt.name, b, c, tt.wantB, tt.wantC)
+			}
+		})
+	}
+
+	kl, other := prep()
+	kl.MergeFunc(other, func(aSection, aKey, aOld, aNew string) string {
+		return aOld + "+" + aNew
+	})
+	if b, _ := kl.AsString("b"); "2+20" != b {
+		t.Errorf("TSection.MergeFunc() b = %q, want %q", b, "2+20")
+	}
+} // TestTSection_MergeWith()
+
+func TestTSectionList_MergeWith(t *testing.T) {
+	prep := func() (*TSectionList, *TSectionList) {
+		sl := NewSectionList()
+		sl.AddSectionKey("s", "a", "1")
+		sl.AddSectionKey("s", "b", "2")
+		other := NewSectionList()
+		other.AddSectionKey("s", "b", "20")
+		other.AddSectionKey("t", "c", "30")
+		return sl, other
+	}
+	tests := []struct {
+		name     string
+		strategy TMergeStrategy
+		wantB    string
+		wantC    string
+		wantErr  bool
+	}{
+		{"1", Overwrite, "20", "30", false},
+		{"2", KeepExisting, "2", "30", false},
+		{"3", ErrorOnConflict, "2", "", true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl, other := prep()
+			err := sl.MergeWith(other, tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%q: TSectionList.MergeWith() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrMergeConflict) {
+				t.Errorf("%q: TSectionList.MergeWith() error = %v, want %v",
+					tt.name, err, ErrMergeConflict)
+			}
+			b, _ := sl.AsString("s", "b")
+			c, _ := sl.AsString("t", "c")
+			if (b != tt.wantB) || (c != tt.wantC) {
+				t.Errorf("%q: TSectionList.MergeWith() b, c = %q, %q, want %q, %q",
+					tt.name, b, c, tt.wantB, tt.wantC)
+			}
+		})
+	}
+
+	sl, other := prep()
+	var calls int
+	sl.MergeFunc(other, func(aSection, aKey, aOld, aNew string) string {
+		calls++
+		return aSection + ":" + aKey
+	})
+	if b, _ := sl.AsString("s", "b"); ("s:b" != b) || (1 != calls) {
+		t.Errorf("TSectionList.MergeFunc() b = %q (%d calls), want %q (1 call)",
+			b, calls, "s:b")
+	}
+} // TestTSectionList_MergeWith()
+
+/* _EoF_ */
@@ -0,0 +1,156 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `blockQuote` delimits values spanning several lines.
+	blockQuote = `"""`
+)
+
+var (
+	// match: key = """value
+	isBlockStartRE = regexp.MustCompile(`^([^=]+?)\s*=\s*"""(.*)$`)
+)
+
+type (
+	// `tValueBlock` collects the lines of a triple-quoted value like
+	//
+	//	key = """
+	//	first line
+	//	  second line
+	//	"""
+	tValueBlock struct {
+		key     string   // the value's key
+		lines   []string // the value's lines read so far
+		lineNum int      // number of the block's first line
+		done    bool     // whether the closing quotes were found
+	}
+)
+
+// `isIndented()` checks whether `aLine` starts with whitespace.
+//
+// Parameters:
+// - `aLine` The raw INI line to check.
+//
+// Returns:
+// - `bool`: `true` if `aLine` is indented, `false` otherwise.
+func isIndented(aLine string) bool {
+	return ("" != aLine) && ((' ' == aLine[0]) || ('\t' == aLine[0]))
+} // isIndented()
+
+// `newValueBlock()` returns a new block if `aLine` starts a
+// triple-quoted value.
+//
+// Parameters:
+// - `aLine` The trimmed INI line to check.
+// - `aLineNum` The number of `aLine`.
+//
+// Returns:
+// - `*tValueBlock`: The new block or `nil` if `aLine` doesn't start one.
+func newValueBlock(aLine string, aLineNum int) *tValueBlock {
+	matches := isBlockStartRE.FindStringSubmatch(aLine)
+	if nil == matches {
+		return nil
+	}
+
+	result := &tValueBlock{
+		key:     strings.TrimSpace(matches[1]),
+		lineNum: aLineNum,
+	}
+	if rest := matches[2]; strings.HasSuffix(rest, blockQuote) {
+		result.lines = []string{rest[:len(rest)-len(blockQuote)]}
+		result.done = true
+	} else if "" != rest {
+		result.lines = []string{rest}
+	}
+
+	return result
+} // newValueBlock()
+
+// `add()` appends the given raw line to the block.
+//
+// Parameters:
+// - `aLine` The untrimmed INI line to add.
+//
+// Returns:
+// - `bool`: `true` if `aLine` closed the block, `false` otherwise.
+func (vb *tValueBlock) add(aLine string) bool {
+	aLine = strings.TrimRight(aLine, "\r")
+	if line := strings.TrimRight(aLine, " \t"); strings.HasSuffix(line, blockQuote) {
+		if line = line[:len(line)-len(blockQuote)]; "" != strings.TrimSpace(line) {
+			vb.lines = append(vb.lines, line)
+		}
+		vb.done = true
+
+		return true
+	}
+	vb.lines = append(vb.lines, aLine)
+
+	return false
+} // add()
+
+// `value()` returns the block's lines as a single value.
+//
+// Returns:
+// - `string`: The lines joined by linefeeds.
+func (vb *tValueBlock) value() string {
+	return strings.Join(vb.lines, "\n")
+} // value()
+
+// `blockValue()` returns `aValue` delimited by triple quotes if it
+// spans several lines.
+//
+// Parameters:
+// - `aValue` The value to write.
+//
+// Returns:
+// - `string`: The value ready to be written to an INI file.
+func blockValue(aValue string) string {
+	if !strings.Contains(aValue, "\n") {
+		return aValue
+	}
+
+	return blockQuote + "\n" + aValue + "\n" + blockQuote
+} // blockValue()
+
+// --------------------------------------------------------------------------
+
+// `SetIndentContinuation()` sets whether indented lines following a
+// key/value pair continue its value (like Python's `ConfigParser`).
+//
+// With this option enabled
+//
+//	hosts = a.example
+//	    b.example
+//
+// results in the value "a.example\nb.example". Since the INI data
+// can't use indentation for readability anymore, this option is
+// disabled by default. Triple-quoted values are always supported
+// and values spanning several lines are written that way by `Store()`.
+//
+// Since the option is applied while reading, it has to be set before
+// the INI data is loaded (see `Load()`).
+//
+// Parameters:
+// - `aEnabled` Whether indented lines continue a value.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetIndentContinuation(aEnabled bool) *TSectionList {
+	sl.indentCont = aEnabled
+
+	return sl
+} // SetIndentContinuation()
+
+/* _EoF_ */
@@ -0,0 +1,134 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_newValueBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		wantNil  bool
+		wantDone bool
+		wantVal  string
+	}{
+		{"1", `key = value`, true, false, ""},
+		{"2", `key = """`, false, false, ""},
+		{"3", `key = """first`, false, false, "first"},
+		{"4", `key = """ quoted "value" """`, false, true, ` quoted "value" `},
+		{"5", `key = """"""`, false, true, ""},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newValueBlock(tt.args, 1)
+			if tt.wantNil != (nil == got) {
+				t.Fatalf("%q: newValueBlock() = %v, want nil: %v",
+					tt.name, got, tt.wantNil)
+			}
+			if nil == got {
+				return
+			}
+			if ("key" != got.key) || (tt.wantDone != got.done) || (tt.wantVal != got.value()) {
+				t.Errorf("%q: newValueBlock() = %+v", tt.name, got)
+			}
+		})
+	}
+} // Test_newValueBlock()
+
+func TestTSectionList_readBlocks(t *testing.T) {
+	const src = `[s]
+# the message
+text = """
+  first line
+; not a comment
+
+last line
+"""
+single = """ keep "quotes" """
+after = value
+`
+	sl := NewSectionList()
+	if _, err := sl.read(bufio.NewScanner(strings.NewReader(src))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+	for key, want := range map[string]string{
+		"text":   "first line\n; not a comment\n\nlast line",
+		"single": `keep "quotes"`,
+		"after":  "value",
+	} {
+		if got, _ := sl.AsString("s", key); want != got {
+			t.Errorf("read() %s = %q, want %q", key, got, want)
+		}
+	}
+
+	// writing and reading again must result in the same values:
+	back := NewSectionList()
+	if _, err := back.read(bufio.NewScanner(strings.NewReader(sl.String()))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+	if !sl.CompareTo(back) {
+		t.Errorf("read() round trip mismatch:\n%s", sl.String())
+	}
+	if got := sl.String(); !strings.Contains(got, "# the message\ntext = \"\"\"\nfirst line\n") {
+		t.Errorf("String() = %q", got)
+	}
+
+	strict := NewSectionList().SetStrict(true)
+	_, err := strict.read(bufio.NewScanner(strings.NewReader("key = \"\"\"\nnever closed\n")))
+	if !errors.Is(err, ErrMalformedLine) {
+		t.Errorf("read() error = %v, want %v", err, ErrMalformedLine)
+	}
+} // TestTSectionList_readBlocks()
+
+func TestTSectionList_SetIndentContinuation(t *testing.T) {
+	const src = `[s]
+hosts = a.example
+    b.example
+	c.example
+next = value \
+  continued
+[t]
+key = x
+`
+	tests := []struct {
+		name    string
+		enabled bool
+		want    string
+		wantNew string
+	}{
+		{"off", false, "a.example", "value continued"},
+		{"on", true, "a.example\nb.example\nc.example", "value continued"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList().SetIndentContinuation(tt.enabled)
+			if _, err := sl.read(bufio.NewScanner(strings.NewReader(src))); nil != err {
+				t.Fatalf("%q: read() error = %v", tt.name, err)
+			}
+			if got, _ := sl.AsString("s", "hosts"); tt.want != got {
+				t.Errorf("%q: hosts = %q, want %q", tt.name, got, tt.want)
+			}
+			if got, _ := sl.AsString("s", "next"); tt.wantNew != got {
+				t.Errorf("%q: next = %q, want %q", tt.name, got, tt.wantNew)
+			}
+			if got, _ := sl.AsString("t", "key"); "x" != got {
+				t.Errorf("%q: key = %q, want %q", tt.name, got, "x")
+			}
+		})
+	}
+} // TestTSectionList_SetIndentContinuation()
+
+/* _EoF_ */
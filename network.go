@@ -0,0 +1,270 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"net"
+	"net/url"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `errInvalidIP` is returned for values that aren't IP addresses.
+	errInvalidIP = errors.New("invalid IP address")
+
+	// `errNoScheme` is returned for URLs without a scheme.
+	errNoScheme = errors.New("missing URL scheme")
+)
+
+// `parseIP()` interprets `aValue` as an IPv4 or IPv6 address.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `net.IP`: The IP address represented by `aValue`.
+// - `error`: A possible parsing error.
+func parseIP(aValue string) (net.IP, error) {
+	if result := net.ParseIP(aValue); nil != result {
+		return result, nil
+	}
+
+	return nil, errInvalidIP
+} // parseIP()
+
+// `parseIPNet()` interprets `aValue` as an IP network in CIDR notation
+// like `192.168.0.0/16` or `2001:db8::/32`.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `*net.IPNet`: The network represented by `aValue`.
+// - `error`: A possible parsing error.
+func parseIPNet(aValue string) (*net.IPNet, error) {
+	_, result, err := net.ParseCIDR(aValue)
+	if nil != err {
+		return nil, err
+	}
+
+	return result, nil
+} // parseIPNet()
+
+// `parseURL()` interprets `aValue` as an absolute URL.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `*url.URL`: The URL represented by `aValue`.
+// - `error`: A possible parsing error.
+func parseURL(aValue string) (*url.URL, error) {
+	result, err := url.Parse(aValue)
+	if nil != err {
+		return nil, err
+	}
+	if "" == result.Scheme {
+		return nil, errNoScheme
+	}
+
+	return result, nil
+} // parseURL()
+
+// --------------------------------------------------------------------------
+
+// `AsIP()` returns the value of `aKey` as an IP address.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid IPv4
+// or IPv6 address then the second return value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `net.IP`: The value of `aKey` as an IP address.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsIP(aKey string) (net.IP, bool) {
+	if value, ok := kl.AsString(aKey); ok {
+		if result, err := parseIP(value); nil == err {
+			return result, true
+		}
+	}
+
+	return nil, false
+} // AsIP()
+
+// `AsIPNet()` returns the value of `aKey` as an IP network.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid network
+// in CIDR notation (like `10.0.0.0/8`) then the second return value
+// will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*net.IPNet`: The value of `aKey` as an IP network.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsIPNet(aKey string) (*net.IPNet, bool) {
+	if value, ok := kl.AsString(aKey); ok {
+		if result, err := parseIPNet(value); nil == err {
+			return result, true
+		}
+	}
+
+	return nil, false
+} // AsIPNet()
+
+// `AsURL()` returns the value of `aKey` as an URL.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid
+// absolute URL (i.e. one with a scheme) then the second return
+// value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*url.URL`: The value of `aKey` as an URL.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsURL(aKey string) (*url.URL, bool) {
+	if value, ok := kl.AsString(aKey); ok {
+		if result, err := parseURL(value); nil == err {
+			return result, true
+		}
+	}
+
+	return nil, false
+} // AsURL()
+
+// --------------------------------------------------------------------------
+
+// `AsIP()` returns the value of `aKey` in `aSection` as an IP address.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// a valid IPv4 or IPv6 address then the second return value will be
+// `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `net.IP`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsIP(aSection, aKey string) (net.IP, bool) {
+	result, err := sl.GetIP(aSection, aKey)
+
+	return result, (nil == err)
+} // AsIP()
+
+// `AsIPNet()` returns the value of `aKey` in `aSection` as an IP network.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// a valid network in CIDR notation then the second return value will
+// be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*net.IPNet`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsIPNet(aSection, aKey string) (*net.IPNet, bool) {
+	result, err := sl.GetIPNet(aSection, aKey)
+
+	return result, (nil == err)
+} // AsIPNet()
+
+// `AsURL()` returns the value of `aKey` in `aSection` as an URL.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// a valid absolute URL then the second return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*url.URL`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsURL(aSection, aKey string) (*url.URL, bool) {
+	result, err := sl.GetURL(aSection, aKey)
+
+	return result, (nil == err)
+} // AsURL()
+
+// `GetIP()` returns the value of `aKey` in `aSection` as an IP address.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `net.IP`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetIP(aSection, aKey string) (net.IP, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return nil, err
+	}
+	result, err := parseIP(value)
+	if nil != err {
+		return nil, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetIP()
+
+// `GetIPNet()` returns the value of `aKey` in `aSection` as an IP network.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*net.IPNet`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetIPNet(aSection, aKey string) (*net.IPNet, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return nil, err
+	}
+	result, err := parseIPNet(value)
+	if nil != err {
+		return nil, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetIPNet()
+
+// `GetURL()` returns the value of `aKey` in `aSection` as an URL.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*url.URL`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetURL(aSection, aKey string) (*url.URL, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return nil, err
+	}
+	result, err := parseURL(value)
+	if nil != err {
+		return nil, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetURL()
+
+/* _EoF_ */
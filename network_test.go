@@ -0,0 +1,119 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepNetworkSection() *TSection {
+	kl := NewSection()
+	_ = kl.AddKey("ip4", "192.168.1.10")
+	_ = kl.AddKey("ip6", "2001:db8::1")
+	_ = kl.AddKey("net4", "10.0.0.0/8")
+	_ = kl.AddKey("net6", "2001:db8::/32")
+	_ = kl.AddKey("url", "https://user@example.com:8443/path?q=1")
+	_ = kl.AddKey("relative", "/just/a/path")
+	_ = kl.AddKey("bad", "300.1.1.1")
+
+	return kl
+} // prepNetworkSection()
+
+func TestTSection_AsIP(t *testing.T) {
+	kl := prepNetworkSection()
+	tests := []struct {
+		args  string
+		want  string
+		want1 bool
+	}{
+		{"ip4", "192.168.1.10", true},
+		{"ip6", "2001:db8::1", true},
+		{"net4", "<nil>", false},
+		{"bad", "<nil>", false},
+		{"n.a.", "<nil>", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsIP(tt.args)
+			if (tt.want != got.String()) || (got1 != tt.want1) {
+				t.Errorf("TSection.AsIP(%q) = %v, %v, want %v, %v",
+					tt.args, got, got1, tt.want, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsIP()
+
+func TestTSection_AsIPNet(t *testing.T) {
+	kl := prepNetworkSection()
+	tests := []struct {
+		args  string
+		want  string
+		want1 bool
+	}{
+		{"net4", "10.0.0.0/8", true},
+		{"net6", "2001:db8::/32", true},
+		{"ip4", "<nil>", false},
+		{"n.a.", "<nil>", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsIPNet(tt.args)
+			if (tt.want != got.String()) || (got1 != tt.want1) {
+				t.Errorf("TSection.AsIPNet(%q) = %v, %v, want %v, %v",
+					tt.args, got, got1, tt.want, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsIPNet()
+
+func TestTSection_AsURL(t *testing.T) {
+	kl := prepNetworkSection()
+
+	got, ok := kl.AsURL("url")
+	if !ok || ("example.com" != got.Hostname()) || ("8443" != got.Port()) {
+		t.Errorf("TSection.AsURL() = %v, %v", got, ok)
+	}
+	for _, key := range []string{"relative", "n.a."} {
+		if got, ok := kl.AsURL(key); ok {
+			t.Errorf("TSection.AsURL(%q) = %v, want failure", key, got)
+		}
+	}
+} // TestTSection_AsURL()
+
+func TestTSectionList_AsIP(t *testing.T) {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("net", "ip", "127.0.0.1")
+	_ = sl.AddSectionKey("net", "cidr", "127.0.0.0/8")
+	_ = sl.AddSectionKey("net", "url", "http://localhost/")
+	_ = sl.AddSectionKey("net", "bad", "localhost")
+
+	if got, ok := sl.AsIP("net", "ip"); !ok || !got.IsLoopback() {
+		t.Errorf("TSectionList.AsIP() = %v, %v", got, ok)
+	}
+	if got, ok := sl.AsIPNet("net", "cidr"); !ok || ("127.0.0.0/8" != got.String()) {
+		t.Errorf("TSectionList.AsIPNet() = %v, %v", got, ok)
+	}
+	if got, ok := sl.AsURL("net", "url"); !ok || ("localhost" != got.Host) {
+		t.Errorf("TSectionList.AsURL() = %v, %v", got, ok)
+	}
+	for _, get := range []func(string, string) error{
+		func(s, k string) error { _, err := sl.GetIP(s, k); return err },
+		func(s, k string) error { _, err := sl.GetIPNet(s, k); return err },
+		func(s, k string) error { _, err := sl.GetURL(s, k); return err },
+	} {
+		if err := get("net", "bad"); !errors.Is(err, ErrParseValue) {
+			t.Errorf("GetXxx() error = %v, want %v", err, ErrParseValue)
+		}
+	}
+} // TestTSectionList_AsIP()
+
+/* _EoF_ */
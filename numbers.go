@@ -0,0 +1,119 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "strings"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `isThousands()` reports whether `aDigits` is a group of three digits
+// following a thousands separator.
+//
+// Parameters:
+// - `aDigits` The text following the separator.
+//
+// Returns:
+// - `bool`: `true` if `aDigits` are three digits, `false` otherwise.
+func isThousands(aDigits string) bool {
+	if 3 != len(aDigits) {
+		return false
+	}
+	for _, digit := range aDigits {
+		if ('0' > digit) || ('9' < digit) {
+			return false
+		}
+	}
+
+	return true
+} // isThousands()
+
+// `lenientFloat()` returns `aValue` with its thousands separators
+// removed and its decimal separator replaced by a dot.
+//
+// If both a comma and a dot are used the one occurring last is the
+// decimal separator. A single comma is a decimal separator unless it's
+// followed by exactly three digits (e.g. `1,000`); several commas are
+// thousands separators.
+//
+// Parameters:
+// - `aValue` The number to normalise.
+//
+// Returns:
+// - `string`: The number in Go syntax.
+func lenientFloat(aValue string) string {
+	aValue = strings.ReplaceAll(aValue, "_", "")
+	comma, dot := strings.LastIndexByte(aValue, ','), strings.LastIndexByte(aValue, '.')
+
+	switch {
+	case 0 > comma:
+		return aValue
+
+	case 0 <= dot:
+		if comma < dot { // 1,234.5
+			return strings.ReplaceAll(aValue, ",", "")
+		}
+		// 1.234,5
+		return strings.Replace(strings.ReplaceAll(aValue, ".", ""), ",", ".", 1)
+
+	case (1 == strings.Count(aValue, ",")) && !isThousands(aValue[comma+1:]):
+		return strings.Replace(aValue, ",", ".", 1) // 3,14
+	}
+
+	return strings.ReplaceAll(aValue, ",", "") // 1,000 or 1,000,000
+} // lenientFloat()
+
+// `lenientInt()` returns `aValue` and the base to parse it with.
+//
+// The prefixes `0x` (hexadecimal), `0o` (octal), and `0b` (binary)
+// are recognised while a leading zero alone doesn't make the number
+// octal. Underscores between the digits are ignored.
+//
+// Parameters:
+// - `aValue` The number to inspect.
+//
+// Returns:
+// - `string`: The number to parse.
+// - `int`: The number's base (`0` for a prefixed number).
+func lenientInt(aValue string) (string, int) {
+	digits := strings.TrimLeft(aValue, "+-")
+	if (2 < len(digits)) && ('0' == digits[0]) {
+		switch digits[1] {
+		case 'b', 'B', 'o', 'O', 'x', 'X':
+			return aValue, 0
+		}
+	}
+
+	return strings.ReplaceAll(aValue, "_", ""), 10
+} // lenientInt()
+
+// `SetLenientNumbers()` determines whether the list's numeric
+// accessors accept the number formats found in hand-written INI files.
+//
+// With this option enabled
+//
+//   - `AsFloat32()`, `AsFloat64()` (and the respective `GetXxx()`
+//     methods) accept a comma as decimal separator (`3,14`) and
+//     thousands separators (`1,000.5`, `1.000,5`, `1_000`),
+//   - `AsInt()`, `AsUInt()` etc. accept hexadecimal (`0x1F`), octal
+//     (`0o755`), and binary (`0b1010`) numbers as well as underscores
+//     between the digits (`1_000`).
+//
+// A single comma followed by exactly three digits (e.g. `1,000`) is
+// considered a thousands separator.
+//
+// Parameters:
+// - `aLenient` Whether to accept the additional number formats.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetLenientNumbers(aLenient bool) *TSectionList {
+	sl.lenientNum = aLenient
+
+	return sl
+} // SetLenientNumbers()
+
+/* _EoF_ */
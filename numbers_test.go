@@ -0,0 +1,106 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "testing"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_lenientFloat(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"1", "3.14", "3.14"},
+		{"2", "3,14", "3.14"},
+		{"3", "1,000", "1000"},
+		{"4", "1,000,000.5", "1000000.5"},
+		{"5", "1.000.000,5", "1000000.5"},
+		{"6", "1_000.25", "1000.25"},
+		{"7", "-0,5", "-0.5"},
+		{"8", "1,5e3", "1.5e3"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lenientFloat(tt.args); got != tt.want {
+				t.Errorf("%q: lenientFloat() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_lenientFloat()
+
+func TestTSectionList_SetLenientNumbers(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("", "pi", "3,14")
+	sl.AddSectionKey("", "big", "1,000.5")
+	sl.AddSectionKey("", "hex", "0x1F")
+	sl.AddSectionKey("", "oct", "0o755")
+	sl.AddSectionKey("", "bin", "0b1010")
+	sl.AddSectionKey("", "under", "1_000")
+	sl.AddSectionKey("", "zero", "010")
+	sl.AddSectionKey("", "neg", "-0x10")
+
+	type tWant struct {
+		value int64
+		ok    bool
+	}
+	ints := []struct {
+		name    string
+		lenient bool
+		key     string
+		want    tWant
+	}{
+		{"1", false, "hex", tWant{0, false}},
+		{"2", true, "hex", tWant{31, true}},
+		{"3", true, "oct", tWant{493, true}},
+		{"4", true, "bin", tWant{10, true}},
+		{"5", true, "under", tWant{1000, true}},
+		{"6", true, "zero", tWant{10, true}},
+		{"7", true, "neg", tWant{-16, true}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range ints {
+		t.Run("int "+tt.name, func(t *testing.T) {
+			value, ok := sl.SetLenientNumbers(tt.lenient).AsInt64("", tt.key)
+			if got := (tWant{value, ok}); got != tt.want {
+				t.Errorf("%q: TSectionList.AsInt64(%q) = %v, want %v",
+					tt.name, tt.key, got, tt.want)
+			}
+		})
+	}
+	if got, ok := sl.SetLenientNumbers(true).AsUInt16("", "oct"); !ok || (493 != got) {
+		t.Errorf("TSectionList.AsUInt16() = %v, %v, want 493, true", got, ok)
+	}
+
+	floats := []struct {
+		name    string
+		lenient bool
+		key     string
+		want    float64
+		ok      bool
+	}{
+		{"1", false, "pi", 0, false},
+		{"2", true, "pi", 3.14, true},
+		{"3", true, "big", 1000.5, true},
+		{"4", true, "under", 1000, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range floats {
+		t.Run("float "+tt.name, func(t *testing.T) {
+			got, ok := sl.SetLenientNumbers(tt.lenient).AsFloat64("", tt.key)
+			if (got != tt.want) || (ok != tt.ok) {
+				t.Errorf("%q: TSectionList.AsFloat64(%q) = %v, %v, want %v, %v",
+					tt.name, tt.key, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+} // TestTSectionList_SetLenientNumbers()
+
+/* _EoF_ */
@@ -0,0 +1,208 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tOrigin` is the place a key/value pair was read from.
+	tOrigin struct {
+		file string // name of the INI file
+		line int    // number of the key's (first) line
+	}
+
+	// `tOrigins` maps section/key pairs to the place they were read from.
+	tOrigins map[string]tOrigin
+)
+
+// `originID()` returns the map index for `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `string`: The index to use with `tOrigins`.
+func originID(aSection, aKey string) string {
+	return aSection + "\x00" + aKey
+} // originID()
+
+// `dropOrigin()` forgets where `aKey` in `aSection` was read from.
+//
+// An empty `aKey` forgets the origins of all keys in `aSection`.
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+func (sl *TSectionList) dropOrigin(aSection, aKey string) {
+	if 0 == len(sl.origins) {
+		return
+	}
+	if "" != aKey {
+		delete(sl.origins, originID(aSection, aKey))
+		return
+	}
+
+	prefix := originID(aSection, "")
+	for id := range sl.origins {
+		if strings.HasPrefix(id, prefix) {
+			delete(sl.origins, id)
+		}
+	}
+} // dropOrigin()
+
+// `renameOrigins()` moves the origins of all keys in `aOldSection`
+// to `aNewSection`.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aOldSection` The previous name of the INI section.
+// - `aNewSection` The new name of the INI section.
+func (sl *TSectionList) renameOrigins(aOldSection, aNewSection string) {
+	prefix := originID(aOldSection, "")
+	for id, origin := range sl.origins {
+		if key, found := strings.CutPrefix(id, prefix); found {
+			delete(sl.origins, id)
+			sl.origins[originID(aNewSection, key)] = origin
+		}
+	}
+} // renameOrigins()
+
+// `setOrigin()` records that `aKey` in `aSection` was read from line
+// `aLineNum` of `aFile`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aFile` The name of the INI file read.
+// - `aLineNum` The number of the key's line in `aFile`.
+func (sl *TSectionList) setOrigin(aSection, aKey, aFile string, aLineNum int) {
+	defer sl.lock()()
+
+	if nil == sl.origins {
+		sl.origins = make(tOrigins)
+	}
+	sl.origins[originID(aSection, aKey)] = tOrigin{aFile, aLineNum}
+} // setOrigin()
+
+// `Origin()` returns the INI file and line number `aKey` in `aSection`
+// was read from.
+//
+// Keys added or changed programmatically have no origin. When merging
+// lists (e.g. by `ReadIniData()`) the origin of the merged keys is kept
+// so that the returned file tells which INI file supplied the value.
+// Data not read from a file (e.g. by `LoadWithDefaults()`) reports an
+// empty filename.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The name of the INI file `aKey` was read from.
+// - `int`: The number of the line (counting from `1`) holding `aKey`.
+// - `bool`: `true` if the origin of `aKey` is known, `false` otherwise.
+func (sl *TSectionList) Origin(aSection, aKey string) (string, int, bool) {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+
+	unlock := sl.rlock()
+	origin, ok := sl.origins[originID(aSection, strings.TrimSpace(aKey))]
+	unlock()
+
+	return origin.file, origin.line, ok
+} // Origin()
+
+// `originString()` returns where `aKey` in `aSection` was read from.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `string`: The key's INI file and line number.
+// - `bool`: `true` if the origin of `aKey` is known, `false` otherwise.
+func (sl *TSectionList) originString(aSection, aKey string) (string, bool) {
+	unlock := sl.rlock()
+	origin, ok := sl.origins[originID(aSection, aKey)]
+	unlock()
+	if !ok {
+		return "", false
+	}
+	if "" == origin.file {
+		return fmt.Sprintf("(built-in):%d", origin.line), true
+	}
+
+	return fmt.Sprintf("%s:%d", origin.file, origin.line), true
+} // originString()
+
+// `storeEffective()` writes all INI data to `aFilename` with a comment
+// preceding each key telling where its value came from.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+// - `aSource` The function returning the source of a key's value.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) storeEffective(aFilename string, aSource func(aSection, aKey string) (string, bool)) (int, error) {
+	if aFilename = strings.TrimSpace(aFilename); "" == aFilename {
+		return 0, fs.ErrNotExist
+	}
+
+	effective := sl.copyList()
+	for _, name := range effective.secOrder {
+		kl := effective.sections[name]
+		for _, kv := range kl.data {
+			source, ok := aSource(name, kv.Key)
+			if !ok {
+				source = "(set programmatically)"
+			}
+			lines := append(trimComments(kl.comments[kv.Key]), "; source: "+source)
+			kl.setComment(kv.Key, lines)
+		}
+	}
+	data, err := effective.storeData()
+	if nil != err {
+		return 0, err
+	}
+
+	return sl.writeData(aFilename, data, sl.fileMode())
+} // storeEffective()
+
+// `StoreEffective()` writes all INI data to `aFilename` with a comment
+// preceding each key telling where its value came from, e.g.
+//
+//	; source: /etc/myApp.ini:12
+//	port = 8080
+//
+// This is meant for support bundles and debugging the configuration
+// read by e.g. `ReadIniData()`. Keys without a known origin (see
+// `Origin()`) are marked as set programmatically. Neither the list's
+// filename nor its modification tracking are changed.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreEffective(aFilename string) (int, error) {
+	return sl.storeEffective(aFilename, sl.originString)
+} // StoreEffective()
+
+/* _EoF_ */
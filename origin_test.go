@@ -0,0 +1,103 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Origin(t *testing.T) {
+	dir := t.TempDir()
+	fName1 := filepath.Join(dir, "first.ini")
+	fName2 := filepath.Join(dir, "second.ini")
+	_ = os.WriteFile(fName1, []byte("; comment\nkey1 = one\n\n[sect]\nkey2 = two \\\n  continued\nkey3 = three\n"), 0600)
+	_ = os.WriteFile(fName2, []byte("[sect]\n\nkey3 = \"\"\"\nmulti\nline\"\"\"\n"), 0600)
+
+	sl, err := NewIni(fName1)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	ini2, err := NewIni(fName2)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	sl.Merge(ini2)
+	sl.AddSectionKey("sect", "key4", "added")
+
+	tests := []struct {
+		name     string
+		section  string
+		key      string
+		wantFile string
+		wantLine int
+		wantOK   bool
+	}{
+		{"1", "", "key1", fName1, 2, true},
+		{"2", "sect", "key2", fName1, 5, true},
+		{"3", "sect", "key3", fName2, 3, true},
+		{"4", "sect", "key4", "", 0, false},
+		{"5", "sect", "n.a.", "", 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, line, ok := sl.Origin(tt.section, tt.key)
+			if (file != tt.wantFile) || (line != tt.wantLine) || (ok != tt.wantOK) {
+				t.Errorf("%q: TSectionList.Origin() = %q, %d, %v, want %q, %d, %v",
+					tt.name, file, line, ok, tt.wantFile, tt.wantLine, tt.wantOK)
+			}
+		})
+	}
+
+	sl.UpdateSectKeyStr("", "key1", "changed")
+	if _, _, ok := sl.Origin("", "key1"); ok {
+		t.Error("TSectionList.Origin() kept origin of updated key")
+	}
+	sl.RemoveSection("sect")
+	if _, _, ok := sl.Origin("sect", "key2"); ok {
+		t.Error("TSectionList.Origin() kept origin of removed section")
+	}
+} // TestTSectionList_Origin()
+
+func TestTSectionList_StoreEffective(t *testing.T) {
+	dir := t.TempDir()
+	fName := filepath.Join(dir, "app.ini")
+	_ = os.WriteFile(fName, []byte("[sect]\n; the key\nkey1 = one\n"), 0600)
+
+	sl, err := NewIni(fName)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	sl.AddSectionKey("sect", "key2", "two")
+
+	outName := filepath.Join(dir, "effective.ini")
+	if _, err = sl.StoreEffective(outName); nil != err {
+		t.Fatalf("TSectionList.StoreEffective() error = %v", err)
+	}
+	data, _ := os.ReadFile(outName)
+	want := "[sect]\n; the key\n; source: " + fName + ":3\nkey1 = one\n" +
+		"; source: (set programmatically)\nkey2 = two\n"
+	if got := string(data); !strings.Contains(got, want) {
+		t.Errorf("TSectionList.StoreEffective() wrote\n%s\nwant\n%s", got, want)
+	}
+	if c, _ := sl.GetSection("sect").AsString("key1"); "one" != c {
+		t.Error("TSectionList.StoreEffective() changed the list")
+	}
+	if strings.Contains(sl.String(), "source:") {
+		t.Error("TSectionList.StoreEffective() changed the list's comments")
+	}
+	if fName != sl.Filename() {
+		t.Errorf("TSectionList.Filename() = %q, want %q", sl.Filename(), fName)
+	}
+} // TestTSectionList_StoreEffective()
+
+/* _EoF_ */
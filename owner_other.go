@@ -0,0 +1,29 @@
+//go:build !unix
+
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+package ini
+
+import "io/fs"
+
+// `fileOwner()` returns the user and group IDs of the given file.
+//
+// File ownership isn't supported on this platform.
+//
+// Parameters:
+// - `aInfo` The file information to use.
+//
+// Returns:
+// - `int`: Always `-1`.
+// - `int`: Always `-1`.
+// - `bool`: Always `false`.
+func fileOwner(aInfo fs.FileInfo) (int, int, bool) {
+	return -1, -1, false
+} // fileOwner()
+
+/* _EoF_ */
@@ -0,0 +1,34 @@
+//go:build unix
+
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+package ini
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// `fileOwner()` returns the user and group IDs of the given file.
+//
+// Parameters:
+// - `aInfo` The file information to use.
+//
+// Returns:
+// - `int`: The file's user ID.
+// - `int`: The file's group ID.
+// - `bool`: `true` if the IDs are available, `false` otherwise.
+func fileOwner(aInfo fs.FileInfo) (int, int, bool) {
+	if st, ok := aInfo.Sys().(*syscall.Stat_t); ok {
+		return int(st.Uid), int(st.Gid), true
+	}
+
+	return -1, -1, false
+} // fileOwner()
+
+/* _EoF_ */
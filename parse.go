@@ -0,0 +1,150 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TParseHandler` receives the events generated by `Parse()` while
+	// reading INI data.
+	//
+	// If a method returns an error, parsing stops and `Parse()` returns
+	// that error.
+	TParseHandler interface {
+		// `Comment()` is called for each (non-empty) comment line.
+		Comment(aLineNum int, aText string) error
+
+		// `Error()` is called for each malformed line; returning `nil`
+		// continues parsing.
+		Error(aErr *TParseError) error
+
+		// `KeyValue()` is called for each key/value pair.
+		KeyValue(aLineNum int, aSection, aKey, aValue string) error
+
+		// `SectionStart()` is called for each section header.
+		SectionStart(aLineNum int, aSection string) error
+	}
+
+	// `TParseFuncs` implements `TParseHandler` by calling the
+	// respective function (if not `nil`).
+	TParseFuncs struct {
+		OnComment      func(aLineNum int, aText string) error
+		OnError        func(aErr *TParseError) error
+		OnKeyValue     func(aLineNum int, aSection, aKey, aValue string) error
+		OnSectionStart func(aLineNum int, aSection string) error
+	}
+)
+
+// `Comment()` implements the `TParseHandler` interface.
+func (pf TParseFuncs) Comment(aLineNum int, aText string) error {
+	if nil == pf.OnComment {
+		return nil
+	}
+
+	return pf.OnComment(aLineNum, aText)
+} // Comment()
+
+// `Error()` implements the `TParseHandler` interface.
+//
+// Without an `OnError` function the error is returned, i.e.
+// parsing stops.
+func (pf TParseFuncs) Error(aErr *TParseError) error {
+	if nil == pf.OnError {
+		return aErr
+	}
+
+	return pf.OnError(aErr)
+} // Error()
+
+// `KeyValue()` implements the `TParseHandler` interface.
+func (pf TParseFuncs) KeyValue(aLineNum int, aSection, aKey, aValue string) error {
+	if nil == pf.OnKeyValue {
+		return nil
+	}
+
+	return pf.OnKeyValue(aLineNum, aSection, aKey, aValue)
+} // KeyValue()
+
+// `SectionStart()` implements the `TParseHandler` interface.
+func (pf TParseFuncs) SectionStart(aLineNum int, aSection string) error {
+	if nil == pf.OnSectionStart {
+		return nil
+	}
+
+	return pf.OnSectionStart(aLineNum, aSection)
+} // SectionStart()
+
+// --------------------------------------------------------------------------
+
+// `Parse()` reads INI data from `aReader` passing the sections, key/value
+// pairs, comments, and malformed lines to `aHandler` as they are read.
+//
+// Unlike `NewIni()` no `TSectionList` is built, so this function can
+// process very large INI-like files with constant memory. Key/value
+// pairs preceding the first section header belong to `DefSection`.
+// Duplicate keys are reported as they occur.
+//
+// Parameters:
+// - `aReader` The source of the INI data.
+// - `aHandler` The receiver of the parse events.
+//
+// Returns:
+// - `error`: The first error returned by `aHandler` or a read error.
+func Parse(aReader io.Reader, aHandler TParseHandler) error {
+	section := DefSection
+	malformed := func(aLineNum int, aLine string) error {
+		return aHandler.Error(&TParseError{
+			Line: aLineNum,
+			Text: aLine,
+			Err:  ErrMalformedLine,
+		})
+	}
+
+	scanner := bufio.NewScanner(skipBOM(aReader))
+	scanner.Buffer(nil, DefMaxLineLength)
+
+	_, err := scanLines(scanner, false, tLineHandler{
+		comment: func(aLine string, aLineNum int) error {
+			if "" == aLine {
+				return nil
+			}
+			return aHandler.Comment(aLineNum, aLine)
+		},
+
+		line: func(aLine string, aLineNum int) error {
+			if matches := isSectionRE.FindStringSubmatch(aLine); nil != matches {
+				if section = strings.TrimSpace(matches[1]); "" == section {
+					section = DefSection
+				}
+				return aHandler.SectionStart(aLineNum, section)
+			}
+			if matches := isKeyValRE.FindStringSubmatch(aLine); nil != matches {
+				return aHandler.KeyValue(aLineNum, section,
+					strings.TrimSpace(matches[1]), removeQuotes(matches[2]))
+			}
+
+			return malformed(aLineNum, aLine)
+		},
+
+		block: func(aBlock *tValueBlock) error {
+			if !aBlock.done {
+				return malformed(aBlock.lineNum, aBlock.key+" = "+blockQuote)
+			}
+			return aHandler.KeyValue(aBlock.lineNum, section, aBlock.key, aBlock.value())
+		},
+	})
+
+	return err
+} // Parse()
+
+/* _EoF_ */
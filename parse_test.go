@@ -0,0 +1,98 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `tEventRecorder` records the events of `Parse()`.
+type tEventRecorder struct {
+	events []string
+}
+
+func (er *tEventRecorder) Comment(aLineNum int, aText string) error {
+	er.events = append(er.events, fmt.Sprintf("%d comment %s", aLineNum, aText))
+	return nil
+} // Comment()
+
+func (er *tEventRecorder) Error(aErr *TParseError) error {
+	er.events = append(er.events, fmt.Sprintf("%d error %s", aErr.Line, aErr.Text))
+	return nil
+} // Error()
+
+func (er *tEventRecorder) KeyValue(aLineNum int, aSection, aKey, aValue string) error {
+	er.events = append(er.events, fmt.Sprintf("%d [%s] %s=%s", aLineNum, aSection, aKey, aValue))
+	return nil
+} // KeyValue()
+
+func (er *tEventRecorder) SectionStart(aLineNum int, aSection string) error {
+	er.events = append(er.events, fmt.Sprintf("%d section %s", aLineNum, aSection))
+	return nil
+} // SectionStart()
+
+func TestParse(t *testing.T) {
+	data := "top = 1\n; a comment\n\n[one]\nkey = \"quoted\"\nlong = a \\\n  b\nbroken line\ntext = \"\"\"\nx\ny\"\"\"\n[]\nlast = 2\n"
+	want := []string{
+		"1 [Default] top=1",
+		"2 comment ; a comment",
+		"4 section one",
+		"5 [one] key=quoted",
+		"6 [one] long=a b",
+		"8 error broken line",
+		"9 [one] text=x\ny",
+		"12 section Default",
+		"13 [Default] last=2",
+	}
+
+	var er tEventRecorder
+	if err := Parse(strings.NewReader(data), &er); nil != err {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !slices.Equal(er.events, want) {
+		t.Errorf("Parse() events =\n%q\nwant\n%q", er.events, want)
+	}
+} // TestParse()
+
+func TestTParseFuncs(t *testing.T) {
+	data := "a = 1\nbroken\nb = 2\n"
+	stop := errors.New("stop")
+
+	var keys []string
+	err := Parse(strings.NewReader(data), TParseFuncs{
+		OnKeyValue: func(aLineNum int, aSection, aKey, aValue string) error {
+			keys = append(keys, aKey)
+			return nil
+		},
+	})
+	if !errors.Is(err, ErrMalformedLine) || !slices.Equal(keys, []string{"a"}) {
+		t.Errorf("Parse() = %v, %v, want %v, [a]", err, keys, ErrMalformedLine)
+	}
+
+	keys = nil
+	err = Parse(strings.NewReader(data), TParseFuncs{
+		OnError: func(aErr *TParseError) error { return nil },
+		OnKeyValue: func(aLineNum int, aSection, aKey, aValue string) error {
+			keys = append(keys, aKey)
+			if "b" == aKey {
+				return stop
+			}
+			return nil
+		},
+	})
+	if !errors.Is(err, stop) || !slices.Equal(keys, []string{"a", "b"}) {
+		t.Errorf("Parse() = %v, %v, want %v, [a b]", err, keys, stop)
+	}
+} // TestTParseFuncs()
+
+/* _EoF_ */
@@ -0,0 +1,135 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TParseError` describes a problem found while reading INI data.
+	//
+	// It's returned as an error in strict mode and collected as a
+	// warning otherwise (see `SetStrict()` and `ParseWarnings()`).
+	TParseError struct {
+		Filename string // name of the INI file (if any)
+		Line     int    // number of the offending line
+		Text     string // the offending (possibly concatenated) line
+		Err      error  // the kind of problem, e.g. `ErrMalformedLine`
+	}
+)
+
+var (
+	// `ErrMalformedLine` marks a line which is neither a section
+	// header, a key/value pair, nor a comment.
+	ErrMalformedLine = errors.New("ini: malformed line")
+
+	// `ErrInternal` is returned by `ParseBytes()` if reading the data
+	// failed due to a bug in this package.
+	ErrInternal = errors.New("ini: internal error")
+)
+
+// `Error()` implements the `error` interface.
+//
+// Returns:
+// - `string`: The error message.
+func (pe *TParseError) Error() string {
+	name := pe.Filename
+	if "" == name {
+		name = "<input>"
+	}
+
+	return fmt.Sprintf("%s:%d: %v: %q", name, pe.Line, pe.Err, pe.Text)
+} // Error()
+
+// `Unwrap()` returns the underlying error.
+//
+// Returns:
+// - `error`: The kind of problem.
+func (pe *TParseError) Unwrap() error {
+	return pe.Err
+} // Unwrap()
+
+// --------------------------------------------------------------------------
+
+// `malformedLine()` handles a line which couldn't be parsed.
+//
+// In strict mode an error is returned, otherwise the problem is
+// recorded as a warning.
+//
+// Parameters:
+// - `aLineNum` The number of the offending line.
+// - `aLine` The offending line.
+//
+// Returns:
+// - `error`: The parse error in strict mode, `nil` otherwise.
+func (sl *TSectionList) malformedLine(aLineNum int, aLine string) error {
+	err := sl.newParseError(aLineNum, aLine, ErrMalformedLine)
+	if sl.strict {
+		return err
+	}
+	sl.warnings = append(sl.warnings, *err)
+
+	return nil
+} // malformedLine()
+
+// `newParseError()` returns a new parse error for the given line.
+//
+// Parameters:
+// - `aLineNum` The number of the offending line.
+// - `aLine` The offending line.
+// - `aErr` The kind of problem.
+//
+// Returns:
+// - `*TParseError`: The new parse error.
+func (sl *TSectionList) newParseError(aLineNum int, aLine string, aErr error) *TParseError {
+	return &TParseError{
+		Filename: sl.fName,
+		Line:     aLineNum,
+		Text:     aLine,
+		Err:      aErr,
+	}
+} // newParseError()
+
+// `ParseWarnings()` returns the problems found while reading the INI
+// data in lenient (i.e. non-strict) mode.
+//
+// Returns:
+// - `[]TParseError`: The list of problems found.
+func (sl *TSectionList) ParseWarnings() []TParseError {
+	result := make([]TParseError, len(sl.warnings))
+	copy(result, sl.warnings)
+
+	return result
+} // ParseWarnings()
+
+// `SetStrict()` sets whether reading the INI data should fail on
+// malformed lines.
+//
+// By default such lines are ignored and recorded as warnings which
+// can be retrieved by `ParseWarnings()`. In strict mode reading stops
+// with a `*TParseError` mentioning the file name, line number, and
+// offending text.
+//
+// Since the mode is applied while reading, it has to be set before
+// the INI data is loaded (see `Load()`).
+//
+// Parameters:
+// - `aStrict` Whether to fail on malformed lines.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetStrict(aStrict bool) *TSectionList {
+	sl.strict = aStrict
+
+	return sl
+} // SetStrict()
+
+/* _EoF_ */
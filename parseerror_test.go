@@ -0,0 +1,80 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_ParseWarnings(t *testing.T) {
+	sl, err := NewIni("./testIn.ini")
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	got := sl.ParseWarnings()
+	if 1 != len(got) {
+		t.Fatalf("TSectionList.ParseWarnings() = %d warnings, want 1", len(got))
+	}
+	if w := got[0]; (124 != w.Line) || !errors.Is(&w, ErrMalformedLine) ||
+		!strings.HasPrefix(w.Text, "O'Dear!") {
+		t.Errorf("TSectionList.ParseWarnings() = %v", &w)
+	}
+	if sl.Clear(); 0 != len(sl.ParseWarnings()) {
+		t.Error("TSectionList.Clear() didn't reset the warnings")
+	}
+} // TestTSectionList_ParseWarnings()
+
+func TestTSectionList_SetStrict(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantLine int
+		wantErr  error
+	}{
+		{"ok", "[s]\nkey = val\n", 0, nil},
+		{"broken", "[s]\nkey = val\nbroken line\n", 3, ErrMalformedLine},
+		{"concat", "[s]\n\nbroken \\\n line\n", 3, ErrMalformedLine},
+		{"noKey", "[s]\n = val\n", 2, ErrMalformedLine},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList().SetFilename("test.ini").SetStrict(true)
+			_, err := sl.read(bufio.NewScanner(strings.NewReader(tt.src)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%q: read() error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if nil == err {
+				return
+			}
+			var pe *TParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("%q: read() error %T isn't a *TParseError", tt.name, err)
+			}
+			if (tt.wantLine != pe.Line) || ("test.ini" != pe.Filename) {
+				t.Errorf("%q: read() error = %v, want line %d",
+					tt.name, err, tt.wantLine)
+			}
+		})
+	}
+
+	// duplicate key errors mention the line as well:
+	sl := NewSectionList().SetDuplicatePolicy(ErrorOnDuplicate)
+	_, err := sl.read(bufio.NewScanner(strings.NewReader("a = 1\na = 2\n")))
+	var pe *TParseError
+	if !errors.As(err, &pe) || (2 != pe.Line) || !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("read() error = %v, want duplicate key in line 2", err)
+	}
+} // TestTSectionList_SetStrict()
+
+/* _EoF_ */
@@ -0,0 +1,124 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"os"
+	"path/filepath"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `ConfigSearchPaths()` returns the INI files searched by `ReadIniData()`
+// for the application `aName` in the order they are read, i.e. later
+// files take precedence over earlier ones.
+//
+// The list consists of
+//
+//	(1) the local `./aName.ini`,
+//	(2) the platform's system-wide configuration files,
+//	(3) the user-local `~/.aName.ini`,
+//	(4) the user's configuration directory `aName.ini`.
+//
+// On Windows the system-wide file is `%PROGRAMDATA%\aName\aName.ini`
+// while the user's configuration directory is `%APPDATA%`.
+// On other platforms the system-wide files are `/etc/aName.ini`
+// followed by `aName.ini` in the directories listed in `$XDG_CONFIG_DIRS`
+// (default `/etc/xdg`) with the most important directory last; the
+// user's configuration directory is `$XDG_CONFIG_HOME` (default
+// `~/.config`); see `SystemConfigFiles()` and `UserConfigFile()`.
+//
+// The files are not checked for existence.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+//
+// Returns:
+// - `[]string`: The list of INI files to read.
+func ConfigSearchPaths(aName string) []string {
+	var result []string
+	seen := make(map[string]struct{})
+	add := func(aFilename string) {
+		if fName, err := filepath.Abs(aFilename); nil == err {
+			if _, dup := seen[fName]; !dup {
+				seen[fName] = struct{}{}
+				result = append(result, fName)
+			}
+		}
+	}
+	fName := aName + `.ini`
+
+	// (1) ./
+	add(fName)
+
+	// (2) system-wide
+	for _, sysName := range SystemConfigFiles(aName) {
+		add(sysName)
+	}
+
+	// (3) ~user/
+	if dir, err := os.UserHomeDir(); (nil == err) && ("" != dir) {
+		add(filepath.Join(dir, `.`+fName))
+	}
+
+	// (4) ~/.config/
+	if userName, err := UserConfigFile(aName); nil == err {
+		add(userName)
+	}
+
+	return result
+} // ConfigSearchPaths()
+
+// `SystemConfigFiles()` returns the system-wide INI files of the
+// application `aName` in the order they are read, i.e. the most
+// important file last.
+//
+// On Windows this is `%PROGRAMDATA%\aName\aName.ini`; on other platforms
+// it's `/etc/aName.ini` followed by `aName.ini` in the directories
+// listed in `$XDG_CONFIG_DIRS` (default `/etc/xdg`).
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+//
+// Returns:
+// - `[]string`: The list of system-wide INI files.
+func SystemConfigFiles(aName string) []string {
+	dirs := systemConfigDirs(aName)
+	result := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		result = append(result, filepath.Join(dir, aName+`.ini`))
+	}
+
+	return result
+} // SystemConfigFiles()
+
+// `UserConfigFile()` returns the user's INI file of the application
+// `aName` within the user's configuration directory.
+//
+// On Windows this is `%APPDATA%\aName.ini`; on other platforms it's
+// `aName.ini` in `$XDG_CONFIG_HOME` if that's an absolute path, or in
+// `~/.config` otherwise. The file is not checked for existence.
+//
+// Parameters:
+// - `aName` The application's name used as the INI file name
+// (without `.ini` extension).
+//
+// Returns:
+// - `string`: The name of the user's INI file.
+// - `error`: An error if the user's directory can't be determined.
+func UserConfigFile(aName string) (string, error) {
+	dir, err := userConfigDir()
+	if nil != err {
+		return "", err
+	}
+
+	return filepath.Join(dir, aName+`.ini`), nil
+} // UserConfigFile()
+
+/* _EoF_ */
@@ -0,0 +1,66 @@
+//go:build !windows
+
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+package ini
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// `systemConfigDirs()` returns the system-wide configuration
+// directories for the application `aName`.
+//
+// Besides `/etc` the directories listed in `$XDG_CONFIG_DIRS`
+// (default `/etc/xdg`) are used in reverse order since the first
+// directory listed is the most important one. Relative entries are
+// ignored as required by the XDG Base Directory specification.
+//
+// Parameters:
+// - `aName` The application's name (not used on this platform).
+//
+// Returns:
+// - `[]string`: The list of directories, least important first.
+func systemConfigDirs(aName string) []string {
+	var xdgDirs []string
+	for _, dir := range filepath.SplitList(os.Getenv(`XDG_CONFIG_DIRS`)) {
+		if filepath.IsAbs(dir) { // ignore invalid entries
+			xdgDirs = append(xdgDirs, dir)
+		}
+	}
+	if 0 == len(xdgDirs) {
+		xdgDirs = []string{`/etc/xdg`}
+	}
+	slices.Reverse(xdgDirs)
+
+	return append([]string{`/etc`}, xdgDirs...)
+} // systemConfigDirs()
+
+// `userConfigDir()` returns the user's configuration directory.
+//
+// This is `$XDG_CONFIG_HOME` if it's an absolute path, `~/.config`
+// otherwise (on all non-Windows platforms including macOS).
+//
+// Returns:
+// - `string`: The user's configuration directory.
+// - `error`: A possible error condition.
+func userConfigDir() (string, error) {
+	if dir := os.Getenv(`XDG_CONFIG_HOME`); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if nil != err {
+		return "", err
+	}
+
+	return filepath.Join(home, `.config`), nil
+} // userConfigDir()
+
+/* _EoF_ */
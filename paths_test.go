@@ -0,0 +1,106 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestConfigSearchPaths(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("XDG directories are not used on Windows")
+	}
+	t.Setenv("HOME", "/home/tester")
+	t.Setenv("XDG_CONFIG_HOME", "/home/tester/.cfg")
+	local, _ := filepath.Abs("myApp.ini")
+
+	tests := []struct {
+		name    string
+		xdgDirs string
+		want    []string
+	}{
+		{"0", "", []string{local, "/etc/myApp.ini", "/etc/xdg/myApp.ini",
+			"/home/tester/.myApp.ini", "/home/tester/.cfg/myApp.ini"}},
+		{"1", "/opt/one:relative:/opt/two", []string{local, "/etc/myApp.ini",
+			"/opt/two/myApp.ini", "/opt/one/myApp.ini",
+			"/home/tester/.myApp.ini", "/home/tester/.cfg/myApp.ini"}},
+		{"2", "/etc", []string{local, "/etc/myApp.ini",
+			"/home/tester/.myApp.ini", "/home/tester/.cfg/myApp.ini"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIRS", tt.xdgDirs)
+			if got := ConfigSearchPaths("myApp"); !slices.Equal(got, tt.want) {
+				t.Errorf("%q: ConfigSearchPaths() = %v,\nwant %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestConfigSearchPaths()
+
+func TestSystemConfigFiles(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("XDG directories are not used on Windows")
+	}
+
+	tests := []struct {
+		name    string
+		xdgDirs string
+		want    []string
+	}{
+		{"0", "", []string{"/etc/myApp.ini", "/etc/xdg/myApp.ini"}},
+		{"1", "/opt/one:/opt/two", []string{"/etc/myApp.ini",
+			"/opt/two/myApp.ini", "/opt/one/myApp.ini"}},
+		{"2", "relative:other", []string{"/etc/myApp.ini", "/etc/xdg/myApp.ini"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_DIRS", tt.xdgDirs)
+			if got := SystemConfigFiles("myApp"); !slices.Equal(got, tt.want) {
+				t.Errorf("%q: SystemConfigFiles() = %v,\nwant %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestSystemConfigFiles()
+
+func TestUserConfigFile(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("XDG directories are not used on Windows")
+	}
+	t.Setenv("HOME", "/home/tester")
+
+	tests := []struct {
+		name    string
+		xdgHome string
+		want    string
+	}{
+		{"0", "", "/home/tester/.config/myApp.ini"},
+		{"1", "/home/tester/.cfg", "/home/tester/.cfg/myApp.ini"},
+		{"2", "relative/dir", "/home/tester/.config/myApp.ini"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", tt.xdgHome)
+			got, err := UserConfigFile("myApp")
+			if (nil != err) || (got != tt.want) {
+				t.Errorf("%q: UserConfigFile() = %q, %v, want %q",
+					tt.name, got, err, tt.want)
+			}
+		})
+	}
+} // TestUserConfigFile()
+
+/* _EoF_ */
@@ -0,0 +1,42 @@
+//go:build windows
+
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+
+package ini
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// `systemConfigDirs()` returns the system-wide configuration
+// directories for the application `aName`.
+//
+// Parameters:
+// - `aName` The application's name.
+//
+// Returns:
+// - `[]string`: The list of directories, least important first.
+func systemConfigDirs(aName string) []string {
+	if dir := os.Getenv(`PROGRAMDATA`); "" != dir {
+		return []string{filepath.Join(dir, aName)}
+	}
+
+	return nil
+} // systemConfigDirs()
+
+// `userConfigDir()` returns the user's configuration directory.
+//
+// Returns:
+// - `string`: The user's configuration directory (`%APPDATA%`).
+// - `error`: A possible error condition.
+func userConfigDir() (string, error) {
+	return os.UserConfigDir()
+} // userConfigDir()
+
+/* _EoF_ */
@@ -0,0 +1,178 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `PHPBooleans` maps the (lower case) boolean values of the
+	// `DialectPHP` dialect to their meaning.
+	PHPBooleans = map[string]bool{
+		"":      false,
+		"0":     false,
+		"1":     true,
+		"false": false,
+		"no":    false,
+		"none":  false,
+		"null":  false,
+		"off":   false,
+		"on":    true,
+		"true":  true,
+		"yes":   true,
+	}
+
+	// `PHPConstants` maps the constants usable in integer values of
+	// the `DialectPHP` dialect to their values.
+	PHPConstants = map[string]int64{
+		"E_ERROR":             1,
+		"E_WARNING":           2,
+		"E_PARSE":             4,
+		"E_NOTICE":            8,
+		"E_CORE_ERROR":        16,
+		"E_CORE_WARNING":      32,
+		"E_COMPILE_ERROR":     64,
+		"E_COMPILE_WARNING":   128,
+		"E_USER_ERROR":        256,
+		"E_USER_WARNING":      512,
+		"E_USER_NOTICE":       1024,
+		"E_STRICT":            2048,
+		"E_RECOVERABLE_ERROR": 4096,
+		"E_DEPRECATED":        8192,
+		"E_USER_DEPRECATED":   16384,
+		"E_ALL":               32767,
+	}
+)
+
+// `evalPHPConstants()` evaluates an expression of `PHPConstants` and
+// integers combined by the bitwise operators `|`, `&`, `^`, and `~`
+// (e.g. `E_ALL & ~E_DEPRECATED`).
+//
+// The binary operators are applied from left to right.
+//
+// Parameters:
+// - `aExpr` The expression to evaluate.
+//
+// Returns:
+// - `int64`: The expression's value.
+// - `bool`: `true` if `aExpr` is a valid expression, `false` otherwise.
+func evalPHPConstants(aExpr string) (int64, bool) {
+	var (
+		negate  bool
+		op      byte = '|'
+		operand      = true // whether an operand is expected
+		result  int64
+	)
+
+	for i := 0; i < len(aExpr); {
+		c := aExpr[i]
+		switch {
+		case (' ' == c) || ('\t' == c):
+			i++
+
+		case '~' == c:
+			if !operand {
+				return 0, false
+			}
+			negate = !negate
+			i++
+
+		case ('|' == c) || ('&' == c) || ('^' == c):
+			if operand {
+				return 0, false
+			}
+			op, operand = c, true
+			i++
+
+		default:
+			end := i
+			for (end < len(aExpr)) && isPHPNameChar(aExpr[end]) {
+				end++
+			}
+			if (end == i) || !operand {
+				return 0, false
+			}
+			value, ok := PHPConstants[aExpr[i:end]]
+			if !ok {
+				var err error
+				if value, err = strconv.ParseInt(aExpr[i:end], 10, 64); nil != err {
+					return 0, false
+				}
+			}
+			if negate {
+				value, negate = ^value, false
+			}
+			switch op {
+			case '|':
+				result |= value
+			case '&':
+				result &= value
+			case '^':
+				result ^= value
+			}
+			operand, i = false, end
+		}
+	}
+
+	return result, !operand
+} // evalPHPConstants()
+
+// `isPHPArrayKey()` tells whether `aKey` is an array key (e.g.
+// `extension[]`).
+//
+// Parameters:
+// - `aKey` The name of the key.
+//
+// Returns:
+// - `bool`: `true` for an array key, `false` otherwise.
+func isPHPArrayKey(aKey string) bool {
+	return strings.HasSuffix(aKey, "[]")
+} // isPHPArrayKey()
+
+// `isPHPNameChar()` tells whether `aChar` may be part of a constant's
+// name or an integer.
+//
+// Parameters:
+// - `aChar` The character to check.
+//
+// Returns:
+// - `bool`: `true` for letters, digits, and the underscore.
+func isPHPNameChar(aChar byte) bool {
+	return ('_' == aChar) || ('0' <= aChar && '9' >= aChar) ||
+		('A' <= aChar && 'Z' >= aChar) || ('a' <= aChar && 'z' >= aChar)
+} // isPHPNameChar()
+
+// `phpValue()` returns the value of a key/value line of a `php.ini` file.
+//
+// A quoted value is returned without its quotes (keeping e.g. an
+// embedded `=` or `;`) while an unquoted value ends at a `;` comment.
+//
+// Parameters:
+// - `aValue` The raw value.
+//
+// Returns:
+// - `string`: The value to store.
+func phpValue(aValue string) string {
+	if aValue = strings.TrimSpace(aValue); "" == aValue {
+		return aValue
+	}
+	if quote := aValue[0]; ('"' == quote) || ('\'' == quote) {
+		if end := strings.IndexByte(aValue[1:], quote); 0 <= end {
+			return aValue[1 : end+1]
+		}
+		return aValue
+	}
+	value, _, _ := strings.Cut(aValue, ";")
+
+	return strings.TrimSpace(value)
+} // phpValue()
+
+/* _EoF_ */
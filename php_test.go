@@ -0,0 +1,94 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_evalPHPConstants(t *testing.T) {
+	tests := []struct {
+		expr   string
+		want   int64
+		wantOK bool
+	}{
+		{"E_ALL", 32767, true},
+		{"E_ALL & ~E_DEPRECATED & ~E_STRICT", 32767 &^ 8192 &^ 2048, true},
+		{"E_ERROR | E_WARNING", 3, true},
+		{"~E_NOTICE & E_ALL", 32767 &^ 8, true},
+		{"E_ALL &", 0, false},
+		{"E_UNKNOWN", 0, false},
+		{"", 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, ok := evalPHPConstants(tt.expr)
+			if (ok != tt.wantOK) || (ok && (got != tt.want)) {
+				t.Errorf("evalPHPConstants(%q) = %d, %v, want %d, %v",
+					tt.expr, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+} // Test_evalPHPConstants()
+
+func TestTSectionList_SetDialect_php(t *testing.T) {
+	data := `[PHP]
+engine = On
+short_open_tag = Off
+error_reporting = E_ALL & ~E_DEPRECATED
+memory_limit = 128M ; maximum amount of memory
+session.save_path = "N;/path=x"
+extension[] = curl
+extension[] = mbstring
+`
+	sl := NewSectionList().SetDialect(DialectPHP)
+	if _, err := sl.read(bufio.NewScanner(strings.NewReader(data))); nil != err {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	if b, ok := sl.AsBool("PHP", "engine"); !ok || !b {
+		t.Errorf("AsBool(engine) = %v, %v, want true, true", b, ok)
+	}
+	if b, ok := sl.AsBool("PHP", "short_open_tag"); !ok || b {
+		t.Errorf("AsBool(short_open_tag) = %v, %v, want false, true", b, ok)
+	}
+	if i, _ := sl.AsInt("PHP", "error_reporting"); 32767&^8192 != i {
+		t.Errorf("AsInt(error_reporting) = %d, want %d", i, 32767&^8192)
+	}
+	if u, ok := sl.AsUInt("PHP", "error_reporting"); !ok || (32767&^8192 != u) {
+		t.Errorf("AsUInt(error_reporting) = %d, %v, want %d, true", u, ok, 32767&^8192)
+	}
+	if s, _ := sl.AsString("PHP", "memory_limit"); "128M" != s {
+		t.Errorf("AsString(memory_limit) = %q, want %q", s, "128M")
+	}
+	if s, _ := sl.AsString("PHP", "session.save_path"); "N;/path=x" != s {
+		t.Errorf("AsString(session.save_path) = %q, want %q", s, "N;/path=x")
+	}
+	want := []string{"curl", "mbstring"}
+	if got, _ := sl.AsStringSlice("PHP", "extension", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("AsStringSlice(extension) = %q, want %q", got, want)
+	}
+
+	sl.UpdateSectKeySlice("PHP", "extension", append(want, "intl"), "")
+	out := sl.String()
+	if !strings.Contains(out, "extension[] = mbstring\nextension[] = intl\n") {
+		t.Errorf("String() misses the array keys:\n%s", out)
+	}
+	back := NewSectionList().SetDialect(DialectPHP)
+	_, _ = back.read(bufio.NewScanner(strings.NewReader(out)))
+	if !back.CompareTo(sl) {
+		t.Errorf("re-read data differ:\n%s", out)
+	}
+} // TestTSectionList_SetDialect_php()
+
+/* _EoF_ */
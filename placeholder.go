@@ -0,0 +1,196 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TPlaceholderResolver` returns the value of the placeholder
+	// expression `aExpr` (i.e. the trimmed text between `{{` and `}}`).
+	TPlaceholderResolver func(aExpr string) (string, error)
+
+	// `tPlaceholder` is a resolved placeholder expression.
+	tPlaceholder struct {
+		expires time.Time // zero: never expires
+		value   string
+	}
+
+	// `tPlaceholders` resolves the placeholder expressions caching
+	// their values.
+	tPlaceholders struct {
+		cache    map[string]tPlaceholder
+		mtx      sync.Mutex
+		resolver TPlaceholderResolver
+		ttl      time.Duration // the time to cache the values
+	}
+)
+
+var (
+	// `ErrPlaceholder` is returned if a placeholder can't be resolved.
+	ErrPlaceholder = errors.New("ini: can't resolve placeholder")
+
+	// `placeholderRE` matches the `{{...}}` placeholders of a value.
+	placeholderRE = regexp.MustCompile(`\{\{(.*?)\}\}`)
+)
+
+// `get()` returns the cached value of `aExpr`.
+//
+// Parameters:
+// - `aExpr` The placeholder expression to lookup.
+//
+// Returns:
+// - `string`: The cached value.
+// - `bool`: `true` if a valid value was found, `false` otherwise.
+func (ph *tPlaceholders) get(aExpr string) (string, bool) {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+
+	entry, ok := ph.cache[aExpr]
+	if !ok {
+		return "", false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(ph.cache, aExpr)
+		return "", false
+	}
+
+	return entry.value, true
+} // get()
+
+// `put()` caches `aValue` of `aExpr`.
+//
+// Parameters:
+// - `aExpr` The placeholder expression resolved.
+// - `aValue` The expression's value.
+func (ph *tPlaceholders) put(aExpr, aValue string) {
+	if 0 == ph.ttl {
+		return
+	}
+	entry := tPlaceholder{value: aValue}
+	if 0 < ph.ttl {
+		entry.expires = time.Now().Add(ph.ttl)
+	}
+
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+
+	if nil == ph.cache {
+		ph.cache = make(map[string]tPlaceholder)
+	}
+	ph.cache[aExpr] = entry
+} // put()
+
+// `reset()` removes all cached values.
+func (ph *tPlaceholders) reset() {
+	if nil == ph {
+		return
+	}
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+
+	ph.cache = nil
+} // reset()
+
+// --------------------------------------------------------------------------
+
+// `FlushPlaceholders()` removes all cached placeholder values so
+// that the next access resolves them again.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) FlushPlaceholders() *TSectionList {
+	sl.placeholder.reset()
+
+	return sl
+} // FlushPlaceholders()
+
+// `resolvePlaceholders()` replaces all `{{...}}` placeholders in
+// `aValue` by the values returned by the list's placeholder resolver.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The value to resolve.
+//
+// Returns:
+// - `string`: The resolved value.
+// - `error`: A wrapped `ErrPlaceholder` if the resolver failed.
+func (sl *TSectionList) resolvePlaceholders(aSection, aKey, aValue string) (string, error) {
+	if (nil == sl.placeholder) || !strings.Contains(aValue, "{{") {
+		return aValue, nil
+	}
+
+	var err error
+	result := placeholderRE.ReplaceAllStringFunc(aValue, func(aMatch string) string {
+		if nil != err {
+			return aMatch
+		}
+		expr := strings.TrimSpace(aMatch[2 : len(aMatch)-2])
+		if value, ok := sl.placeholder.get(expr); ok {
+			return value
+		}
+
+		var value string
+		if value, err = sl.placeholder.resolver(expr); nil != err {
+			err = fmt.Errorf("[%s] %s: %w: %w", aSection, aKey, ErrPlaceholder, err)
+			return aMatch
+		}
+		sl.placeholder.put(expr, value)
+
+		return value
+	})
+	if nil != err {
+		return "", err
+	}
+
+	return result, nil
+} // resolvePlaceholders()
+
+// `SetPlaceholderResolver()` registers `aResolver` for the `{{...}}`
+// placeholders of the values.
+//
+// The resolver is called lazily whenever a value holding placeholders
+// is accessed by one of the `AsXxx()` or `GetXxx()` methods while the
+// INI data itself keeps the placeholders. This allows to fetch values
+// from e.g. a service discovery or a vault at the time they're used:
+//
+//	sl.SetPlaceholderResolver(func(aExpr string) (string, error) {
+//		return consulClient.Lookup(aExpr)
+//	}, time.Minute)
+//
+// A value like `url = http://{{ db-host }}:5432` then calls the
+// resolver with `db-host`. The resolved values are cached per
+// expression for `aTTL`; a TTL of zero disables the caching while a
+// negative TTL caches the values until `FlushPlaceholders()` is called.
+//
+// Parameters:
+// - `aResolver` The resolver to use (`nil` disables the placeholders).
+// - `aTTL` The time to cache the resolved values.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetPlaceholderResolver(aResolver TPlaceholderResolver, aTTL time.Duration) *TSectionList {
+	sl.placeholder.reset()
+	if nil == aResolver {
+		sl.placeholder = nil
+	} else {
+		sl.placeholder = &tPlaceholders{resolver: aResolver, ttl: aTTL}
+	}
+
+	return sl
+} // SetPlaceholderResolver()
+
+/* _EoF_ */
@@ -0,0 +1,93 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetPlaceholderResolver(t *testing.T) {
+	calls := 0
+	resolver := func(aExpr string) (string, error) {
+		calls++
+		switch aExpr {
+		case "db-host":
+			return "db.example.com", nil
+		case "counter":
+			return strconv.Itoa(calls), nil
+		}
+		return "", errors.New("unknown expression")
+	}
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "url", "http://{{ db-host }}:5432")
+	sl.AddSectionKey("db", "count", "{{counter}}")
+	sl.AddSectionKey("db", "bad", "{{n.a.}}")
+	sl.AddSectionKey("db", "plain", "{ not a placeholder }")
+
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{"1", -1, "url", "http://db.example.com:5432", false},
+		{"2", -1, "plain", "{ not a placeholder }", false},
+		{"3", -1, "bad", "", true},
+		{"4", 0, "count", "3", false},
+		{"5", 0, "count", "4", false},
+		{"6", time.Hour, "count", "5", false},
+		{"7", -1, "count", "6", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl.SetPlaceholderResolver(resolver, tt.ttl)
+			got, err := sl.GetString("db", tt.key)
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: TSectionList.GetString() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrPlaceholder) {
+				t.Errorf("%q: TSectionList.GetString() error = %v, want %v",
+					tt.name, err, ErrPlaceholder)
+			}
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.GetString() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// cached values are reused until they expire or are flushed:
+	sl.SetPlaceholderResolver(resolver, time.Hour)
+	first, _ := sl.GetString("db", "count")
+	if got, _ := sl.GetString("db", "count"); got != first {
+		t.Errorf("cached TSectionList.GetString() = %q, want %q", got, first)
+	}
+	if got, _ := sl.FlushPlaceholders().GetString("db", "count"); got == first {
+		t.Errorf("flushed TSectionList.GetString() = %q, want a new value", got)
+	}
+	sl.SetPlaceholderResolver(resolver, time.Nanosecond)
+	first, _ = sl.GetString("db", "count")
+	time.Sleep(time.Millisecond)
+	if got, _ := sl.GetString("db", "count"); got == first {
+		t.Errorf("expired TSectionList.GetString() = %q, want a new value", got)
+	}
+
+	// the INI data keeps the placeholders:
+	if got, _ := sl.GetSection("db").AsString("url"); "http://{{ db-host }}:5432" != got {
+		t.Errorf("TSection.AsString() = %q, want the placeholder", got)
+	}
+} // TestTSectionList_SetPlaceholderResolver()
+
+/* _EoF_ */
@@ -0,0 +1,293 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+// `propertiesEscape()` returns `aString` escaped for a Java properties
+// file.
+//
+// Non-ASCII characters are written as `\uXXXX` (using surrogate pairs
+// for characters outside the BMP) so that the result is valid in the
+// traditional ISO-8859-1 encoding as well as in UTF-8.
+//
+// Parameters:
+// - `aString` The string to escape.
+// - `aIsKey` Whether `aString` is a key (escaping all blanks) or a
+// value (escaping only a leading blank).
+//
+// Returns:
+// - `string`: The escaped string.
+func propertiesEscape(aString string, aIsKey bool) string {
+	var sb strings.Builder
+
+	for idx, c := range aString {
+		switch c {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case ' ':
+			if aIsKey || (0 == idx) {
+				sb.WriteString(`\ `)
+			} else {
+				sb.WriteByte(' ')
+			}
+		case '=', ':', '#', '!':
+			sb.WriteByte('\\')
+			sb.WriteRune(c)
+		default:
+			if (0x20 > c) || (0x7e < c) {
+				if 0xffff < c {
+					r1, r2 := utf16.EncodeRune(c)
+					fmt.Fprintf(&sb, `\u%04X\u%04X`, r1, r2)
+				} else {
+					fmt.Fprintf(&sb, `\u%04X`, c)
+				}
+			} else {
+				sb.WriteRune(c)
+			}
+		}
+	}
+
+	return sb.String()
+} // propertiesEscape()
+
+// `propertiesUnescape()` returns `aString` with all escape sequences
+// of a Java properties file resolved.
+//
+// Parameters:
+// - `aString` The string to unescape.
+//
+// Returns:
+// - `string`: The unescaped string.
+// - `error`: `ErrParseValue` if a `\u` escape is malformed.
+func propertiesUnescape(aString string) (string, error) {
+	if !strings.Contains(aString, `\`) {
+		return aString, nil
+	}
+	var (
+		sb    strings.Builder
+		units []uint16 // pending UTF-16 code units
+	)
+	flush := func() {
+		if 0 < len(units) {
+			sb.WriteString(string(utf16.Decode(units)))
+			units = units[:0]
+		}
+	}
+
+	for idx := 0; idx < len(aString); idx++ {
+		c := aString[idx]
+		if ('\\' != c) || (idx+1 == len(aString)) {
+			flush()
+			sb.WriteByte(c)
+			continue
+		}
+		idx++
+		if 'u' == aString[idx] {
+			if idx+5 > len(aString) {
+				return "", fmt.Errorf("%w: incomplete escape %q",
+					ErrParseValue, aString[idx-1:])
+			}
+			code, err := strconv.ParseUint(aString[idx+1:idx+5], 16, 16)
+			if nil != err {
+				return "", fmt.Errorf("%w: invalid escape %q",
+					ErrParseValue, aString[idx-1:idx+5])
+			}
+			units = append(units, uint16(code))
+			idx += 4
+			continue
+		}
+		flush()
+		switch aString[idx] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		default: // `\\`, `\=`, `\:`, `\ ` etc.
+			sb.WriteByte(aString[idx])
+		}
+	}
+	flush()
+
+	return sb.String(), nil
+} // propertiesUnescape()
+
+// `propertiesSplit()` splits a logical properties line into its
+// (still escaped) key and value.
+//
+// The key ends at the first unescaped `=`, `:`, or blank; blanks
+// around the separator are skipped.
+//
+// Parameters:
+// - `aLine` The logical line (without leading blanks).
+//
+// Returns:
+// - `string`: The escaped key.
+// - `string`: The escaped value.
+func propertiesSplit(aLine string) (string, string) {
+	end := len(aLine)
+	for idx := 0; idx < len(aLine); idx++ {
+		c := aLine[idx]
+		if '\\' == c {
+			idx++
+			continue
+		}
+		if ('=' == c) || (':' == c) || (' ' == c) || ('\t' == c) || ('\f' == c) {
+			end = idx
+			break
+		}
+	}
+	key, rest := aLine[:end], aLine[end:]
+
+	rest = strings.TrimLeft(rest, " \t\f")
+	if strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":") {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+
+	return key, rest
+} // propertiesSplit()
+
+// `isPropertiesContinued()` checks whether `aLine` ends with an odd
+// number of backslashes, i.e. continues on the next line.
+//
+// Parameters:
+// - `aLine` The line to check.
+//
+// Returns:
+// - `bool`: `true` if the line is continued, `false` otherwise.
+func isPropertiesContinued(aLine string) bool {
+	count := 0
+	for idx := len(aLine) - 1; (0 <= idx) && ('\\' == aLine[idx]); idx-- {
+		count++
+	}
+
+	return 1 == count%2
+} // isPropertiesContinued()
+
+// `ToProperties()` writes the list's default section to `aWriter`
+// in the format of Java properties files.
+//
+// Each key is written as `key=value` with special characters escaped
+// and non-ASCII characters written as `\uXXXX`. Since properties files
+// know nothing about sections, all other sections are ignored.
+//
+// Parameters:
+// - `aWriter` The writer to write the properties to.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) ToProperties(aWriter io.Writer) error {
+	var sb strings.Builder
+
+	if kl, ok := sl.section(sl.defSect); ok {
+		kl.mtx.RLock()
+		for _, kv := range kl.data {
+			sb.WriteString(propertiesEscape(kv.Key, true) + "=" +
+				propertiesEscape(kv.Value, false) + "\n")
+		}
+		kl.mtx.RUnlock()
+	}
+
+	_, err := io.WriteString(aWriter, sb.String())
+
+	return err
+} // ToProperties()
+
+// `FromProperties()` returns a new list with the data read from the
+// Java properties file provided by `aReader`.
+//
+// All keys are stored in the list's default section. Lines starting
+// with `#` or `!` are comments, keys are separated from their values
+// by `=`, `:`, or blanks, and a line ending with a backslash continues
+// on the next line (whose leading blanks are ignored). Escape
+// sequences like `\t`, `\n` and `\uXXXX` are resolved.
+//
+// Parameters:
+// - `aReader` The reader to read the properties from.
+//
+// Returns:
+// - `*TSectionList`: The list read from `aReader`.
+// - `error`: A possible error condition.
+func FromProperties(aReader io.Reader) (*TSectionList, error) {
+	var (
+		logical strings.Builder
+		lineNo  int
+		pending bool // whether the logical line is continued
+		startNo int
+	)
+	result := NewSectionList()
+
+	addLine := func() error {
+		rawKey, rawValue := propertiesSplit(logical.String())
+		key, err := propertiesUnescape(rawKey)
+		if nil == err {
+			var value string
+			if value, err = propertiesUnescape(rawValue); nil == err {
+				_ = result.AddSectionKey("", key, value)
+			}
+		}
+		if nil != err {
+			return fmt.Errorf("line %d: %w", startNo, err)
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(aReader)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if !pending {
+			if ("" == line) || ('#' == line[0]) || ('!' == line[0]) {
+				continue
+			}
+			logical.Reset()
+			startNo = lineNo
+		}
+		if pending = isPropertiesContinued(line); pending {
+			logical.WriteString(line[:len(line)-1])
+			continue
+		}
+		logical.WriteString(line)
+		if err := addLine(); nil != err {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, err
+	}
+	if pending { // a continuation at EOF: use what we've got
+		if err := addLine(); nil != err {
+			return nil, err
+		}
+	}
+
+	return result, nil
+} // FromProperties()
+
+/* _EoF_ */
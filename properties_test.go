@@ -0,0 +1,99 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestFromProperties(t *testing.T) {
+	src := `# a comment
+! another comment
+app.name = My App
+app.port:8080
+greeting Gr\u00fc\u00dfe
+emoji=\uD83D\uDE00
+path=C:\\temp\\dir
+multi = first, \
+        second, \
+        third
+key\ with\ blanks = tab\there
+   indented=yes
+`
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"1", "app.name", "My App"},
+		{"2", "app.port", "8080"},
+		{"3", "greeting", "Grüße"},
+		{"4", "emoji", "😀"},
+		{"5", "path", `C:\temp\dir`},
+		{"6", "multi", "first, second, third"},
+		{"7", "key with blanks", "tab\there"},
+		{"8", "indented", "yes"},
+		// TODO: Add test cases.
+	}
+
+	sl, err := FromProperties(strings.NewReader(src))
+	if nil != err {
+		t.Fatalf("FromProperties() error = %v", err)
+	}
+	if got := len(sl.sections); 1 != got {
+		t.Errorf("FromProperties() sections = %d, want 1", got)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := sl.AsString("", tt.key); tt.want != got {
+				t.Errorf("FromProperties() %q = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+
+	if _, err = FromProperties(strings.NewReader(`bad=\u12`)); !errors.Is(err, ErrParseValue) {
+		t.Errorf("FromProperties() error = %v, want %v", err, ErrParseValue)
+	}
+} // TestFromProperties()
+
+func TestTSectionList_ToProperties(t *testing.T) {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("", "app.name", "My App")
+	_ = sl.AddSectionKey("", "key=x", "a:b")
+	_ = sl.AddSectionKey("", "greeting", "Grüße 😀")
+	_ = sl.AddSectionKey("other", "ignored", "true")
+
+	want := `app.name=My App
+key\=x=a\:b
+greeting=Gr\u00FC\u00DFe \uD83D\uDE00
+`
+	var sb strings.Builder
+	if err := sl.ToProperties(&sb); nil != err {
+		t.Fatalf("TSectionList.ToProperties() error = %v", err)
+	}
+	if got := sb.String(); want != got {
+		t.Errorf("TSectionList.ToProperties() =\n%s\nwant\n%s", got, want)
+	}
+
+	// and back again:
+	back, err := FromProperties(strings.NewReader(sb.String()))
+	if nil != err {
+		t.Fatalf("FromProperties() error = %v", err)
+	}
+	for _, key := range []string{"app.name", "key=x", "greeting"} {
+		want, _ := sl.AsString("", key)
+		if got, _ := back.AsString("", key); want != got {
+			t.Errorf("FromProperties() round trip %q = %q, want %q", key, got, want)
+		}
+	}
+} // TestTSectionList_ToProperties()
+
+/* _EoF_ */
@@ -0,0 +1,51 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "errors"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrReadOnly` is returned when modifying a read-only list
+	// (see `SetReadOnly()`).
+	ErrReadOnly = errors.New("ini: list is read-only")
+)
+
+// `IsReadOnly()` reports whether the list rejects all modifications.
+//
+// Returns:
+// - `bool`: `true` if the list is read-only, `false` otherwise.
+func (sl *TSectionList) IsReadOnly() bool {
+	return sl.readOnly
+} // IsReadOnly()
+
+// `SetReadOnly()` determines whether the list rejects all modifications.
+//
+// In read-only mode all methods modifying the list's data fail: those
+// returning a `bool` (e.g. `AddSectionKey()`, `UpdateSectKeyStr()`,
+// `RemoveSectionKey()`) return `false`, those returning an `error` (e.g.
+// `Load()`, `MergeWith()`, `UnmarshalText()`, `TTransaction.Commit()`)
+// return `ErrReadOnly`, and those returning the list (e.g. `Clear()`,
+// `Merge()`, `Sort()`) leave it unchanged.
+//
+// This protects shared configuration objects from accidental changes
+// by downstream code. Note that a `TSection` returned by `GetSection()`
+// can still be modified directly; see `Freeze()` for an immutable view.
+//
+// Parameters:
+// - `aReadOnly` Whether to reject all modifications.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetReadOnly(aReadOnly bool) *TSectionList {
+	sl.readOnly = aReadOnly
+
+	return sl
+} // SetReadOnly()
+
+/* _EoF_ */
@@ -0,0 +1,79 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetReadOnly(t *testing.T) {
+	sl := prepSectionList()
+	want, changed := sl.String(), len(sl.ChangedKeys())
+	other := NewSectionList()
+	other.AddSectionKey("s1", "bool", "yes")
+	other.AddSectionKey("new", "key", "value")
+
+	if sl.SetReadOnly(true); !sl.IsReadOnly() {
+		t.Fatal("TSectionList.IsReadOnly() = false")
+	}
+
+	tests := []struct {
+		name string
+		call func() bool
+	}{
+		{"AddSectionKey", func() bool { return sl.AddSectionKey("s1", "bool", "yes") }},
+		{"AddSectionKey new", func() bool { return sl.AddSectionKey("new", "key", "value") }},
+		{"AddSectionFromMap", func() bool { return sl.AddSectionFromMap("s1", map[string]string{"k": "v"}) }},
+		{"UpdateSectKeyStr", func() bool { return sl.UpdateSectKeyStr("s1", "bool", "yes") }},
+		{"UpdateSectKeyInt", func() bool { return sl.UpdateSectKeyInt("s3", "int", 1) }},
+		{"RemoveSectionKey", func() bool { return sl.RemoveSectionKey("s1", "bool") }},
+		{"RemoveSection", func() bool { removed, _ := sl.RemoveSection("s2"); return removed }},
+		{"RenameSection", func() bool { return sl.RenameSection("s2", "s5") }},
+		{"Apply", func() bool { return sl.Apply([]TChange{{Section: "s1", Key: "bool", Value: "yes"}})[0] }},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.call() {
+				t.Errorf("TSectionList.%s() = true for a read-only list", tt.name)
+			}
+		})
+	}
+
+	sl.Clear().Merge(other).Sort()
+	if err := sl.MergeWith(other, Overwrite); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("TSectionList.MergeWith() error = %v, want %v", err, ErrReadOnly)
+	}
+	if err := sl.UnmarshalText([]byte("[x]\nkey = value\n")); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("TSectionList.UnmarshalText() error = %v, want %v", err, ErrReadOnly)
+	}
+	if err := sl.UnmarshalJSON([]byte(`{"x":{"key":"value"}}`)); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("TSectionList.UnmarshalJSON() error = %v, want %v", err, ErrReadOnly)
+	}
+	tx := sl.Begin()
+	tx.AddSectionKey("s1", "bool", "yes")
+	if err := tx.Commit(); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("TTransaction.Commit() error = %v, want %v", err, ErrReadOnly)
+	}
+	if got := sl.String(); got != want {
+		t.Errorf("read-only list was modified:\n%s\nwant\n%s", got, want)
+	}
+	if got := len(sl.ChangedKeys()); got != changed {
+		t.Errorf("TSectionList.ChangedKeys() = %d keys, want %d", got, changed)
+	}
+
+	// modifications are possible again:
+	sl.SetReadOnly(false)
+	if !sl.AddSectionKey("s1", "bool", "yes") {
+		t.Error("TSectionList.AddSectionKey() = false after SetReadOnly(false)")
+	}
+} // TestTSectionList_SetReadOnly()
+
+/* _EoF_ */
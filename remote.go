@@ -0,0 +1,180 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `THTTPOptions` configures reading INI data from a HTTP server
+	// (see `NewFromURL()`).
+	THTTPOptions struct {
+		// `Client` is used for the requests (`http.DefaultClient` if `nil`).
+		Client *http.Client
+
+		// `Header` holds additional request headers (e.g. for
+		// authorisation).
+		Header http.Header
+
+		// `Limits` restricts the INI data accepted.
+		Limits TParseLimits
+
+		// `OnChange` is called with the newly read list whenever a
+		// refresh found modified INI data.
+		OnChange func(*TSectionList)
+
+		// `RefreshInterval` is the time between two refreshes;
+		// a zero (or negative) value disables refreshing.
+		RefreshInterval time.Duration
+	}
+
+	// `tRemoteSource` remembers the validators of the INI data last read.
+	tRemoteSource struct {
+		etag    string       // value of the `ETag` header
+		lastMod string       // value of the `Last-Modified` header
+		opts    THTTPOptions // request options
+		url     string       // address of the INI data
+	}
+)
+
+var (
+	// `ErrHTTPStatus` is returned if the server answered a request
+	// with an unexpected status code.
+	ErrHTTPStatus = errors.New("ini: unexpected HTTP status")
+)
+
+// `fetch()` requests the INI data from the remote source.
+//
+// The request is made conditional if the previous response provided
+// an `ETag` or `Last-Modified` header.
+//
+// Parameters:
+// - `aCtx` The context of the request.
+//
+// Returns:
+// - `*TSectionList`: The list read (`nil` if the data wasn't modified).
+// - `error`: A possible error condition.
+func (rs *tRemoteSource) fetch(aCtx context.Context) (*TSectionList, error) {
+	req, err := http.NewRequestWithContext(aCtx, http.MethodGet, rs.url, nil)
+	if nil != err {
+		return nil, err
+	}
+	for name, values := range rs.opts.Header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if "" != rs.etag {
+		req.Header.Set("If-None-Match", rs.etag)
+	}
+	if "" != rs.lastMod {
+		req.Header.Set("If-Modified-Since", rs.lastMod)
+	}
+
+	client := rs.opts.Client
+	if nil == client {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if nil != err {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrHTTPStatus, resp.Status)
+	}
+
+	result := NewSectionList().SetParseLimits(rs.opts.Limits)
+	if _, err = result.read(result.newScanner(aCtx, resp.Body)); nil != err {
+		return nil, err
+	}
+	rs.etag = resp.Header.Get("ETag")
+	rs.lastMod = resp.Header.Get("Last-Modified")
+
+	return result, nil
+} // fetch()
+
+// `refresh()` periodically requests the INI data until `aCtx` is done
+// calling the `OnChange` option whenever modified data were read.
+//
+// Parameters:
+// - `aCtx` The context to stop refreshing.
+func (rs *tRemoteSource) refresh(aCtx context.Context) {
+	ticker := time.NewTicker(rs.opts.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-aCtx.Done():
+			return
+
+		case <-ticker.C:
+			list, err := rs.fetch(aCtx)
+			if (nil != err) || (nil == list) {
+				continue // unmodified or try again with the next tick
+			}
+			if nil != rs.opts.OnChange {
+				rs.opts.OnChange(list)
+			}
+		}
+	}
+} // refresh()
+
+// `NewFromURL()` reads the INI data from the HTTP(S) address `aURL`.
+//
+// If `aOpts.RefreshInterval` is positive a goroutine requests the
+// data periodically until `aCtx` is done; those requests use the
+// `ETag` and `Last-Modified` headers of the previous response so the
+// server can answer with `304 Not Modified`. Whenever modified data
+// were read, they are passed to `aOpts.OnChange`.
+//
+// Example:
+//
+//	sl, err := ini.NewFromURL(ctx, "https://config.example.com/app.ini",
+//		ini.THTTPOptions{
+//			RefreshInterval: time.Minute,
+//			OnChange:        func(aList *ini.TSectionList) { cfg.Store(aList) },
+//		})
+//
+// Parameters:
+// - `aCtx` The context of the requests.
+// - `aURL` The address of the INI data.
+// - `aOpts` The request options.
+//
+// Returns:
+// - `*TSectionList`: The list of sections read.
+// - `error`: A possible error condition (e.g. `ErrHTTPStatus`).
+func NewFromURL(aCtx context.Context, aURL string, aOpts THTTPOptions) (*TSectionList, error) {
+	source := &tRemoteSource{opts: aOpts, url: aURL}
+	result, err := source.fetch(aCtx)
+	if nil != err {
+		return NewSectionList(), err
+	}
+	if nil == result { // not modified without a conditional request
+		return NewSectionList(), fmt.Errorf("%w: %d", ErrHTTPStatus, http.StatusNotModified)
+	}
+
+	if 0 < aOpts.RefreshInterval {
+		go source.refresh(aCtx)
+	}
+
+	return result, nil
+} // NewFromURL()
+
+/* _EoF_ */
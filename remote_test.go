@@ -0,0 +1,85 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestNewFromURL(t *testing.T) {
+	var (
+		requests    atomic.Int32
+		conditional atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(aW http.ResponseWriter, aR *http.Request) {
+		requests.Add(1)
+		if "secret" != aR.Header.Get("X-Token") {
+			aW.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if `"v1"` == aR.Header.Get("If-None-Match") {
+			conditional.Add(1)
+			aW.WriteHeader(http.StatusNotModified)
+			return
+		}
+		aW.Header().Set("ETag", `"v1"`)
+		_, _ = aW.Write([]byte("[server]\nport = 8080\n"))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := NewFromURL(ctx, srv.URL, THTTPOptions{}); !errors.Is(err, ErrHTTPStatus) {
+		t.Errorf("NewFromURL() error = %v, want %v", err, ErrHTTPStatus)
+	}
+
+	changed := make(chan *TSectionList, 1)
+	sl, err := NewFromURL(ctx, srv.URL, THTTPOptions{
+		Header:          http.Header{"X-Token": {"secret"}},
+		OnChange:        func(aList *TSectionList) { changed <- aList },
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	if nil != err {
+		t.Fatalf("NewFromURL() error = %v", err)
+	}
+	if port, _ := sl.AsInt("server", "port"); 8080 != port {
+		t.Errorf("NewFromURL() port = %d, want 8080", port)
+	}
+
+	// wait for some refreshes answered by "304 Not Modified"
+	deadline := time.Now().Add(2 * time.Second)
+	for (2 > conditional.Load()) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if 2 > conditional.Load() {
+		t.Errorf("NewFromURL() conditional requests = %d, want >= 2", conditional.Load())
+	}
+	select {
+	case <-changed:
+		t.Error("NewFromURL() OnChange called for unmodified data")
+	default:
+	}
+
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	count := requests.Load()
+	time.Sleep(30 * time.Millisecond)
+	if count != requests.Load() {
+		t.Error("NewFromURL() kept refreshing after cancellation")
+	}
+} // TestNewFromURL()
+
+/* _EoF_ */
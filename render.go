@@ -0,0 +1,127 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tTemplates` holds the settings of rendering the values read.
+	tTemplates struct {
+		data    any              // the data passed to the templates
+		enabled bool             // render the values on loading
+		funcs   template.FuncMap // the functions available to the templates
+	}
+)
+
+var (
+	// `ErrTemplate` is returned if a value can't be rendered.
+	ErrTemplate = errors.New("ini: can't render template")
+)
+
+// `TemplateFuncs()` returns the functions available to the templates
+// rendered on loading (see `SetTemplateData()`):
+//
+//   - `env "NAME"` returns the value of the environment variable `NAME`,
+//   - `hostname` returns the host's name,
+//   - `now` returns the current local time.
+//
+// Returns:
+// - `template.FuncMap`: The default template functions.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"hostname": func() (string, error) {
+			return os.Hostname()
+		},
+		"now": time.Now,
+	}
+} // TemplateFuncs()
+
+// `renderTemplates()` runs the values of the keys just read through
+// `text/template`.
+//
+// Values failing to render are left unchanged.
+//
+// Parameters:
+// - `aSeen` The section/key pairs read.
+//
+// Returns:
+// - `error`: The (joined) errors of the values failing to render.
+func (sl *TSectionList) renderTemplates(aSeen tSeenKeys) error {
+	funcs := TemplateFuncs()
+	maps.Copy(funcs, sl.templates.funcs)
+
+	var errs []error
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		for _, kv := range kl.KeyVals() {
+			if _, ok := aSeen[originID(name, kv.Key)]; !ok || !strings.Contains(kv.Value, "{{") {
+				continue
+			}
+
+			var sb strings.Builder
+			tmpl, err := template.New(kv.Key).Funcs(funcs).
+				Option("missingkey=error").Parse(kv.Value)
+			if nil == err {
+				err = tmpl.Execute(&sb, sl.templates.data)
+			}
+			if nil != err {
+				errs = append(errs, fmt.Errorf("[%s] %s: %w: %w", name, kv.Key, ErrTemplate, err))
+				continue
+			}
+			kl.AddKey(kv.Key, sb.String())
+		}
+	}
+
+	return errors.Join(errs...)
+} // renderTemplates()
+
+// `SetTemplateData()` enables running each value read from an INI
+// file through `text/template` using `aData` and the functions of
+// `TemplateFuncs()` and `aFuncs`.
+//
+// This allows the INI file to compute values, e.g.:
+//
+//	workdir = /var/lib/{{ .AppName }}
+//	logfile = /var/log/{{ hostname }}-{{ env "USER" }}.log
+//
+// The values are rendered once while loading; the INI data written
+// by `Store()` holds the rendered values. Values failing to render
+// are kept unchanged while the loading method returns an error naming
+// the respective section and key (and wrapping `ErrTemplate`).
+// Since `{{...}}` is the templates' syntax, this option isn't meant
+// to be combined with `SetPlaceholderResolver()`.
+//
+// Passing `nil` for both arguments disables the rendering.
+//
+// Parameters:
+// - `aData` The data passed to the templates (may be `nil`).
+// - `aFuncs` Additional template functions (may be `nil`).
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetTemplateData(aData any, aFuncs template.FuncMap) *TSectionList {
+	sl.templates = tTemplates{
+		data:    aData,
+		enabled: (nil != aData) || (nil != aFuncs),
+		funcs:   aFuncs,
+	}
+
+	return sl
+} // SetTemplateData()
+
+/* _EoF_ */
@@ -0,0 +1,71 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetTemplateData(t *testing.T) {
+	t.Setenv("INI_TEMPLATE_TEST", "tester")
+	host, _ := os.Hostname()
+	data := struct{ AppName string }{"myApp"}
+	funcs := template.FuncMap{"upper": strings.ToUpper}
+
+	tests := []struct {
+		name    string
+		data    any
+		funcs   template.FuncMap
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"1", data, nil, "/var/lib/{{ .AppName }}", "/var/lib/myApp", false},
+		{"2", data, nil, `{{ env "INI_TEMPLATE_TEST" }}`, "tester", false},
+		{"3", data, nil, "{{ hostname }}.log", host + ".log", false},
+		{"4", data, funcs, "{{ upper .AppName }}", "MYAPP", false},
+		{"5", data, nil, "{{ .Unknown }}", "{{ .Unknown }}", true},
+		{"6", data, nil, "{{ broken", "{{ broken", true},
+		{"7", nil, nil, "/var/lib/{{ .AppName }}", "/var/lib/{{ .AppName }}", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList().SetTemplateData(tt.data, tt.funcs)
+			err := sl.UnmarshalText([]byte("[app]\nplain = value\nkey = " + tt.value + "\n"))
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("%q: TSectionList.UnmarshalText() error = %v, wantErr %v",
+					tt.name, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrTemplate) || !strings.Contains(err.Error(), "[app] key") {
+					t.Errorf("%q: TSectionList.UnmarshalText() error = %v, want %v for [app] key",
+						tt.name, err, ErrTemplate)
+				}
+			}
+			if got, _ := sl.GetString("app", "key"); got != tt.want {
+				t.Errorf("%q: TSectionList.GetString() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			if got, _ := sl.GetString("app", "plain"); "value" != got {
+				t.Errorf("%q: TSectionList.GetString() = %q, want %q",
+					tt.name, got, "value")
+			}
+			if sl.IsDirty() {
+				t.Errorf("%q: TSectionList.IsDirty() = true after loading", tt.name)
+			}
+		})
+	}
+} // TestTSectionList_SetTemplateData()
+
+/* _EoF_ */
@@ -0,0 +1,236 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TRequirement` collects the errors of mandatory keys which are
+	// missing or invalid.
+	//
+	// see `TSectionList.Require()`
+	TRequirement struct {
+		errs []error       // the errors found
+		list *TSectionList // the list to check
+	}
+)
+
+// `note()` records `aErr` (if any).
+//
+// Parameters:
+// - `aErr` The error to record.
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) note(aErr error) *TRequirement {
+	if nil != aErr {
+		rq.errs = append(rq.errs, aErr)
+	}
+
+	return rq
+} // note()
+
+// `Bool()` requires `aKey` in `aSection` to hold a boolean value
+// which is stored in `aTarget`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` The variable to store the value in (may be `nil`).
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) Bool(aSection, aKey string, aTarget *bool) *TRequirement {
+	value, err := rq.list.GetBool(aSection, aKey)
+	if (nil == err) && (nil != aTarget) {
+		*aTarget = value
+	}
+
+	return rq.note(err)
+} // Bool()
+
+// `Duration()` requires `aKey` in `aSection` to hold a duration
+// which is stored in `aTarget`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` The variable to store the value in (may be `nil`).
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) Duration(aSection, aKey string, aTarget *time.Duration) *TRequirement {
+	value, err := rq.list.GetDuration(aSection, aKey)
+	if (nil == err) && (nil != aTarget) {
+		*aTarget = value
+	}
+
+	return rq.note(err)
+} // Duration()
+
+// `Err()` returns all errors found so far.
+//
+// Returns:
+// - `error`: The joined errors (see `errors.Join()`) or `nil` if all
+// required keys are present and valid.
+func (rq *TRequirement) Err() error {
+	return errors.Join(rq.errs...)
+} // Err()
+
+// `Int()` requires `aKey` in `aSection` to hold an integer value
+// which is stored in `aTarget`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` The variable to store the value in (may be `nil`).
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) Int(aSection, aKey string, aTarget *int) *TRequirement {
+	value, err := rq.list.GetInt(aSection, aKey)
+	if (nil == err) && (nil != aTarget) {
+		*aTarget = value
+	}
+
+	return rq.note(err)
+} // Int()
+
+// `Keys()` requires all `aKeys` to exist in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKeys` The names of the keys to lookup.
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) Keys(aSection string, aKeys ...string) *TRequirement {
+	for _, key := range aKeys {
+		_, err := rq.list.GetString(aSection, key)
+		rq.note(err)
+	}
+
+	return rq
+} // Keys()
+
+// `String()` requires `aKey` in `aSection` to exist; its value is
+// stored in `aTarget`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aTarget` The variable to store the value in (may be `nil`).
+//
+// Returns:
+// - `*TRequirement`: The current requirement.
+func (rq *TRequirement) String(aSection, aKey string, aTarget *string) *TRequirement {
+	value, err := rq.list.GetString(aSection, aKey)
+	if (nil == err) && (nil != aTarget) {
+		*aTarget = value
+	}
+
+	return rq.note(err)
+} // String()
+
+// --------------------------------------------------------------------------
+
+// `MustBool()` returns the value of `aKey` in `aSection` as a boolean
+// value panicking if it's missing or invalid.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `bool`: The value associated with `aKey`.
+func (sl *TSectionList) MustBool(aSection, aKey string) bool {
+	result, err := sl.GetBool(aSection, aKey)
+	if nil != err {
+		panic(err)
+	}
+
+	return result
+} // MustBool()
+
+// `MustDuration()` returns the value of `aKey` in `aSection` as a
+// duration panicking if it's missing or invalid.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `time.Duration`: The value associated with `aKey`.
+func (sl *TSectionList) MustDuration(aSection, aKey string) time.Duration {
+	result, err := sl.GetDuration(aSection, aKey)
+	if nil != err {
+		panic(err)
+	}
+
+	return result
+} // MustDuration()
+
+// `MustInt()` returns the value of `aKey` in `aSection` as an integer
+// panicking if it's missing or invalid.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `int`: The value associated with `aKey`.
+func (sl *TSectionList) MustInt(aSection, aKey string) int {
+	result, err := sl.GetInt(aSection, aKey)
+	if nil != err {
+		panic(err)
+	}
+
+	return result
+} // MustInt()
+
+// `MustString()` returns the value of `aKey` in `aSection` panicking
+// if it's missing.
+//
+// This is meant for keys which are mandatory at startup; see
+// `Require()` to check several keys at once.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+func (sl *TSectionList) MustString(aSection, aKey string) string {
+	result, err := sl.GetString(aSection, aKey)
+	if nil != err {
+		panic(err)
+	}
+
+	return result
+} // MustString()
+
+// `Require()` returns a new requirement to check the list's mandatory
+// keys collecting all errors found:
+//
+//	var port int
+//	err := iniList.Require().
+//		Keys("db", "host", "user").
+//		Int("db", "port", &port).
+//		Err()
+//
+// Returns:
+// - `*TRequirement`: The new requirement.
+func (sl *TSectionList) Require() *TRequirement {
+	return &TRequirement{list: sl}
+} // Require()
+
+/* _EoF_ */
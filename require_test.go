@@ -0,0 +1,102 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepRequireList() *TSectionList {
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "host", "localhost")
+	sl.AddSectionKey("db", "port", "5432")
+	sl.AddSectionKey("db", "debug", "yes")
+	sl.AddSectionKey("db", "timeout", "5s")
+	sl.AddSectionKey("db", "name", "n.a.")
+
+	return sl
+} // prepRequireList()
+
+func TestTSectionList_MustString(t *testing.T) {
+	sl := prepRequireList()
+	if got := sl.MustString("db", "host"); "localhost" != got {
+		t.Errorf("TSectionList.MustString() = %q, want %q", got, "localhost")
+	}
+	if got := sl.MustInt("db", "port"); 5432 != got {
+		t.Errorf("TSectionList.MustInt() = %d, want 5432", got)
+	}
+	if got := sl.MustBool("db", "debug"); !got {
+		t.Error("TSectionList.MustBool() = false, want true")
+	}
+	if got := sl.MustDuration("db", "timeout"); 5*time.Second != got {
+		t.Errorf("TSectionList.MustDuration() = %v, want 5s", got)
+	}
+
+	tests := []struct {
+		name string
+		call func()
+		want error
+	}{
+		{"1", func() { sl.MustString("db", "missing") }, ErrKeyNotFound},
+		{"2", func() { sl.MustInt("db", "name") }, ErrParseValue},
+		{"3", func() { sl.MustBool("none", "debug") }, ErrSectionNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, tt.want) {
+					t.Errorf("%q: TSectionList.MustXxx() panic = %v, want %v",
+						tt.name, err, tt.want)
+				}
+			}()
+			tt.call()
+		})
+	}
+} // TestTSectionList_MustString()
+
+func TestTSectionList_Require(t *testing.T) {
+	sl := prepRequireList()
+
+	var (
+		host    string
+		port    int
+		debug   bool
+		timeout time.Duration
+	)
+	err := sl.Require().
+		Keys("db", "host", "port").
+		String("db", "host", &host).
+		Int("db", "port", &port).
+		Bool("db", "debug", &debug).
+		Duration("db", "timeout", &timeout).
+		Err()
+	if nil != err {
+		t.Fatalf("TSectionList.Require() error = %v", err)
+	}
+	if ("localhost" != host) || (5432 != port) || !debug || (5*time.Second != timeout) {
+		t.Errorf("TSectionList.Require() = %q, %d, %v, %v", host, port, debug, timeout)
+	}
+
+	err = sl.Require().
+		Keys("db", "host", "user").
+		Int("db", "name", nil).
+		String("cache", "dir", nil).
+		Err()
+	for _, want := range []error{ErrKeyNotFound, ErrParseValue, ErrSectionNotFound} {
+		if !errors.Is(err, want) {
+			t.Errorf("TSectionList.Require() error = %v, want %v", err, want)
+		}
+	}
+} // TestTSectionList_Require()
+
+/* _EoF_ */
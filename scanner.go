@@ -0,0 +1,169 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tLineHandler` receives the logical lines assembled by `scanLines()`.
+	tLineHandler struct {
+		// called for each (trimmed) blank or comment line
+		comment func(aLine string, aLineNum int) error
+
+		// called for each complete (possibly concatenated) line
+		line func(aLine string, aLineNum int) error
+
+		// called for each triple-quoted value (`done` is `false`
+		// for a block not terminated at the end of the input)
+		block func(aBlock *tValueBlock) error
+
+		// whether comment lines within a backslash concatenation
+		// are skipped instead of ending it
+		skipContComments bool
+	}
+)
+
+// `scanLines()` reads the INI data line by line assembling the logical
+// lines which are passed to `aHandler`.
+//
+// Values may span several lines by a trailing backslash, by triple
+// quotes, or (if `aIndentCont` is `true`) by indented follow-up lines.
+//
+// Parameters:
+// - `aScanner`: The scanner to read from.
+// - `aIndentCont`: Whether indented lines continue a value.
+// - `aHandler`: The functions to call with the lines read.
+//
+// Returns:
+// - `int`: The number of bytes read.
+// - `error`: A handler's error or the scanner's error.
+func scanLines(aScanner *bufio.Scanner, aIndentCont bool, aHandler tLineHandler) (rRead int, rErr error) {
+	var (
+		block    *tValueBlock // a triple-quoted value being read
+		lastLine string
+		lineNum  int    // number of the current line
+		pending  string // a key/value line which may be continued
+		pendNum  int    // line number of `pending`
+		startNum int    // number of a concatenation's first line
+	)
+
+	// handle a key/value line waiting for indented continuation lines:
+	flush := func() error {
+		if "" == pending {
+			return nil
+		}
+		line := pending
+		pending = ""
+
+		return aHandler.line(line, pendNum)
+	}
+
+	for lineRead := aScanner.Scan(); lineRead; lineRead = aScanner.Scan() {
+		raw := aScanner.Text()
+		rRead += len(raw) + 1 // add trailing LF
+		lineNum++
+
+		if nil != block {
+			if block.add(raw) {
+				if rErr = aHandler.block(block); nil != rErr {
+					return
+				}
+				block = nil
+			}
+			continue
+		}
+
+		line := strings.TrimSpace(raw)
+		lineLen := len(line)
+		if (0 == lineLen) || (';' == line[0]) || ('#' == line[0]) {
+			if aHandler.skipContComments && ("" != lastLine) && (0 < lineLen) {
+				continue // a comment within a concatenation
+			}
+			if rErr = flush(); nil != rErr {
+				return
+			}
+			if "" != lastLine {
+				// blank and comment lines end a value concatenation
+				if rErr = aHandler.line(lastLine, startNum); nil != rErr {
+					return
+				}
+				lastLine = ""
+			}
+			if rErr = aHandler.comment(line, lineNum); nil != rErr {
+				return
+			}
+			continue
+		}
+
+		if ("" != pending) && ("" == lastLine) && isIndented(raw) {
+			pending += "\n" + line // indentation continuation
+			continue
+		}
+		if rErr = flush(); nil != rErr {
+			return
+		}
+
+		if "" == lastLine {
+			if block = newValueBlock(line, lineNum); nil != block {
+				if block.done { // a single line block
+					if rErr = aHandler.block(block); nil != rErr {
+						return
+					}
+					block = nil
+				}
+				continue
+			}
+		}
+
+		if '\\' == line[lineLen-1] { // possible value concatenation
+			if "" == lastLine {
+				startNum = lineNum
+			}
+			if (1 < lineLen) && (' ' == line[lineLen-2]) {
+				lastLine += line[:lineLen-1]
+			} else {
+				lastLine += line[:lineLen-1] + " "
+			}
+			continue // concatenation handled
+		}
+		num := lineNum
+		if 0 < len(lastLine) {
+			line, lastLine, num = lastLine+line, "", startNum
+		}
+
+		if aIndentCont && !isSectionRE.MatchString(line) {
+			pending, pendNum = line, num
+			continue
+		}
+		if rErr = aHandler.line(line, num); nil != rErr {
+			return
+		}
+	}
+	if "" != lastLine {
+		if rErr = aHandler.line(lastLine, startNum); nil != rErr {
+			return
+		}
+	}
+	if rErr = flush(); nil != rErr {
+		return
+	}
+	if nil != block {
+		if rErr = aHandler.block(block); nil != rErr {
+			return
+		}
+	}
+	rErr = aScanner.Err()
+
+	return
+} // scanLines()
+
+/* _EoF_ */
@@ -0,0 +1,192 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TSecretResolver` returns the secret referenced by `aRef`
+	// (i.e. the part of a value following the scheme's colon).
+	TSecretResolver func(aRef string) (string, error)
+
+	// `tResolvers` maps URI-like schemes to their secret resolvers.
+	tResolvers map[string]TSecretResolver
+)
+
+var (
+	// `ErrSecretRef` is returned if a secret reference can't be resolved.
+	ErrSecretRef = errors.New("ini: can't resolve secret reference")
+)
+
+// `EnvResolver()` returns the value of the environment variable `aRef`
+// (e.g. `password = env:DB_PASS`).
+//
+// Parameters:
+// - `aRef` The name of the environment variable.
+//
+// Returns:
+// - `string`: The variable's value.
+// - `error`: An error if the variable isn't set.
+func EnvResolver(aRef string) (string, error) {
+	aRef = strings.TrimSpace(aRef)
+	if value, ok := os.LookupEnv(aRef); ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("environment variable %q not set", aRef)
+} // EnvResolver()
+
+// `ExecResolver()` returns the output of the command `aRef`
+// (e.g. `password = exec:pass show db`).
+//
+// The command line is split at white space (no shell is involved)
+// and trailing line breaks of the output are removed.
+//
+// Parameters:
+// - `aRef` The command line to run.
+//
+// Returns:
+// - `string`: The command's output.
+// - `error`: A possible error running the command.
+func ExecResolver(aRef string) (string, error) {
+	args := strings.Fields(aRef)
+	if 0 == len(args) {
+		return "", errors.New("empty command")
+	}
+
+	output, err := exec.Command(args[0], args[1:]...).Output()
+	if nil != err {
+		return "", err
+	}
+
+	return strings.TrimRight(string(output), "\r\n"), nil
+} // ExecResolver()
+
+// `FileResolver()` returns the contents of the file `aRef`
+// (e.g. `password = file:/run/secrets/db`).
+//
+// Trailing line breaks of the file's contents are removed.
+//
+// Parameters:
+// - `aRef` The name of the file to read.
+//
+// Returns:
+// - `string`: The file's contents.
+// - `error`: A possible error reading the file.
+func FileResolver(aRef string) (string, error) {
+	data, err := os.ReadFile(strings.TrimSpace(aRef))
+	if nil != err {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+} // FileResolver()
+
+// --------------------------------------------------------------------------
+
+// `resolveSecret()` returns the secret referenced by `aValue`.
+//
+// Values without a registered scheme are returned unchanged.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The value to resolve.
+//
+// Returns:
+// - `string`: The resolved value.
+// - `error`: A wrapped `ErrSecretRef` if the resolver failed.
+func (sl *TSectionList) resolveSecret(aSection, aKey, aValue string) (string, error) {
+	if 0 == len(sl.resolvers) {
+		return aValue, nil
+	}
+	scheme, ref, found := strings.Cut(aValue, ":")
+	if !found {
+		return aValue, nil
+	}
+	resolver, exists := sl.resolvers[strings.ToLower(scheme)]
+	if !exists {
+		return aValue, nil
+	}
+
+	value, err := resolver(ref)
+	if nil != err {
+		return "", fmt.Errorf("[%s] %s: %w: %w", aSection, aKey, ErrSecretRef, err)
+	}
+
+	return value, nil
+} // resolveSecret()
+
+// `SetResolveSecrets()` determines whether values referring to secrets
+// by the `env:` and `file:` schemes are resolved when accessed.
+//
+// With this option enabled the `AsXxx()` and `GetXxx()` methods return
+// e.g. the value of the environment variable `DB_PASS` for a value of
+// `env:DB_PASS` while the INI data itself keeps the reference.
+// Running commands by the `exec:` scheme has to be enabled explicitly
+// by `SetSecretResolver("exec", ini.ExecResolver)`.
+//
+// Parameters:
+// - `aResolve` Whether to resolve secret references.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetResolveSecrets(aResolve bool) *TSectionList {
+	if !aResolve {
+		sl.resolvers = nil
+		return sl
+	}
+
+	return sl.SetSecretResolver(`env`, EnvResolver).
+		SetSecretResolver(`file`, FileResolver)
+} // SetResolveSecrets()
+
+// `SetSecretResolver()` registers `aResolver` for values starting
+// with `aScheme` followed by a colon.
+//
+// The resolver is called lazily whenever such a value is accessed by
+// one of the `AsXxx()` or `GetXxx()` methods.
+//
+// Example:
+//
+//	sl.SetSecretResolver("vault", func(aRef string) (string, error) {
+//		return vaultClient.Read(aRef)
+//	})
+//
+// Parameters:
+// - `aScheme` The (case-insensitive) scheme's name.
+// - `aResolver` The resolver to use (`nil` removes the scheme).
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetSecretResolver(aScheme string, aResolver TSecretResolver) *TSectionList {
+	aScheme = strings.ToLower(strings.TrimSpace(aScheme))
+	if "" == aScheme {
+		return sl
+	}
+
+	if nil == aResolver {
+		delete(sl.resolvers, aScheme)
+		return sl
+	}
+	if nil == sl.resolvers {
+		sl.resolvers = make(tResolvers)
+	}
+	sl.resolvers[aScheme] = aResolver
+
+	return sl
+} // SetSecretResolver()
+
+/* _EoF_ */
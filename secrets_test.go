@@ -0,0 +1,71 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_SetResolveSecrets(t *testing.T) {
+	t.Setenv("INI_TEST_SECRET", "fromEnv")
+	fName := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(fName, []byte("fromFile\n"), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	sl := NewSectionList().SetResolveSecrets(true).
+		SetSecretResolver("Const", func(aRef string) (string, error) {
+			return "const-" + aRef, nil
+		})
+	sl.AddSectionKey("", "env", "env:INI_TEST_SECRET")
+	sl.AddSectionKey("", "file", "file:"+fName)
+	sl.AddSectionKey("", "const", "const:42")
+	sl.AddSectionKey("", "url", "http://example.com/")
+	sl.AddSectionKey("", "missing", "env:INI_TEST_NOT_SET")
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr error
+	}{
+		{"env", "fromEnv", nil},
+		{"file", "fromFile", nil},
+		{"const", "const-42", nil},
+		{"url", "http://example.com/", nil},
+		{"missing", "", ErrSecretRef},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := sl.GetString("", tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// the references are kept in the INI data
+	if _, raw, _ := sl.rawValue("", "env"); "env:INI_TEST_SECRET" != raw {
+		t.Errorf("rawValue() = %q, want the reference", raw)
+	}
+
+	sl.SetResolveSecrets(false)
+	if got, _ := sl.AsString("", "env"); "env:INI_TEST_SECRET" != got {
+		t.Errorf("AsString() = %q, want the reference", got)
+	}
+} // TestTSectionList_SetResolveSecrets()
+
+/* _EoF_ */
@@ -7,11 +7,14 @@ Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
 package ini
 
 import (
+	"encoding"
+	"encoding/base64"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -22,18 +25,21 @@ const (
 )
 
 type (
-	// `tKeyVal` represents an key/value pair.
-	tKeyVal struct {
+	// `TKeyVal` represents a key/value pair.
+	TKeyVal struct {
 		Key   string
 		Value string
 	}
 	// a list of key/value pairs
-	tKeyValList []tKeyVal
+	tKeyValList []TKeyVal
 
 	// `TSection` is a slice of sorted key/value pairs.
 	TSection struct {
-		data tKeyValList
-		mtx  sync.RWMutex
+		comments map[string][]string // comments preceding the keys
+		data     tKeyValList
+		edits    uint64    // number of modifications
+		index    tKeyIndex // positions of the keys in `data`
+		mtx      sync.RWMutex
 	}
 
 	// `TSectionWalkFunc()` is used by `Walk()` when visiting the entries
@@ -118,6 +124,8 @@ func (kvl tKeyValList) hasKey(aKey string) bool {
 // `insert()` inserts a new key/value pair returning `true` on success or
 // `false` otherwise.
 //
+// An existing key's value is updated in place while a new key is
+// appended to the list, thus preserving the order the keys were added.
 // If `aKey` is an empty string the method's result will be `false`.
 //
 // Parameters:
@@ -125,31 +133,25 @@ func (kvl tKeyValList) hasKey(aKey string) bool {
 //
 // Returns:
 // - `bool`: `true` if `aKeyVal` was added successfully, `false` otherwise.
-func (kvl *tKeyValList) insert(aKeyVal tKeyVal) bool {
+func (kvl *tKeyValList) insert(aKeyVal TKeyVal) bool {
 	if aKeyVal.Key = strings.TrimSpace(aKeyVal.Key); "" == aKeyVal.Key {
 		return false
 	}
 
-	sLen := len(*kvl)
-	idx := sort.Search(sLen, func(i int) bool {
-		return (*kvl)[i].Key >= aKeyVal.Key
-	})
-
-	if sLen == idx { // key not found
-		*kvl = append(*kvl, tKeyVal{})
-		copy((*kvl)[idx+1:], (*kvl)[idx:])
-	} else if (*kvl)[idx].Key != aKeyVal.Key { // it's a new key
-		*kvl = append(*kvl, tKeyVal{})
-		copy((*kvl)[idx+1:], (*kvl)[idx:])
+	for idx, entry := range *kvl {
+		if aKeyVal.Key == entry.Key {
+			(*kvl)[idx].Value = aKeyVal.Value // update the value
+			return true
+		}
 	}
-	(*kvl)[idx] = aKeyVal // update the vale
+	*kvl = append(*kvl, aKeyVal) // it's a new key
 
 	return true
 } // insert()
 
 func (kvl *tKeyValList) merge(aList *tKeyValList) *tKeyValList {
 	for _, kv := range *aList {
-		kvl.insert(tKeyVal{kv.Key, kv.Value})
+		kvl.insert(TKeyVal{kv.Key, kv.Value})
 	}
 
 	return kvl
@@ -173,22 +175,41 @@ func (kvl *tKeyValList) remove(aKey string) bool {
 	return false
 } // remove()
 
+// `size()` returns the estimated number of bytes needed for the
+// string representation of the list.
+//
+// Returns:
+// - `int`: The estimated size of `String()`'s result.
+func (kvl tKeyValList) size() (rSize int) {
+	for _, kv := range kvl {
+		rSize += len(kv.Key) + len(kv.Value) + 4 // " = " and "\n"
+	}
+
+	return
+} // size()
+
 // `String()` returns a string representation of the whole INI section.
 //
 // The single key/value pairs are delimited by a linefeed ('\n).
 //
 // Returns:
 // - `string`: The string representation of the current section.
-func (kvl tKeyValList) String() (rString string) {
+func (kvl tKeyValList) String() string {
+	var sb strings.Builder
+	sb.Grow(kvl.size())
+
 	for _, kv := range kvl {
+		sb.WriteString(kv.Key)
 		if "" == kv.Value {
-			rString += kv.Key + " =\n"
+			sb.WriteString(" =\n")
 		} else {
-			rString += kv.Key + " = " + kv.Value + "\n"
+			sb.WriteString(" = ")
+			sb.WriteString(blockValue(kv.Value))
+			sb.WriteByte('\n')
 		}
 	}
 
-	return
+	return sb.String()
 } // String()
 
 // `value()` returns the value of `aKey` as a string.
@@ -212,6 +233,133 @@ func (kvl tKeyValList) value(aKey string) (string, bool) {
 	return "", false
 } // value()
 
+// `sortedKeys()` returns the keys of `aMap` in sorted order.
+//
+// Parameters:
+// - `aMap` The map whose keys to return.
+//
+// Returns:
+// - `[]string`: The sorted keys of `aMap`.
+func sortedKeys(aMap map[string]string) []string {
+	result := make([]string, 0, len(aMap))
+	for key := range aMap {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+
+	return result
+} // sortedKeys()
+
+// `parseBool()` interprets `aValue` as a boolean value.
+//
+// `0`, `f`, `F`, `n`, and `N` are considered `false` while
+// `1`, `t`, `T`, `y`, `Y`, `j`, `J`, `o`, `O` are considered `true`.
+// Only the first character of `aValue` is checked.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `bool`: The boolean value represented by `aValue`.
+// - `bool`: `true` if `aValue` is a valid boolean, `false` otherwise.
+func parseBool(aValue string) (bool, bool) {
+	aValue += "\t" // in case of empty string: default FALSE
+	// Since all values are TRIMed there can never be a TAB at the start.
+
+	switch aValue[:1] {
+	case `0`, `f`, `F`, `n`, `N`:
+		return false, true
+
+	case `1`, `t`, `T`, `y`, `Y`, `j`, `J`, `o`, `O`:
+		// True, Yes (English), Ja (German), Oui (French)`
+		return true, true
+	}
+
+	return false, false
+} // parseBool()
+
+// `parseBytes()` decodes the base64 encoded `aValue`.
+//
+// Both the standard and the URL alphabet are accepted, with or
+// without padding.
+//
+// Parameters:
+// - `aValue` The string to decode.
+//
+// Returns:
+// - `[]byte`: The decoded data.
+// - `error`: A possible decoding error.
+func parseBytes(aValue string) ([]byte, error) {
+	var (
+		err    error
+		result []byte
+	)
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding, base64.RawStdEncoding,
+		base64.URLEncoding, base64.RawURLEncoding,
+	} {
+		if result, err = enc.DecodeString(aValue); nil == err {
+			return result, nil
+		}
+	}
+
+	return nil, err
+} // parseBytes()
+
+// `parseTime()` interprets `aValue` as a time value.
+//
+// The `time.RFC3339` layout is tried first, then the given `aLayouts`
+// in turn.
+//
+// Parameters:
+// - `aValue` The string to parse.
+// - `aLayouts` Additional layouts to try.
+//
+// Returns:
+// - `time.Time`: The time represented by `aValue`.
+// - `error`: The error of the last failed parsing attempt, or `nil`.
+func parseTime(aValue string, aLayouts []string) (time.Time, error) {
+	result, err := time.Parse(time.RFC3339, aValue)
+	for _, layout := range aLayouts {
+		if nil == err {
+			break
+		}
+		result, err = time.Parse(layout, aValue)
+	}
+
+	return result, err
+} // parseTime()
+
+// `parsePercent()` interprets `aValue` as a percentage returning
+// a fraction between `0.0` and `1.0`.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `float64`: The fraction represented by `aValue`.
+// - `bool`: `true` if `aValue` is a valid percentage, `false` otherwise.
+func parsePercent(aValue string) (float64, bool) {
+	isPercent := strings.HasSuffix(aValue, `%`)
+	if isPercent {
+		aValue = strings.TrimSpace(aValue[:len(aValue)-1])
+	}
+
+	f64, err := strconv.ParseFloat(aValue, 64)
+	if (nil != err) || (f64 != f64) {
+		// for NaN the inequality comparison with itself returns true
+		return float64(0.0), false
+	}
+	if isPercent || (1.0 < f64) {
+		f64 /= 100.0
+	}
+	if (0.0 > f64) || (1.0 < f64) {
+		return float64(0.0), false
+	}
+
+	return f64, true
+} // parsePercent()
+
 // --------------------------------------------------------------------------
 
 // `AddKey()` appends a new key/value pair returning `true` on success or
@@ -229,12 +377,12 @@ func (kl *TSection) AddKey(aKey, aValue string) bool {
 	if aKey = strings.TrimSpace(aKey); "" == aKey {
 		return false
 	}
-	kv := tKeyVal{aKey, strings.TrimSpace(aValue)}
+	kv := TKeyVal{aKey, strings.TrimSpace(aValue)}
 
 	kl.mtx.Lock()
 	defer kl.mtx.Unlock()
 
-	return kl.data.insert(kv)
+	return kl.insert(kv)
 } // AddKey()
 
 // Bool
@@ -267,22 +415,75 @@ func (kl *TSection) AsBool(aKey string) (bool, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
-		value += "\t" // in case of empty string: default FALSE
-		// Since all values are TRIMed there can never be a TAB at the start.
+	if value, exists := kl.value(aKey); exists {
+		return parseBool(value)
+	}
+
+	return false, false
+} // AsBool()
 
-		switch value[:1] {
-		case `0`, `f`, `F`, `n`, `N`:
-			return false, true
+// Bytes
 
-		case `1`, `t`, `T`, `y`, `Y`, `j`, `J`, `o`, `O`:
-			// True, Yes (English), Ja (German), Oui (French)`
-			return true, true
+// `AsBytes()` returns the base64 decoded value of `aKey`.
+//
+// If the given `aKey` doesn't exist or its value isn't valid base64
+// data then the second return value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `[]byte`: The decoded value of `aKey`.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsBytes(aKey string) ([]byte, bool) {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return nil, false
+	}
+
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	if value, exists := kl.value(aKey); exists {
+		if result, err := parseBytes(value); nil == err {
+			return result, true
 		}
 	}
 
-	return false, false
-} // AsBool()
+	return nil, false
+} // AsBytes()
+
+// Duration
+
+// `AsDuration()` returns the value of `aKey` as a time duration.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid
+// duration then the second return value will be `false`.
+//
+// The value is parsed by `time.ParseDuration()` so it may look like
+// e.g. `30s`, `5m`, or `1h30m`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `time.Duration`: The value of `aKey` as a duration.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsDuration(aKey string) (time.Duration, bool) {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return time.Duration(0), false
+	}
+
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	if value, exists := kl.value(aKey); exists {
+		if d, err := time.ParseDuration(value); nil == err {
+			return d, true
+		}
+	}
+
+	return time.Duration(0), false
+} // AsDuration()
 
 // Float
 
@@ -309,7 +510,7 @@ func (kl *TSection) AsFloat32(aKey string) (float32, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if f64, err := strconv.ParseFloat(value, 32); (nil == err) && (f64 == f64) {
 			// for NaN the inequality comparison with itself returns true
 			return float32(f64), true
@@ -342,7 +543,7 @@ func (kl *TSection) AsFloat64(aKey string) (float64, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if f64, err := strconv.ParseFloat(value, 64); (nil == err) && (f64 == f64) {
 			// for NaN the inequality comparison with itself returns true
 			return f64, true
@@ -373,7 +574,7 @@ func (kl *TSection) AsInt(aKey string) (int, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if i64, err := strconv.ParseInt(value, 10, 0); nil == err {
 			return int(i64), true
 		}
@@ -401,7 +602,7 @@ func (kl *TSection) AsInt8(aKey string) (int8, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if i64, err := strconv.ParseInt(value, 10, 8); nil == err {
 			return int8(i64), true
 		}
@@ -429,7 +630,7 @@ func (kl *TSection) AsInt16(aKey string) (int16, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if i64, err := strconv.ParseInt(value, 10, 16); nil == err {
 			return int16(i64), true
 		}
@@ -457,7 +658,7 @@ func (kl *TSection) AsInt32(aKey string) (int32, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if i64, err := strconv.ParseInt(value, 10, 32); nil == err {
 			return int32(i64), true
 		}
@@ -485,7 +686,7 @@ func (kl *TSection) AsInt64(aKey string) (int64, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if i64, err := strconv.ParseInt(value, 10, 64); nil == err {
 			return i64, true
 		}
@@ -494,6 +695,65 @@ func (kl *TSection) AsInt64(aKey string) (int64, bool) {
 	return int64(0), false
 } // AsInt64()
 
+// Percent
+
+// `AsMap()` returns a map of the section's keys and their values.
+//
+// This allows to hand the section's data to e.g. template engines;
+// see `KeyVals()` for an ordered list.
+//
+// Returns:
+// - `map[string]string`: The section's key/value pairs.
+func (kl *TSection) AsMap() map[string]string {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	result := make(map[string]string, len(kl.data))
+	for _, kv := range kl.data {
+		result[kv.Key] = kv.Value
+	}
+
+	return result
+} // AsMap()
+
+// `AsPercent()` returns the value of `aKey` as a fraction between
+// `0.0` and `1.0`.
+//
+// If the given `aKey` doesn't exist or its value can't be interpreted
+// as a percentage then the second return value will be `false`.
+//
+// The value is interpreted according to the following rules:
+//
+//	(1) a trailing `%` marks a percentage, i.e. `75%` gives `0.75`;
+//	(2) a plain number between `0` and `1` is taken as a fraction,
+//	    i.e. `0.75` gives `0.75` (and `1` gives `1.0`);
+//	(3) any other plain number is taken as a percentage,
+//	    i.e. `75` gives `0.75`.
+//
+// Values resulting in a fraction outside the `0.0` to `1.0` range are
+// rejected.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float64`: The value of `aKey` as a fraction.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsPercent(aKey string) (float64, bool) {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return float64(0.0), false
+	}
+
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	if value, exists := kl.value(aKey); exists {
+		return parsePercent(value)
+	}
+
+	return float64(0.0), false
+} // AsPercent()
+
 // String
 
 // `AsString()` returns the value of `aKey` as a string.
@@ -515,13 +775,68 @@ func (kl *TSection) AsString(aKey string) (string, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		return value, true
 	}
 
 	return "", false
 } // AsString()
 
+// `AsTextUnmarshaler()` lets `aValue` parse the value of `aKey`.
+//
+// This allows types like `net.IP`, `time.Time`, or custom enums
+// implementing the `encoding.TextUnmarshaler` interface to be read
+// directly from the INI section.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+// - `aValue` The target to unmarshal the key's value into.
+//
+// Returns:
+// - `error`: `ErrKeyNotFound` or the error returned by `aValue`.
+func (kl *TSection) AsTextUnmarshaler(aKey string, aValue encoding.TextUnmarshaler) error {
+	value, ok := kl.AsString(aKey)
+	if !ok {
+		return fmt.Errorf("%s: %w", aKey, ErrKeyNotFound)
+	}
+
+	return aValue.UnmarshalText([]byte(value))
+} // AsTextUnmarshaler()
+
+// Time
+
+// `AsTime()` returns the value of `aKey` as a time value.
+//
+// The value is parsed using the `time.RFC3339` layout first and then
+// the given `aLayouts` in turn until one of them matches.
+//
+// If the given `aKey` doesn't exist or its value matches none of the
+// layouts then the second return value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+// - `aLayouts` Optional additional layouts to try (see `time.Parse()`).
+//
+// Returns:
+// - `time.Time`: The value of `aKey` as a time value.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsTime(aKey string, aLayouts ...string) (time.Time, bool) {
+	if aKey = strings.TrimSpace(aKey); "" == aKey {
+		return time.Time{}, false
+	}
+
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	if value, exists := kl.value(aKey); exists {
+		if t, err := parseTime(value, aLayouts); nil == err {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+} // AsTime()
+
 // UInt
 
 // `AsUInt()` returns the value of `aKey` as an unsigned integer.
@@ -543,7 +858,7 @@ func (kl *TSection) AsUInt(aKey string) (uint, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if ui64, err := strconv.ParseUint(value, 10, 0); nil == err {
 			return uint(ui64), true
 		}
@@ -571,7 +886,7 @@ func (kl *TSection) AsUInt8(aKey string) (uint8, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if ui64, err := strconv.ParseUint(value, 10, 8); nil == err {
 			return uint8(ui64), true
 		}
@@ -599,7 +914,7 @@ func (kl *TSection) AsUInt16(aKey string) (uint16, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if ui64, err := strconv.ParseUint(value, 10, 16); nil == err {
 			return uint16(ui64), true
 		}
@@ -627,7 +942,7 @@ func (kl *TSection) AsUInt32(aKey string) (uint32, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if ui64, err := strconv.ParseUint(value, 10, 32); nil == err {
 			return uint32(ui64), true
 		}
@@ -655,7 +970,7 @@ func (kl *TSection) AsUInt64(aKey string) (uint64, bool) {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	if value, exists := kl.data.value(aKey); exists {
+	if value, exists := kl.value(aKey); exists {
 		if ui64, err := strconv.ParseUint(value, 10, 64); nil == err {
 			return ui64, true
 		}
@@ -681,6 +996,9 @@ func (kl *TSection) Clear() *TSection {
 
 	// replace the current list by fresh/empty one
 	kl.data = make(tKeyValList, 0, kvDefCapacity)
+	kl.index = nil
+	kl.comments = nil
+	kl.edits++
 
 	return kl
 } // Clear()
@@ -720,6 +1038,10 @@ func (kl *TSection) Copy() (rSection *TSection) {
 
 	kvl := kl.data.copy()
 	rSection.data = *kvl
+	rSection.reindex()
+	for key, lines := range kl.comments {
+		rSection.setComment(key, lines)
+	}
 
 	return
 } // Copy()
@@ -738,21 +1060,59 @@ func (kl *TSection) HasKey(aKey string) bool {
 	kl.mtx.RLock()
 	defer kl.mtx.RUnlock()
 
-	return kl.data.hasKey(aKey)
+	_, ok := kl.position(aKey)
+
+	return ok
 } // HasKey()
 
+// `Keys()` returns the section's keys in the order they appear in
+// the INI file.
+//
+// Returns:
+// - `[]string`: A list of the section's keys.
+func (kl *TSection) Keys() []string {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	result := make([]string, len(kl.data))
+	for idx, kv := range kl.data {
+		result[idx] = kv.Key
+	}
+
+	return result
+} // Keys()
+
+// `KeyVals()` returns a copy of the section's key/value pairs in the
+// order they appear in the INI file.
+//
+// Returns:
+// - `[]TKeyVal`: A list of the section's key/value pairs.
+func (kl *TSection) KeyVals() []TKeyVal {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	result := make([]TKeyVal, len(kl.data))
+	copy(result, kl.data)
+
+	return result
+} // KeyVals()
+
 // `Len()` counts the number of key/value pairs in this section.
 //
 // Returns:
 // - `int`: The number of key/value pairs in this section.
 func (kl *TSection) Len() int {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
 	return len(kl.data)
 } // Len()
 
 // `Merge()` merges all section key/value pairs into this section.
 //
 // The method adds all non-existing key/value pairs from `aSection` to this
-// section and updates all existing keys with the values from `aSection`
+// section and updates all existing keys with the values from `aSection`;
+// see `MergeWith()` and `MergeFunc()` for other strategies.
 //
 // Parameters:
 // - `aSection`: The INI section to merge with this section.
@@ -760,16 +1120,7 @@ func (kl *TSection) Len() int {
 // Returns:
 // - `TSection`: This section added/updated from `aSection`.
 func (kl *TSection) Merge(aSection *TSection) *TSection {
-	if nil == aSection {
-		return kl
-	}
-	kl.mtx.RLock()
-	defer kl.mtx.RUnlock()
-
-	merged := kl.data.merge(&aSection.data)
-	kl.data = *merged
-
-	return kl
+	return kl.MergeFunc(aSection, nil)
 } // Merge()
 
 // `RemoveKey()` removes `aKey` from this section.
@@ -787,6 +1138,8 @@ func (kl *TSection) RemoveKey(aKey string) bool {
 	defer kl.mtx.Unlock()
 
 	if kl.data.remove(aKey) {
+		kl.reindex()
+		delete(kl.comments, aKey)
 		return true
 	}
 
@@ -794,6 +1147,86 @@ func (kl *TSection) RemoveKey(aKey string) bool {
 	return true
 } // RemoveKey()
 
+// `RenameKey()` renames `aOldKey` to `aNewKey` keeping its value,
+// comments, and position within the section.
+//
+// Parameters:
+// - `aOldKey` The current name of the key.
+// - `aNewKey` The new name of the key.
+//
+// Returns:
+// - `bool`: `true` if the key was renamed, `false` if `aOldKey`
+// doesn't exist or `aNewKey` is empty or already exists.
+func (kl *TSection) RenameKey(aOldKey, aNewKey string) bool {
+	aOldKey, aNewKey = strings.TrimSpace(aOldKey), strings.TrimSpace(aNewKey)
+	if ("" == aOldKey) || ("" == aNewKey) {
+		return false
+	}
+
+	kl.mtx.Lock()
+	defer kl.mtx.Unlock()
+
+	idx, ok := kl.position(aOldKey)
+	if !ok {
+		return false
+	}
+	if aOldKey == aNewKey {
+		return true
+	}
+	if _, exists := kl.position(aNewKey); exists {
+		return false
+	}
+
+	kl.data[idx].Key = aNewKey
+	kl.reindex()
+	if lines, ok := kl.comments[aOldKey]; ok {
+		delete(kl.comments, aOldKey)
+		kl.comments[aNewKey] = lines
+	}
+
+	return true
+} // RenameKey()
+
+// `setComment()` attaches the given comment lines to `aKey`.
+//
+// Parameters:
+// - `aKey` The name of the key to use.
+// - `aComments` The comment lines preceding the key/value pair.
+func (kl *TSection) setComment(aKey string, aComments []string) {
+	if aComments = trimComments(aComments); nil == aComments {
+		return
+	}
+	if nil == kl.comments {
+		kl.comments = make(map[string][]string)
+	}
+	kl.comments[aKey] = append([]string(nil), aComments...)
+	kl.edits++
+} // setComment()
+
+// `SetMany()` adds or updates all key/value pairs of `aPairs`
+// acquiring the section's lock only once.
+//
+// The keys are set in sorted order; empty keys are ignored.
+//
+// Parameters:
+// - `aPairs` The key/value pairs to set.
+//
+// Returns:
+// - `map[string]bool`: Whether each of the given keys was set.
+func (kl *TSection) SetMany(aPairs map[string]string) map[string]bool {
+	result := make(map[string]bool, len(aPairs))
+
+	kl.mtx.Lock()
+	defer kl.mtx.Unlock()
+
+	for _, key := range sortedKeys(aPairs) {
+		kv := TKeyVal{strings.TrimSpace(key), strings.TrimSpace(aPairs[key])}
+		result[key] = ("" != kv.Key) && kl.insert(kv)
+	}
+
+	return result
+} // SetMany()
+
 // `Sort()` sorts the key/value pairs in the section alphabetically by key.
 //
 // The original map is replaced with the new sorted map.
@@ -812,21 +1245,24 @@ func (kl *TSection) Sort() *TSection {
 	sort.Slice(kl.data, func(i, j int) bool {
 		return kl.data[i].Key < kl.data[j].Key
 	})
+	kl.reindex()
 
 	return kl
 } // Sort()
 
 // `String()` returns a string representation of the whole INI section.
 //
-// The single key/value pairs are delimited by a linefeed ('\n).
+// The single key/value pairs are delimited by a linefeed ('\n) and
+// emitted in the order they were read or added.
+// Comments attached to a key are emitted in front of that key.
 //
 // Returns:
 // - `string`: The string representation of the current section.
-func (kl *TSection) String() (rString string) {
-	kl.mtx.RLock()
-	defer kl.mtx.RUnlock()
+func (kl *TSection) String() string {
+	var sb strings.Builder
+	kl.format(&sb, &TFormatOptions{}, DialectDefault)
 
-	return kl.data.String()
+	return sb.String()
 } // String()
 
 // `UpdateKey()` replaces the current value of `aKey` by the provided
@@ -873,6 +1309,35 @@ func (kl *TSection) UpdateKeyBool(aKey string, aValue bool) bool {
 	return kl.UpdateKey(aKey, `false`)
 } // UpdateKeyBool()
 
+// `UpdateKeyBytes()` replaces the current value of `aKey` by the
+// base64 encoded `aValue`.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The binary data to store.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeyBytes(aKey string, aValue []byte) bool {
+	return kl.UpdateKey(aKey, base64.StdEncoding.EncodeToString(aValue))
+} // UpdateKeyBytes()
+
+// `UpdateKeyDuration()` replaces the current value of `aKey`
+// by the provided new `aValue` duration.
+//
+// The duration is stored in the format produced by
+// `time.Duration.String()`, e.g. `1h30m0s`.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The duration value of the key/value pair to update.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeyDuration(aKey string, aValue time.Duration) bool {
+	return kl.UpdateKey(aKey, aValue.String())
+} // UpdateKeyDuration()
+
 // `UpdateKeyFloat()` replaces the current value of `aKey`
 // by the provided new `aValue` float.
 //
@@ -899,6 +1364,21 @@ func (kl *TSection) UpdateKeyInt(aKey string, aValue int64) bool {
 	return kl.UpdateKey(aKey, fmt.Sprintf("%d", aValue))
 } // UpdateKeyInt()
 
+// `UpdateKeyTime()` replaces the current value of `aKey`
+// by the provided new `aValue` time.
+//
+// The time is stored using the `time.RFC3339` layout.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The time value of the key/value pair to update.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeyTime(aKey string, aValue time.Time) bool {
+	return kl.UpdateKey(aKey, aValue.Format(time.RFC3339))
+} // UpdateKeyTime()
+
 // `UpdateKeyUInt()` replaces the current value of `aKey`
 // by the provided new `aValue` unsigned integer.
 //
@@ -928,10 +1408,14 @@ func (kl *TSection) UpdateKeyStr(aKey, aValue string) bool {
 // `Walk()` traverses through all entries in the section calling
 // `aFunc` for each entry.
 //
+// The entries visited are a snapshot taken when the method is called,
+// so `aFunc` may safely modify the section (and other goroutines may
+// do so concurrently) without affecting the traversal.
+//
 // Parameters:
 // - `aFunc` The function called for each key/value pair in the sections.
 func (kl *TSection) Walk(aFunc TSectionWalkFunc) {
-	for _, kv := range kl.data {
+	for _, kv := range kl.KeyVals() {
 		aFunc(kv.Key, kv.Value)
 	}
 } // Walk()
@@ -945,6 +1429,31 @@ func (kl *TSection) Walker(aWalker TSectionWalker) {
 	kl.Walk(aWalker.Walk)
 } // Walker()
 
+// `WithPrefix()` returns the key/value pairs whose keys start with
+// `aPrefix` in the order they appear in the INI file.
+//
+// This allows to group related keys like `plugin.foo.path` and
+// `plugin.foo.enabled` without using separate sections.
+//
+// Parameters:
+// - `aPrefix` The prefix of the keys to return.
+//
+// Returns:
+// - `[]TKeyVal`: A list of the matching key/value pairs.
+func (kl *TSection) WithPrefix(aPrefix string) []TKeyVal {
+	kl.mtx.RLock()
+	defer kl.mtx.RUnlock()
+
+	var result []TKeyVal
+	for _, kv := range kl.data {
+		if strings.HasPrefix(kv.Key, aPrefix) {
+			result = append(result, kv)
+		}
+	}
+
+	return result
+} // WithPrefix()
+
 // utility function
 
 // `NewSection()` returns a new instance of `TSection`.
@@ -957,4 +1466,24 @@ func NewSection() *TSection {
 	}
 } // NewSection()
 
+// `NewSectionFromMap()` returns a new instance of `TSection` holding
+// the key/value pairs of `aMap`.
+//
+// The keys are added in sorted order so the result doesn't depend
+// on Go's random map iteration; empty keys are ignored.
+//
+// Parameters:
+// - `aMap` The key/value pairs to add.
+//
+// Returns:
+// - `*TSection`: A new instance of `TSection`.
+func NewSectionFromMap(aMap map[string]string) *TSection {
+	result := NewSection()
+	for _, key := range sortedKeys(aMap) {
+		_ = result.AddKey(key, aMap[key])
+	}
+
+	return result
+} // NewSectionFromMap()
+
 /* _EoF_ */
@@ -12,17 +12,18 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
 
 func prepKeyValList() *tKeyValList {
 	kvl := &tKeyValList{
-		tKeyVal{"bool", "b"},
-		tKeyVal{"float", "f"},
-		tKeyVal{"int", "i"},
-		tKeyVal{"key0", "k"},
-		tKeyVal{"uint", "u"},
+		TKeyVal{"bool", "b"},
+		TKeyVal{"float", "f"},
+		TKeyVal{"int", "i"},
+		TKeyVal{"key0", "k"},
+		TKeyVal{"uint", "u"},
 	}
 
 	return kvl
@@ -34,7 +35,7 @@ func Test_tKeyValList_compareTo(t *testing.T) {
 	kv1 := prepKeyValList()
 
 	kv2 := prepKeyValList()
-	_ = kv2.insert(tKeyVal{"key2", "2"})
+	_ = kv2.insert(TKeyVal{"key2", "2"})
 
 	kv3 := prepKeyValList()
 	_ = kv3.remove("key0")
@@ -62,7 +63,7 @@ func Test_tKeyValList_compareTo(t *testing.T) {
 func Test_tKeyValList_copy(t *testing.T) {
 	kv1 := prepKeyValList()
 	kv2 := prepKeyValList()
-	_ = kv2.insert(tKeyVal{"key2", "2"})
+	_ = kv2.insert(TKeyVal{"key2", "2"})
 
 	tests := []struct {
 		name string
@@ -111,13 +112,13 @@ func Test_tKeyValList_insert(t *testing.T) {
 
 	tests := []struct {
 		name string
-		kvl  tKeyVal
+		kvl  TKeyVal
 		want bool
 	}{
-		{"0", tKeyVal{"", "v0"}, false},     // empty key
-		{"1", tKeyVal{"k 1", "v 1"}, true},  // insert
-		{"2", tKeyVal{"int", "1234"}, true}, // update
-		{"3", tKeyVal{"zero", "Z"}, true},   // add
+		{"0", TKeyVal{"", "v0"}, false},     // empty key
+		{"1", TKeyVal{"k 1", "v 1"}, true},  // insert
+		{"2", TKeyVal{"int", "1234"}, true}, // update
+		{"3", TKeyVal{"zero", "Z"}, true},   // add
 		// TODO: Add test cases.
 	}
 	for _, tt := range tests {
@@ -136,10 +137,10 @@ func Test_tKeyValList_merge(t *testing.T) {
 	kv1 := prepKeyValList()
 
 	kv2 := prepKeyValList()
-	_ = kv2.insert(tKeyVal{"key2", "2"})
+	_ = kv2.insert(TKeyVal{"key2", "2"})
 
 	kv3 := prepKeyValList()
-	_ = kv3.insert(tKeyVal{"key3", "3"})
+	_ = kv3.insert(TKeyVal{"key3", "3"})
 
 	tests := []struct {
 		name  string
@@ -255,6 +256,29 @@ func TestNewSection(t *testing.T) {
 	}
 } // TestNewSection()
 
+func TestNewSectionFromMap(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]string
+		want []TKeyVal
+	}{
+		{"1", map[string]string{"port": "8080", "host": "localhost", "debug": "no"},
+			[]TKeyVal{{"debug", "no"}, {"host", "localhost"}, {"port", "8080"}}},
+		{"2", map[string]string{"": "empty", "key": " value "},
+			[]TKeyVal{{"key", "value"}}},
+		{"3", nil, []TKeyVal{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSectionFromMap(tt.args).KeyVals(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: NewSectionFromMap() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestNewSectionFromMap()
+
 func TestTSection_AddKey(t *testing.T) {
 	type tArgs struct {
 		aKey   string
@@ -320,6 +344,91 @@ func TestTSection_AsBool(t *testing.T) {
 	}
 } // TestTSection_AsBool()
 
+func TestTSection_AsBytes(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key1", "aGVsbG8=")
+	_ = kl.AddKey("key2", "aGVsbG8")
+	_ = kl.AddKey("key3", "_-8=")
+	_ = kl.AddKey("key4", "no base64!")
+
+	tests := []struct {
+		args  string
+		want  []byte
+		want1 bool
+	}{
+		{"key1", []byte("hello"), true},
+		{"key2", []byte("hello"), true},
+		{"key3", []byte{0xff, 0xef}, true},
+		{"key4", nil, false},
+		{"n.a.", nil, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsBytes(tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TSection.AsBytes(%q) val = %v, want %v",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsBytes(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsBytes()
+
+func TestTSection_UpdateKeyBytes(t *testing.T) {
+	kl := prepSection()
+	data := []byte{0, 1, 2, 0xfe, 0xff}
+
+	if !kl.UpdateKeyBytes("blob", data) {
+		t.Fatal("TSection.UpdateKeyBytes() = false, want true")
+	}
+	if got, _ := kl.AsString("blob"); "AAEC/v8=" != got {
+		t.Errorf("TSection.UpdateKeyBytes() stored %q, want %q", got, "AAEC/v8=")
+	}
+	if got, ok := kl.AsBytes("blob"); !ok || !reflect.DeepEqual(got, data) {
+		t.Errorf("TSection.UpdateKeyBytes() read back %v, want %v", got, data)
+	}
+} // TestTSection_UpdateKeyBytes()
+
+func TestTSection_AsDuration(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key0", "")
+	_ = kl.AddKey("key1", "30s")
+	_ = kl.AddKey("key2", "1h30m")
+	_ = kl.AddKey("key3", "-5m")
+	_ = kl.AddKey("key4", "30")
+	tests := []struct {
+		args  string
+		want  time.Duration
+		want1 bool
+	}{
+		{"", 0, false},
+		{"key0", 0, false},
+		{"key1", 30 * time.Second, true},
+		{"key2", 90 * time.Minute, true},
+		{"key3", -5 * time.Minute, true},
+		{"key4", 0, false},
+		{"n.a.", 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsDuration(tt.args)
+			if got != tt.want {
+				t.Errorf("TSection.AsDuration(%q) val = %v, want %v",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsDuration(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsDuration()
+
 func TestTSection_AsFloat32(t *testing.T) {
 	kl := prepSection()
 	_ = kl.AddKey("key0", "0")
@@ -575,6 +684,73 @@ func TestTSection_AsInt64(t *testing.T) {
 	}
 } // TestTSection_AsInt32()
 
+func TestTSection_AsMap(t *testing.T) {
+	kl := NewSection()
+	_ = kl.AddKey("host", "localhost")
+	_ = kl.AddKey("port", "8080")
+
+	tests := []struct {
+		name   string
+		fields *TSection
+		want   map[string]string
+	}{
+		{"1", kl, map[string]string{"host": "localhost", "port": "8080"}},
+		{"2", NewSection(), map[string]string{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fields.AsMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSection.AsMap() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSection_AsMap()
+
+func TestTSection_AsPercent(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key0", "")
+	_ = kl.AddKey("key1", "75%")
+	_ = kl.AddKey("key2", "0.75")
+	_ = kl.AddKey("key3", "75")
+	_ = kl.AddKey("key4", "1")
+	_ = kl.AddKey("key5", "150%")
+	_ = kl.AddKey("key6", "-5")
+	_ = kl.AddKey("key7", "many")
+
+	tests := []struct {
+		args  string
+		want  float64
+		want1 bool
+	}{
+		{"", 0, false},
+		{"key0", 0, false},
+		{"key1", 0.75, true},
+		{"key2", 0.75, true},
+		{"key3", 0.75, true},
+		{"key4", 1.0, true},
+		{"key5", 0, false},
+		{"key6", 0, false},
+		{"key7", 0, false},
+		{"n.a.", 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsPercent(tt.args)
+			if got != tt.want {
+				t.Errorf("TSection.AsPercent(%q) val = %v, want %v",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsPercent(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsPercent()
+
 func TestTSection_AsString(t *testing.T) {
 	kl := prepSection()
 	_ = kl.AddKey("key0", "")
@@ -611,6 +787,47 @@ func TestTSection_AsString(t *testing.T) {
 	}
 }
 
+func TestTSection_AsTime(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key1", "2024-06-29T12:30:00Z")
+	_ = kl.AddKey("key2", "2024-06-29")
+	_ = kl.AddKey("key3", "29.06.2024 12:30")
+	want1 := time.Date(2024, 6, 29, 12, 30, 0, 0, time.UTC)
+	want2 := time.Date(2024, 6, 29, 0, 0, 0, 0, time.UTC)
+
+	type tArgs struct {
+		aKey     string
+		aLayouts []string
+	}
+	tests := []struct {
+		name  string
+		args  tArgs
+		want  time.Time
+		want1 bool
+	}{
+		{"0", tArgs{"", nil}, time.Time{}, false},
+		{"1", tArgs{"key1", nil}, want1, true},
+		{"2", tArgs{"key2", nil}, time.Time{}, false},
+		{"3", tArgs{"key2", []string{time.DateOnly}}, want2, true},
+		{"4", tArgs{"key3", []string{time.DateOnly, "02.01.2006 15:04"}}, want1, true},
+		{"5", tArgs{"n.a.", nil}, time.Time{}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := kl.AsTime(tt.args.aKey, tt.args.aLayouts...)
+			if !got.Equal(tt.want) {
+				t.Errorf("%q: TSection.AsTime() val = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSection.AsTime() ok = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsTime()
+
 func TestTSection_AsUInt(t *testing.T) {
 	kl := prepSection()
 	_ = kl.AddKey("key0", "")
@@ -809,6 +1026,7 @@ func TestTSection_AsUInt64(t *testing.T) {
 func TestTSection_Clear(t *testing.T) {
 	kl := prepSection()
 	klw := NewSection()
+	klw.edits = kl.edits + 1 // `Clear()` counts as a modification
 
 	tests := []struct {
 		name   string
@@ -909,6 +1127,123 @@ func TestTSection_HasKey(t *testing.T) {
 	}
 } // TestTSection_HasKey()
 
+func TestTSection_Keys(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields *TSection
+		want   []string
+	}{
+		{"1", prepSection(), []string{"key0", "bool", "float", "uint", "int"}},
+		{"2", NewSection(), []string{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fields.Keys(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSection.Keys() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSection_Keys()
+
+func TestTSection_KeyVals(t *testing.T) {
+	kl := NewSection()
+	_ = kl.AddKey("host", "localhost")
+	_ = kl.AddKey("port", "8080")
+
+	tests := []struct {
+		name   string
+		fields *TSection
+		want   []TKeyVal
+	}{
+		{"1", kl, []TKeyVal{{"host", "localhost"}, {"port", "8080"}}},
+		{"2", NewSection(), []TKeyVal{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fields.KeyVals()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSection.KeyVals() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if 0 < len(got) {
+				// the result is a copy:
+				got[0].Value = "changed"
+				if v, _ := tt.fields.AsString(got[0].Key); "changed" == v {
+					t.Errorf("%q: TSection.KeyVals() returned the section's data", tt.name)
+				}
+			}
+		})
+	}
+} // TestTSection_KeyVals()
+
+func TestTSection_WithPrefix(t *testing.T) {
+	kl := NewSection()
+	_ = kl.AddKey("plugin.foo.path", "/opt/foo")
+	_ = kl.AddKey("name", "myApp")
+	_ = kl.AddKey("plugin.foo.enabled", "true")
+	_ = kl.AddKey("plugin.bar.path", "/opt/bar")
+
+	tests := []struct {
+		name string
+		args string
+		want []TKeyVal
+	}{
+		{"1", "plugin.foo.", []TKeyVal{{"plugin.foo.path", "/opt/foo"}, {"plugin.foo.enabled", "true"}}},
+		{"2", "plugin.bar", []TKeyVal{{"plugin.bar.path", "/opt/bar"}}},
+		{"3", "none", nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kl.WithPrefix(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSection.WithPrefix() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSection_WithPrefix()
+
+func TestTSection_RenameKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldKey   string
+		newKey   string
+		want     bool
+		wantKeys []string
+	}{
+		{"1", "float", "real", true, []string{"key0", "bool", "real", "uint", "int"}},
+		{"2", "missing", "other", false, []string{"key0", "bool", "float", "uint", "int"}},
+		{"3", "float", "int", false, []string{"key0", "bool", "float", "uint", "int"}},
+		{"4", "float", " ", false, []string{"key0", "bool", "float", "uint", "int"}},
+		{"5", "float", "float", true, []string{"key0", "bool", "float", "uint", "int"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kl := prepSection()
+			_ = kl.UpdateKey("float", "1.5")
+			kl.setComment("float", []string{"; a number"})
+
+			if got := kl.RenameKey(tt.oldKey, tt.newKey); got != tt.want {
+				t.Errorf("%q: TSection.RenameKey() = %v, want %v", tt.name, got, tt.want)
+			}
+			if got := kl.Keys(); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("%q: TSection.Keys() = %v, want %v", tt.name, got, tt.wantKeys)
+			}
+			key := tt.wantKeys[2]
+			if value, _ := kl.AsString(key); "1.5" != value {
+				t.Errorf("%q: value = %q, want %q", tt.name, value, "1.5")
+			}
+			if _, ok := kl.comments[key]; !ok {
+				t.Errorf("%q: comment of %q lost", tt.name, key)
+			}
+		})
+	}
+} // TestTSection_RenameKey()
+
 func TestTSection_Len(t *testing.T) {
 	kl1 := prepSection()
 	kl2 := NewSection()
@@ -1001,6 +1336,21 @@ func TestTSection_RemoveKey(t *testing.T) {
 	}
 } // TestTSection_RemoveKey()
 
+func TestTSection_SetMany(t *testing.T) {
+	kl := NewSection()
+	_ = kl.AddKey("host", "localhost")
+
+	got := kl.SetMany(map[string]string{"port": " 8080 ", "host": "example.com", " ": "empty"})
+	want := map[string]bool{"port": true, "host": true, " ": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TSection.SetMany() = %v, want %v", got, want)
+	}
+	wantKV := []TKeyVal{{"host", "example.com"}, {"port", "8080"}}
+	if got := kl.KeyVals(); !reflect.DeepEqual(got, wantKV) {
+		t.Errorf("TSection.KeyVals() = %v, want %v", got, wantKV)
+	}
+} // TestTSection_SetMany()
+
 func TestTSection_Sort(t *testing.T) {
 	runtime.GOMAXPROCS(1)
 	kl := prepSection()
@@ -1115,6 +1465,40 @@ func TestTSection_UpdateKeyBool(t *testing.T) {
 	}
 } // TestTSection_UpdateKeyBool()
 
+func TestTSection_UpdateKeyDuration(t *testing.T) {
+	type tArgs struct {
+		aKey   string
+		aValue time.Duration
+	}
+
+	kl := prepSection()
+	tests := []struct {
+		name string
+		args tArgs
+		want bool
+	}{
+		{"0", tArgs{"", 0}, false},
+		{"1", tArgs{"key0", 90 * time.Second}, true},
+		{"2", tArgs{"n.a.", time.Hour}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kl.UpdateKeyDuration(tt.args.aKey, tt.args.aValue); got != tt.want {
+				t.Errorf("%q: TSection.UpdateKeyDuration() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			if got, _ := kl.AsDuration(tt.args.aKey); got != tt.args.aValue {
+				t.Errorf("%q: TSection.UpdateKeyDuration() stored %v, want %v",
+					tt.name, got, tt.args.aValue)
+			}
+		})
+	}
+} // TestTSection_UpdateKeyDuration()
+
 func TestTSection_UpdateSectKeyFloat(t *testing.T) {
 	type tArgs struct {
 		aKey   string
@@ -1199,6 +1583,41 @@ func TestTSection_UpdateKeyUInt(t *testing.T) {
 	}
 } // TestTSection_UpdateKeyUInt()
 
+func TestTSection_UpdateKeyTime(t *testing.T) {
+	type tArgs struct {
+		aKey   string
+		aValue time.Time
+	}
+
+	kl := prepSection()
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name string
+		args tArgs
+		want bool
+	}{
+		{"0", tArgs{"", now}, false},
+		{"1", tArgs{"key0", now}, true},
+		{"2", tArgs{"n.a.", now.UTC()}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kl.UpdateKeyTime(tt.args.aKey, tt.args.aValue); got != tt.want {
+				t.Errorf("%q: TSection.UpdateKeyTime() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			if got, _ := kl.AsTime(tt.args.aKey); !got.Equal(tt.args.aValue) {
+				t.Errorf("%q: TSection.UpdateKeyTime() stored %v, want %v",
+					tt.name, got, tt.args.aValue)
+			}
+		})
+	}
+} // TestTSection_UpdateKeyTime()
+
 func TestTSection_UpdateKeyStr(t *testing.T) {
 	type tArgs struct {
 		aKey   string
@@ -8,10 +8,18 @@ package ini
 
 import (
 	"bufio"
+	"context"
+	"encoding"
+	"encoding/base64"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -20,10 +28,22 @@ type (
 	// `tSections` is a list (map) of INI sections.
 	tSections map[string]*TSection
 
+	// `tComments` maps section or key names to the comment lines
+	// preceding them in the INI file.
+	tComments map[string][]string
+
 	// A helper slice of strings (i.e. section names)
 	// used to preserve the order of INI sections.
 	tSectionOrder = []string
 
+	// `TNamedSection` is an INI section together with its name.
+	//
+	// see `OrderedSections()`
+	TNamedSection struct {
+		Name    string
+		Section *TSection
+	}
+
 	// `TSectionList` is a list of INI sections.
 	//
 	// This opaque data structure is filled by e.g. `load()`.
@@ -31,10 +51,55 @@ type (
 	// For accessing the sections and key/value pairs it provides
 	// the appropriate methods.
 	TSectionList struct {
-		defSect  string        // name of default section
-		fName    string        // name of the INI file to use
-		secOrder tSectionOrder // slice containing the order of sections
-		sections tSections     // map of INI sections
+		aliases     tAliases         // deprecated names of keys
+		atomicStore bool             // write the INI file via a temporary file
+		bom         bool             // the INI file starts with a BOM
+		boolStrict  bool             // accept the vocabulary's booleans only
+		boolWords   map[string]bool  // spellings of boolean values
+		cache       *tRenderCache    // the INI data rendered last
+		changed     []TSectionKey    // keys modified since loading/storing
+		checksum    tChecksum        // the integrity footer read
+		cipher      TCipher          // en-/decrypts the secret keys' values
+		comments    tComments        // comments preceding the section headers
+		crlf        bool             // the INI file read used CR/LF line endings
+		defaults    []byte           // default INI data (see `NewWithDefaults()`)
+		defSect     string           // name of default section
+		dialect     TDialect         // syntax variant of the INI data
+		dupPolicy   TDuplicatePolicy // handling of duplicate keys
+		encoding    TEncoding        // character encoding of the INI file
+		expandEnv   bool             // expand environment variables in values
+		fallback    bool             // missing keys fall back to the default section
+		filePerm    fs.FileMode      // permissions of new INI files
+		fmtOpts     TFormatOptions   // layout of the INI data written
+		fName       string           // name of the INI file to use
+		forcePerm   bool             // apply `filePerm` to existing files
+		help        tKeyHelp         // help texts of the keys
+		indentCont  bool             // indented lines continue values
+		integrity   bool             // write a checksum footer
+		interpolate bool             // resolve references to other keys
+		keepOwner   bool             // preserve the INI file's ownership
+		lenientNum  bool             // accept hand-written number formats
+		limits      TParseLimits     // restrictions of the INI data read
+		loading     bool             // reading an INI file (no change tracking)
+		mtx         *sync.RWMutex    // guards the sections and their order
+		onAlias     TAliasFunc       // called on reading deprecated keys
+		onChange    TChangeFunc      // called on modifications
+		onMiss      TAccessFunc      // called on failing lookups
+		onRead      TAccessFunc      // called on successful lookups
+		origins     tOrigins         // files and lines the keys were read from
+		parseTime   time.Duration    // time spent reading the INI data last
+		placeholder *tPlaceholders   // resolves the `{{...}}` placeholders
+		readKeys    *tReadKeys       // keys read by the accessors
+		readOnly    bool             // reject all modifications
+		resolvers   tResolvers       // resolvers of secret references
+		secOrder    tSectionOrder    // slice containing the order of sections
+		secrets     tSecretKeys      // section/key pairs to encrypt
+		sections    tSections        // map of INI sections
+		strict      bool             // fail on malformed lines
+		templates   tTemplates       // renders the values read
+		trailer     []string         // comments following the last section
+		validators  tValidators      // checks of new values
+		warnings    []TParseError    // problems found while reading
 	}
 
 	// `TIniWalkFunc()` is used by `Walk()` when visiting an entry
@@ -68,12 +133,94 @@ var (
 	isSectionRE = regexp.MustCompile(`^\[\s*([^\]]*?)\s*]$`)
 
 	// match: key = val
-	isKeyValRE = regexp.MustCompile(`^([^=]+?)\s*=\s*(.*)$`)
+	isKeyValRE = regexp.MustCompile(`(?s)^([^=]+?)\s*=\s*(.*)$`)
 
 	// match: quoted ' " string " '
 	isQuotesRE = regexp.MustCompile(`^\s*(['"])\s*(.*?)\s*(['"])\s*$`)
 )
 
+// `envNameLen()` returns the length of the environment variable's
+// name `aText` starts with.
+//
+// Parameters:
+// - `aText` The text to check.
+//
+// Returns:
+// - `int`: The length of the variable's name (`0` if there's none).
+func envNameLen(aText string) (rLen int) {
+	for ; rLen < len(aText); rLen++ {
+		c := aText[rLen]
+		if ('_' != c) && !(('A' <= c) && ('Z' >= c)) &&
+			!(('a' <= c) && ('z' >= c)) && !(('0' <= c) && ('9' >= c)) {
+			break
+		}
+	}
+
+	return
+} // envNameLen()
+
+// `expandEnv()` replaces the environment variables in `aValue`
+// by their current values.
+//
+// Both the Unix style (`$VAR` and `${VAR}`) and the Windows style
+// (`%VAR%`) notations are supported. Undefined Unix style variables
+// are replaced by an empty string (like a shell would do) while
+// undefined Windows style variables are left untouched. A literal
+// `$` followed by a variable name has to be written as `$$`.
+// The value is scanned only once, so the values of the variables
+// are never expanded themselves.
+//
+// Parameters:
+// - `aValue` The string to expand.
+//
+// Returns:
+// - `string`: The expanded string.
+func expandEnv(aValue string) string {
+	if !strings.ContainsAny(aValue, `$%`) {
+		return aValue
+	}
+
+	var sb strings.Builder
+	for idx := 0; idx < len(aValue); {
+		c, rest := aValue[idx], aValue[idx+1:]
+		switch {
+		case ('%' == c) && (0 < envNameLen(rest)) && ('9' < rest[0]):
+			if end := envNameLen(rest); (end < len(rest)) && ('%' == rest[end]) {
+				if val, ok := os.LookupEnv(rest[:end]); ok {
+					sb.WriteString(val)
+				} else {
+					sb.WriteString(aValue[idx : idx+end+2])
+				}
+				idx += end + 2
+				continue
+			}
+
+		case ('$' == c) && strings.HasPrefix(rest, `$`):
+			sb.WriteByte('$') // escaped dollar sign
+			idx += 2
+			continue
+
+		case ('$' == c) && strings.HasPrefix(rest, `{`):
+			if end := strings.IndexByte(rest, '}'); 1 < end {
+				sb.WriteString(os.Getenv(rest[1:end]))
+				idx += end + 2
+				continue
+			}
+
+		case '$' == c:
+			if end := envNameLen(rest); 0 < end {
+				sb.WriteString(os.Getenv(rest[:end]))
+				idx += end + 1
+				continue
+			}
+		}
+		sb.WriteByte(c)
+		idx++
+	}
+
+	return sb.String()
+} // expandEnv()
+
 // `removeQuotes()` returns a quoted string w/o the quote characters.
 //
 // Parameters:
@@ -92,6 +239,42 @@ func removeQuotes(aString string) (rString string) {
 	return
 } // removeQuotes()
 
+// `commentString()` returns the given comment lines as a string with
+// each line terminated by a linefeed ('\n').
+//
+// Parameters:
+// - `aComments` The comment lines to join.
+//
+// Returns:
+// - `string`: The joined comment lines.
+func commentString(aComments []string) string {
+	if 0 == len(aComments) {
+		return ""
+	}
+
+	return strings.Join(aComments, "\n") + "\n"
+} // commentString()
+
+// `trimComments()` removes the trailing blank lines from the given
+// comment lines.
+//
+// Parameters:
+// - `aComments` The comment lines to trim.
+//
+// Returns:
+// - `[]string`: The trimmed comment lines or `nil` if there are none.
+func trimComments(aComments []string) []string {
+	cLen := len(aComments)
+	for (0 < cLen) && ("" == aComments[cLen-1]) {
+		cLen--
+	}
+	if 0 == cLen {
+		return nil
+	}
+
+	return aComments[:cLen]
+} // trimComments()
+
 /* * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * * */
 
 // `addSection()` appends a new INI section returning `true` on success or
@@ -104,21 +287,43 @@ func removeQuotes(aString string) (rString string) {
 // - bool: `true` if the section list was successfully updated,
 // `false` otherwise.
 func (sl *TSectionList) addSection(aSection string) (rOK bool) {
-	if _, rOK = sl.sections[aSection]; rOK {
-		return // already there: nothing more to do
-	}
+	defer sl.lock()()
+
+	return nil != sl.newSection(aSection)
+} // addSection()
 
-	sl.sections[aSection] = NewSection()
-	if _, rOK = sl.sections[aSection]; rOK {
-		// add new section name to order list
-		sl.secOrder = append(sl.secOrder, aSection)
+// `AddSectionFromMap()` adds the key/value pairs of `aMap` to
+// `aSection` returning `true` on success or `false` otherwise.
+//
+// The keys are added in sorted order so the result doesn't depend
+// on Go's random map iteration; existing keys are updated.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aMap` The key/value pairs to add.
+//
+// Returns:
+// - `bool`: `true` on success, or `false` if a key is empty, or
+// `aSection` can't be found or added.
+func (sl *TSectionList) AddSectionFromMap(aSection string, aMap map[string]string) (rOK bool) {
+	if sl.readOnly {
+		return
+	}
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	if rOK = sl.addSection(aSection); !rOK {
+		return // can't find nor add the section
+	}
 
-		// just to be safe:
-		_, rOK = sl.sections[aSection]
+	for _, key := range sortedKeys(aMap) {
+		if !sl.AddSectionKey(aSection, key, aMap[key]) {
+			rOK = false
+		}
 	}
 
 	return
-} // addSection()
+} // AddSectionFromMap()
 
 // `AddSectionKey()` appends a new key/value pair to `aSection`
 // returning `true` on success or `false` otherwise.
@@ -132,6 +337,9 @@ func (sl *TSectionList) addSection(aSection string) (rOK bool) {
 // - `bool`: `true` on success, of `false` if either `aKey` is empty, or
 // `aSection` can't be found or added.
 func (sl *TSectionList) AddSectionKey(aSection, aKey, aValue string) (rOK bool) {
+	if sl.readOnly {
+		return
+	}
 	if aKey = strings.TrimSpace(aKey); "" == aKey {
 		return
 	}
@@ -139,14 +347,17 @@ func (sl *TSectionList) AddSectionKey(aSection, aKey, aValue string) (rOK bool)
 	if aSection = strings.TrimSpace(aSection); "" == aSection {
 		aSection = sl.defSect
 	}
-
-	if rOK = sl.addSection(aSection); !rOK {
-		return // can't find nor add the section
+	if !sl.loading {
+		if nil != sl.checkValue(aSection, aKey, aValue) {
+			return
+		}
 	}
 
-	if kl, exists := sl.sections[aSection]; exists {
-		rOK = kl.AddKey(aKey, aValue)
-	}
+	var events []tChangeEvent
+	unlock := sl.lock()
+	rOK = sl.putKey(aSection, sl.newSection(aSection), aKey, aValue, &events)
+	unlock()
+	sl.notifyChanges(events)
 
 	return
 } // AddSectionKey()
@@ -167,7 +378,8 @@ func (sl *TSectionList) AddSectionKey(aSection, aKey, aValue string) (rOK bool)
 //
 // This method actually checks only the first character of the key's value
 // so one can write e.g. "false" or "NO" (for a `false` result), or "True" or
-// "yes" (for a `true` result).
+// "yes" (for a `true` result); see `SetBoolVocabulary()` for matching
+// exact spellings instead.
 //
 // Parameters:
 // - `aSection` the name of the INI section to lookup.
@@ -177,20 +389,48 @@ func (sl *TSectionList) AddSectionKey(aSection, aKey, aValue string) (rOK bool)
 // - `bool`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsBool(aSection, aKey string) (bool, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return false, false
-	}
+	result, err := sl.GetBool(aSection, aKey)
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
+	return result, (nil == err)
+} // AsBool()
 
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsBool(aKey)
-	}
+// `AsBytes()` returns the base64 decoded value of `aKey` in `aSection`.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// valid base64 data then the second return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `[]byte`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsBytes(aSection, aKey string) ([]byte, bool) {
+	result, err := sl.GetBytes(aSection, aKey)
 
-	return false, false
-} // AsBool()
+	return result, (nil == err)
+} // AsBytes()
+
+// `AsDuration()` returns the value of `aKey` in `aSection` as a time
+// duration.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't
+// a valid duration (like `30s`, `5m`, or `1h30m`) then the second
+// return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `time.Duration`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsDuration(aSection, aKey string) (time.Duration, bool) {
+	result, err := sl.GetDuration(aSection, aKey)
+
+	return result, (nil == err)
+} // AsDuration()
 
 // Float
 
@@ -212,19 +452,9 @@ func (sl *TSectionList) AsBool(aSection, aKey string) (bool, bool) {
 // - `float32`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsFloat32(aSection, aKey string) (float32, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return float32(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsFloat32(aKey)
-	}
+	result, err := sl.GetFloat32(aSection, aKey)
 
-	return float32(0), false
+	return result, (nil == err)
 } // AsFloat32()
 
 // `AsFloat64` returns the value of `aKey` in `aSection` as a 64bit
@@ -245,19 +475,9 @@ func (sl *TSectionList) AsFloat32(aSection, aKey string) (float32, bool) {
 // - `float64`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsFloat64(aSection, aKey string) (float64, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return float64(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsFloat64(aKey)
-	}
+	result, err := sl.GetFloat64(aSection, aKey)
 
-	return float64(0), false
+	return result, (nil == err)
 } // AsFloat64()
 
 // Int
@@ -275,19 +495,9 @@ func (sl *TSectionList) AsFloat64(aSection, aKey string) (float64, bool) {
 // - `int`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsInt(aSection, aKey string) (int, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return int(0), false
-	}
+	result, err := sl.GetInt(aSection, aKey)
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsInt(aKey)
-	}
-
-	return int(0), false
+	return result, (nil == err)
 } // AsInt()
 
 // `AsInt8()` returns the value of `aKey` in `aSection` as a 8bit integer.
@@ -303,19 +513,9 @@ func (sl *TSectionList) AsInt(aSection, aKey string) (int, bool) {
 // - `int8`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsInt8(aSection, aKey string) (int8, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return int8(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsInt8(aKey)
-	}
+	result, err := sl.GetInt8(aSection, aKey)
 
-	return int8(0), false
+	return result, (nil == err)
 } // AsInt8()
 
 // `AsInt16()` return the value of `aKey` in `aSection` as a 16bit integer.
@@ -331,19 +531,9 @@ func (sl *TSectionList) AsInt8(aSection, aKey string) (int8, bool) {
 // - `int16`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsInt16(aSection, aKey string) (int16, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return int16(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsInt16(aKey)
-	}
+	result, err := sl.GetInt16(aSection, aKey)
 
-	return int16(0), false
+	return result, (nil == err)
 } // AsInt16()
 
 // `AsInt32()` return the value of `aKey` in `aSection` as a 32bit integer.
@@ -359,19 +549,9 @@ func (sl *TSectionList) AsInt16(aSection, aKey string) (int16, bool) {
 // - `int32`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsInt32(aSection, aKey string) (int32, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return int32(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsInt32(aKey)
-	}
+	result, err := sl.GetInt32(aSection, aKey)
 
-	return int32(0), false
+	return result, (nil == err)
 } // AsInt32()
 
 // `AsInt64()` return the value of `aKey` in `aSection` as a 64bit integer.
@@ -387,20 +567,54 @@ func (sl *TSectionList) AsInt32(aSection, aKey string) (int32, bool) {
 // - `int64`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsInt64(aSection, aKey string) (int64, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return int64(0), false
-	}
+	result, err := sl.GetInt64(aSection, aKey)
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
+	return result, (nil == err)
+} // AsInt64()
 
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsInt64(aKey)
+// `AsNestedMap()` returns a map of the list's sections each holding
+// a map of the section's keys and their values.
+//
+// The values are returned as stored, i.e. without decrypting or
+// expanding them; see `TSection.AsMap()`.
+//
+// Returns:
+// - `map[string]map[string]string`: The list's data.
+func (sl *TSectionList) AsNestedMap() map[string]map[string]string {
+	unlock := sl.rlock()
+	sections := maps.Clone(sl.sections)
+	unlock()
+
+	result := make(map[string]map[string]string, len(sections))
+	for name, kl := range sections {
+		result[name] = kl.AsMap()
 	}
 
-	return int64(0), false
-} // AsInt64()
+	return result
+} // AsNestedMap()
+
+// `AsPercent()` returns the value of `aKey` in `aSection` as a fraction
+// between `0.0` and `1.0`.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value can't be
+// interpreted as a percentage then the second return value will be `false`.
+//
+// A value with a trailing `%` (like `75%`) is always a percentage,
+// a plain number between `0` and `1` (like `0.75`) is taken as a fraction,
+// and any other plain number (like `75`) is taken as a percentage.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `float64`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsPercent(aSection, aKey string) (float64, bool) {
+	result, err := sl.GetPercent(aSection, aKey)
+
+	return result, (nil == err)
+} // AsPercent()
 
 //
 
@@ -417,20 +631,59 @@ func (sl *TSectionList) AsInt64(aSection, aKey string) (int64, bool) {
 // - `string`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsString(aSection, aKey string) (string, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return "", false
-	}
+	result, err := sl.GetString(aSection, aKey)
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
+	return result, (nil == err)
+} // AsString()
 
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsString(aKey)
+// `AsTextUnmarshaler()` lets `aValue` parse the value of `aKey`
+// in `aSection`.
+//
+// This allows types like `net.IP`, `time.Time`, or custom enums
+// implementing the `encoding.TextUnmarshaler` interface to be read
+// directly from the INI data.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aValue` The target to unmarshal the key's value into.
+//
+// Returns:
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, or a wrapped
+// `ErrParseValue` with the error returned by `aValue`.
+func (sl *TSectionList) AsTextUnmarshaler(aSection, aKey string, aValue encoding.TextUnmarshaler) error {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return err
+	}
+	if err = aValue.UnmarshalText([]byte(value)); nil != err {
+		return parseError(section, aKey, value, err)
 	}
 
-	return "", false
-} // AsString()
+	return nil
+} // AsTextUnmarshaler()
+
+// `AsTime()` returns the value of `aKey` in `aSection` as a time value.
+//
+// The value is parsed using the `time.RFC3339` layout first and then
+// the given `aLayouts` in turn until one of them matches.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value matches
+// none of the layouts then the second return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aLayouts` Optional additional layouts to try (see `time.Parse()`).
+//
+// Returns:
+// - `time.Time`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsTime(aSection, aKey string, aLayouts ...string) (time.Time, bool) {
+	result, err := sl.GetTime(aSection, aKey, aLayouts...)
+
+	return result, (nil == err)
+} // AsTime()
 
 // Uint
 
@@ -448,19 +701,9 @@ func (sl *TSectionList) AsString(aSection, aKey string) (string, bool) {
 // - `uint`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsUInt(aSection, aKey string) (uint, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return uint(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsUInt(aKey)
-	}
+	result, err := sl.GetUInt(aSection, aKey)
 
-	return uint(0), false
+	return result, (nil == err)
 } // AsUInt()
 
 // `AsUInt8()` returns the value of `aKey` in `aSection` as an
@@ -477,19 +720,9 @@ func (sl *TSectionList) AsUInt(aSection, aKey string) (uint, bool) {
 // - `uint8`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsUInt8(aSection, aKey string) (uint8, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return uint8(0), false
-	}
+	result, err := sl.GetUInt8(aSection, aKey)
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsUInt8(aKey)
-	}
-
-	return uint8(0), false
+	return result, (nil == err)
 } // AsUInt8()
 
 // `AsUInt16()` return the value of `aKey` in `aSection` as an
@@ -506,19 +739,9 @@ func (sl *TSectionList) AsUInt8(aSection, aKey string) (uint8, bool) {
 // - `uint16`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsUInt16(aSection, aKey string) (uint16, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return uint16(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsUInt16(aKey)
-	}
+	result, err := sl.GetUInt16(aSection, aKey)
 
-	return uint16(0), false
+	return result, (nil == err)
 } // AsUInt16()
 
 // `AsUInt32()` return the value of `aKey` in `aSection` as an
@@ -535,19 +758,9 @@ func (sl *TSectionList) AsUInt16(aSection, aKey string) (uint16, bool) {
 // - `uint32`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsUInt32(aSection, aKey string) (uint32, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return uint32(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsUInt32(aKey)
-	}
+	result, err := sl.GetUInt32(aSection, aKey)
 
-	return uint32(0), false
+	return result, (nil == err)
 } // AsUInt32()
 
 // `AsUInt64()` return the value of `aKey` in `aSection` as an unsigned
@@ -564,19 +777,9 @@ func (sl *TSectionList) AsUInt32(aSection, aKey string) (uint32, bool) {
 // - `uint64`: The value associated with `aKey`.
 // - `bool`: `true` if `aKey` was found, or false otherwise.
 func (sl *TSectionList) AsUInt64(aSection, aKey string) (uint64, bool) {
-	if aKey = strings.TrimSpace(aKey); "" == aKey {
-		return uint64(0), false
-	}
-
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.AsUInt64(aKey)
-	}
+	result, err := sl.GetUInt64(aSection, aKey)
 
-	return uint64(0), false
+	return result, (nil == err)
 } // AsUInt64()
 
 //
@@ -591,15 +794,25 @@ func (sl *TSectionList) AsUInt64(aSection, aKey string) (uint64, bool) {
 // Returns:
 // - `*TSectionList`: The return value is the cleared list.
 func (sl *TSectionList) Clear() *TSectionList {
+	if sl.readOnly {
+		return sl
+	}
+	var events []tChangeEvent
+	unlock := sl.lock()
 	// we leave `defSect` alone for now
+	sl.InvalidateCache()
+	sl.comments = nil
+	sl.origins = nil
+	sl.trailer = nil
+	sl.warnings = nil
 	sl.secOrder = make(tSectionOrder, 0, slDefCapacity)
-	for name := range sl.sections {
-		if kl, exists := sl.sections[name]; exists {
-			kl.Clear()
-		}
-		delete(sl.sections, name)
+	for name, kl := range sl.sections {
+		events = append(events, sl.noteRemoval(name, kl)...)
+		kl.Clear()
 	}
 	sl.sections = make(tSections)
+	unlock()
+	sl.notifyChanges(events)
 
 	return sl
 } // Clear()
@@ -617,14 +830,15 @@ func (sl *TSectionList) Clear() *TSectionList {
 // - `bool`: `true` if both lists are equal, `false` otherwise.
 func (sl *TSectionList) CompareTo(aINI *TSectionList) bool {
 	// Check if both lists have the same number of sections
-	if len(sl.sections) != len(aINI.sections) {
+	if sl.Len() != aINI.Len() {
 		return false
 	}
 
 	// Iterate over each section in the current list
-	for name, kl := range sl.sections {
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
 		// Check if the other list has the same section
-		section, exists := aINI.sections[name]
+		section, exists := aINI.section(name)
 		if !exists {
 			return false
 		}
@@ -638,7 +852,103 @@ func (sl *TSectionList) CompareTo(aINI *TSectionList) bool {
 	return true
 } // CompareTo()
 
-// `Filename()` returns the configured filename of the INI file.
+// `copySettings()` copies all of the list's options (e.g. the dialect,
+// the hooks, the validators, and the storage settings) to `aList`.
+//
+// The list's data (sections, comments, change tracking, etc.) and its
+// read-only mode are not copied; it's up to the caller to set the
+// latter once `aList` is filled.
+//
+// Parameters:
+// - `aList` The list to configure.
+//
+// Returns:
+// - `*TSectionList`: The configured list `aList`.
+func (sl *TSectionList) copySettings(aList *TSectionList) *TSectionList {
+	aList.aliases = maps.Clone(sl.aliases)
+	aList.atomicStore = sl.atomicStore
+	aList.bom = sl.bom
+	aList.boolStrict = sl.boolStrict
+	aList.boolWords = maps.Clone(sl.boolWords)
+	aList.cipher = sl.cipher
+	aList.crlf = sl.crlf
+	aList.defaults = sl.defaults
+	aList.defSect = sl.defSect
+	aList.dialect = sl.dialect
+	aList.dupPolicy = sl.dupPolicy
+	aList.encoding = sl.encoding
+	aList.expandEnv = sl.expandEnv
+	aList.fallback = sl.fallback
+	aList.filePerm = sl.filePerm
+	aList.fmtOpts = sl.fmtOpts
+	aList.fName = sl.fName
+	aList.forcePerm = sl.forcePerm
+	aList.help = maps.Clone(sl.help)
+	aList.indentCont = sl.indentCont
+	aList.integrity = sl.integrity
+	aList.interpolate = sl.interpolate
+	aList.keepOwner = sl.keepOwner
+	aList.lenientNum = sl.lenientNum
+	aList.limits = sl.limits
+	aList.onAlias = sl.onAlias
+	aList.onChange = sl.onChange
+	aList.onMiss = sl.onMiss
+	aList.onRead = sl.onRead
+	if nil != sl.readKeys {
+		aList.readKeys = &tReadKeys{}
+	}
+	if nil != sl.placeholder {
+		aList.placeholder = &tPlaceholders{
+			resolver: sl.placeholder.resolver,
+			ttl:      sl.placeholder.ttl,
+		}
+	}
+	aList.resolvers = maps.Clone(sl.resolvers)
+	aList.secrets = maps.Clone(sl.secrets)
+	aList.strict = sl.strict
+	aList.templates = sl.templates
+	aList.validators = maps.Clone(sl.validators)
+
+	return aList
+} // copySettings()
+
+// `DefaultSection()` returns the name of the list's default section.
+//
+// Returns:
+// - `string`: The name of the default section.
+func (sl *TSectionList) DefaultSection() string {
+	return sl.defSect
+} // DefaultSection()
+
+// `dropSection()` removes `aSection` from the list recording the
+// removal of its keys.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section to remove.
+// - `aEvents` Collects the change notifications to send.
+//
+// Returns:
+// - `bool`: `true` if the section existed, `false` otherwise.
+func (sl *TSectionList) dropSection(aSection string, aEvents *[]tChangeEvent) bool {
+	kl, exists := sl.sections[aSection]
+	if !exists {
+		return false
+	}
+	*aEvents = append(*aEvents, sl.noteRemoval(aSection, kl)...)
+	delete(sl.sections, aSection)
+	delete(sl.comments, aSection)
+	sl.dropOrigin(aSection, "")
+	if idx := slices.Index(sl.secOrder, aSection); 0 <= idx {
+		sl.secOrder = slices.Delete(sl.secOrder, idx, idx+1)
+	}
+	sl.InvalidateCache()
+
+	return true
+} // dropSection()
+
+// `Filename()` returns the configured filename of the INI file.
 func (sl *TSectionList) Filename() string {
 	return sl.fName
 } // Filename()
@@ -656,7 +966,7 @@ func (sl *TSectionList) GetSection(aSection string) *TSection {
 		aSection = sl.defSect
 	}
 
-	if result, ok := sl.sections[aSection]; ok {
+	if result, ok := sl.section(aSection); ok {
 		return result
 	}
 
@@ -672,10 +982,9 @@ func (sl *TSectionList) GetSection(aSection string) *TSection {
 // - `bool`: `true` if `aSection` is found, or `false` otherwise.
 func (sl *TSectionList) HasSection(aSection string) (rOK bool) {
 	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		_, rOK = sl.sections[sl.defSect]
-	} else {
-		_, rOK = sl.sections[aSection]
+		aSection = sl.defSect
 	}
+	_, rOK = sl.section(aSection)
 
 	return
 } // HasSection()
@@ -698,7 +1007,7 @@ func (sl *TSectionList) HasSectionKey(aSection, aKey string) bool {
 		aSection = sl.defSect
 	}
 
-	if kl, ok := sl.sections[aSection]; ok {
+	if kl, ok := sl.section(aSection); ok {
 		return kl.HasKey(aKey)
 	}
 
@@ -712,6 +1021,8 @@ func (sl *TSectionList) HasSectionKey(aSection, aKey string) bool {
 // Returns:
 // - `int`: The number of sections in the INI file.
 func (sl *TSectionList) Len() int {
+	defer sl.rlock()()
+
 	return len(sl.sections)
 } // Len()
 
@@ -725,51 +1036,168 @@ func (sl *TSectionList) Len() int {
 // - `*TSectionList`: The loaded INI list.
 // - `error`: A possible error condition.
 func (sl *TSectionList) load() (*TSectionList, error) {
-	file, rErr := os.Open(sl.fName)
-	if nil != rErr {
-		return sl, rErr
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	_, err := sl.read(scanner)
-
-	return sl, err
+	return sl.loadContext(context.Background())
 } // load()
 
-// `mergeWalker()` inserts the given key/value pair in `aSection`.
+// `Load()` reads the configured INI file adding its sections and
+// key/value pairs to the list.
 //
-// This method is called by the `Merge()` method.
+// This allows to configure the list (e.g. by `SetDuplicatePolicy()`)
+// before reading the INI file:
 //
-// Parameters:
-// - `aSection`: The name of the INI section to lookup.
-// - `aKey` The name of the key/value pair to use.
-// - `aValue`: The value of the key/value pair to update.
-func (sl *TSectionList) mergeWalker(aSection, aKey, aValue string) {
-	sl.AddSectionKey(aSection, aKey, aValue) // ignore the return value
-} // mergeWalker()
+//	sl, err := ini.NewSectionList().SetFilename(fName).
+//		SetDuplicatePolicy(ini.KeepFirst).Load()
+//
+// Returns:
+// - `*TSectionList`: The current list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) Load() (*TSectionList, error) {
+	return sl.load()
+} // Load()
 
 // `Merge()` copies or merges all INI sections with all key/value pairs
 // into this list.
 //
+// Existing keys are overwritten by the values of `aINI`; see
+// `MergeWith()` and `MergeFunc()` for other strategies.
+//
 // Parameters:
 // - `aINI` The INI sections to merge with this list.
 //
 // Returns:
 // - `TSectionList` This sections list merged with the other one.
 func (sl *TSectionList) Merge(aINI *TSectionList) *TSectionList {
-	if nil != aINI {
-		aINI.Walk(sl.mergeWalker)
+	return sl.MergeFunc(aINI, nil)
+} // Merge()
+
+// `newSection()` returns the INI section named `aSection` appending
+// a new section if it doesn't exist yet.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+//
+// Returns:
+// - `*TSection`: The existing or new section.
+func (sl *TSectionList) newSection(aSection string) *TSection {
+	if kl, exists := sl.sections[aSection]; exists {
+		return kl // already there: nothing more to do
 	}
 
-	return sl
-} // Merge()
+	kl := NewSection()
+	sl.sections[aSection] = kl
+	// add new section name to order list
+	sl.secOrder = append(sl.secOrder, aSection)
+	sl.InvalidateCache()
+
+	return kl
+} // newSection()
+
+// `OrderedSections()` returns the list's sections together with their
+// names in the order they appear in the INI file.
+//
+// This saves looking up each of the names returned by `Sections()`:
+//
+//	for _, ns := range sl.OrderedSections() {
+//		fmt.Println(ns.Name, ns.Section.Len())
+//	}
+//
+// The returned sections aren't copies, i.e. modifying them modifies
+// the list.
+//
+// Returns:
+// - `[]TNamedSection`: The list's sections in order.
+func (sl *TSectionList) OrderedSections() []TNamedSection {
+	defer sl.rlock()()
+
+	result := make([]TNamedSection, 0, len(sl.secOrder))
+	for _, name := range sl.secOrder {
+		if kl, exists := sl.sections[name]; exists {
+			result = append(result, TNamedSection{name, kl})
+		}
+	}
+
+	return result
+} // OrderedSections()
+
+// `parseLine()` parses a single (possibly concatenated) INI line
+// returning the name of the current section and whether `aLine` was
+// recognised at all.
+//
+// If `aLine` is a section header the returned section name is that of
+// the new section, otherwise `aSection` is returned unchanged.
+// The given `aComments` are attached to the section header or key found.
+//
+// This method is called by the `read()` method.
+//
+// Parameters:
+// - `aSection`: The name of the current INI section.
+// - `aLine`: The trimmed INI line to parse.
+// - `aComments`: The comment lines preceding `aLine`.
+// - `aLineNum`: The number of the (first) line of `aLine`.
+// - `aSeen`: The section/key pairs read so far.
+//
+// Returns:
+// - `string`: The name of the current section.
+// - `bool`: `true` if `aLine` was recognised, `false` otherwise.
+// - `error`: A possible error caused by the duplicate key policy.
+func (sl *TSectionList) parseLine(aSection, aLine string, aComments []string, aLineNum int, aSeen tSeenKeys) (string, bool, error) {
+	if name, ok := sl.sectionMatch(aLine); ok {
+		// update the current section name
+		aSection = sl.headerName(strings.TrimSpace(name))
+		if "" == aSection {
+			aSection = sl.defSect
+		}
+		sl.setSectionComment(aSection, aComments)
+
+		return aSection, true, nil
+	}
+
+	if matches := isKeyValRE.FindStringSubmatch(aLine); nil != matches {
+		// get a slice of RegEx matches,
+		// we expect (1) key, (2) value
+		key := strings.TrimSpace(matches[1])
+		val := removeQuotes(matches[2])
+		if sl.keepQuotes() {
+			val = strings.TrimSpace(matches[2])
+		} else if DialectPHP == sl.dialect {
+			val = phpValue(matches[2])
+		}
+
+		ok, err := sl.addParsedKey(aSection, key, val, aLineNum, aSeen)
+		if ok {
+			sl.GetSection(aSection).setComment(key, aComments)
+		}
+
+		return aSection, true, err
+	}
+
+	if (DialectGit == sl.dialect) && isGitBareKeyRE.MatchString(aLine) {
+		// git's shortcut for a boolean `true`
+		ok, err := sl.addParsedKey(aSection, aLine, "true", aLineNum, aSeen)
+		if ok {
+			sl.GetSection(aSection).setComment(aLine, aComments)
+		}
+
+		return aSection, true, err
+	}
+
+	return aSection, false, nil // ignore broken lines
+} // parseLine()
 
 // `read()` reads/parses the INI file data returning the number of bytes
 // read and a possible error.
 //
-// This method reads one line of the INI file at a time skipping both
-// empty lines and comments (identified by '#' or ';' at line start).
+// This method reads one line of the INI file at a time. Both comments
+// (identified by '#' or ';' at line start) and the blank lines between
+// them are attached to the following section header or key/value pair.
+// Comments following the last section's entries are kept as the list's
+// trailing comments. Values may span several lines by a trailing
+// backslash, by triple quotes, or (if enabled by
+// `SetIndentContinuation()`) by indented follow-up lines (see
+// `scanLines()`). Malformed lines are recorded as warnings or, in
+// strict mode, stop reading with a `*TParseError`.
 //
 // The method updates the current section name and adds new key/value
 // pairs to the list of sections.
@@ -783,114 +1211,109 @@ func (sl *TSectionList) Merge(aINI *TSectionList) *TSectionList {
 // - `int`: The number of bytes read from the INI file.
 // - `error`: A possible error condition.
 func (sl *TSectionList) read(aScanner *bufio.Scanner) (rRead int, rErr error) {
-	var lastLine string
+	if sl.readOnly {
+		return 0, ErrReadOnly
+	}
+	var comments []string
 	section := sl.defSect
+	seen := make(tSeenKeys)
+	start := time.Now()
+	sl.loading = true
+	defer func() {
+		sl.loading = false
+		sl.parseTime = time.Since(start)
+	}()
+
+	rRead, rErr = scanLines(aScanner, sl.indentCont, tLineHandler{
+		comment: func(aLine string, aLineNum int) error {
+			if ("" != aLine) || (0 < len(comments)) {
+				// keep comments and the blank lines between them
+				comments = append(comments, aLine)
+			}
+			return nil
+		},
+
+		// handle a complete (possibly concatenated) line:
+		line: func(aLine string, aLineNum int) error {
+			var (
+				err error
+				ok  bool
+			)
+			if section, ok, err = sl.parseLine(section, aLine, comments, aLineNum, seen); nil != err {
+				return sl.newParseError(aLineNum, aLine, err)
+			}
+			if !ok {
+				return sl.malformedLine(aLineNum, aLine)
+			}
+			comments = nil
 
-	for lineRead := aScanner.Scan(); lineRead; lineRead = aScanner.Scan() {
-		line := aScanner.Text()
-		rRead += len(line) + 1 // add trailing LF
+			return sl.checkLimits(len(seen))
+		},
 
-		line = strings.TrimSpace(line)
-		lineLen := len(line)
-		if 0 == lineLen {
-			if "" == lastLine {
-				continue // Skip blank lines
+		// handle a complete triple-quoted value:
+		block: func(aBlock *tValueBlock) error {
+			if !aBlock.done {
+				return sl.malformedLine(aBlock.lineNum, aBlock.key+" = "+blockQuote)
 			}
-			line, lastLine = lastLine, ""
-		}
-		if ';' == line[0] || '#' == line[0] { // comment indicators
-			if "" == lastLine {
-				continue // Skip comment lines
+			ok, err := sl.addParsedKey(section, aBlock.key, aBlock.value(), aBlock.lineNum, seen)
+			if nil != err {
+				return sl.newParseError(aBlock.lineNum, aBlock.key, err)
 			}
-			line, lastLine = lastLine, ""
-		}
-		if '\\' == line[lineLen-1] { // possible value concatenation
-			if (1 < lineLen) && (' ' == line[lineLen-2]) {
-				lastLine += line[:lineLen-1]
-			} else {
-				lastLine += line[:lineLen-1] + " "
+			if ok {
+				sl.GetSection(section).setComment(aBlock.key, comments)
 			}
-			line = ``
-			continue // concatenation handled
-		}
-		if 0 < len(lastLine) {
-			line, lastLine = lastLine+line, ""
-		}
-
-		if matches := isSectionRE.FindStringSubmatch(line); nil != matches {
-			// update the current section name
-			section = strings.TrimSpace(matches[1])
-		} else if matches := isKeyValRE.FindStringSubmatch(line); nil != matches {
-			// get a slice of RegEx matches,
-			// we expect (1) key, (2) value
-			key := strings.TrimSpace(matches[1])
-			val := removeQuotes(matches[2])
-
-			sl.AddSectionKey(section, key, val) // ignore return value
-		} else {
-			line = "" // ignore broken lines
+			comments = nil
+
+			return sl.checkLimits(len(seen))
+		},
+
+		skipContComments: (DialectSystemd == sl.dialect),
+	})
+	if nil == rErr {
+		unlock := sl.lock()
+		sl.trailer = trimComments(dropChecksum(comments))
+		unlock()
+		sl.InvalidateCache()
+		if sl.templates.enabled {
+			rErr = sl.renderTemplates(seen)
 		}
 	}
-	rErr = aScanner.Err()
 
 	return
 } // read()
 
 // `RemoveSection()` deletes `aSection` from the list of sections.
 //
+// The section's entry in the list of sections and its entry in the
+// order of sections are removed together under the list's lock, so
+// the list stays consistent even if the order of sections was modified
+// (e.g. by `FromYAML()`) and the removal doesn't interleave with other
+// modifications (e.g. a transaction's `Commit()`).
+//
 // Parameters:
 // - `aSection` The name of the INI section to remove.
 //
 // Returns:
-// - `bool`: `true` on success, `false` on failure.
-func (sl *TSectionList) RemoveSection(aSection string) bool {
+// - `bool`: `true` if the section was removed, `false` otherwise.
+// - `bool`: `true` if the section existed, `false` otherwise.
+func (sl *TSectionList) RemoveSection(aSection string) (rRemoved, rExisted bool) {
 	if aSection = strings.TrimSpace(aSection); "" == aSection {
 		aSection = sl.defSect
 	}
-	if _, exists := sl.sections[aSection]; !exists {
-		// section doesn't exist which satisfies the removal request
-		return true
+	if sl.readOnly {
+		_, rExisted = sl.section(aSection)
+		return
 	}
 
-	delete(sl.sections, aSection)
-	if _, exists := sl.sections[aSection]; exists {
-		return false // this should never happen!
-	}
+	var events []tChangeEvent
+	unlock := sl.lock()
+	rExisted = sl.dropSection(aSection, &events)
+	unlock()
 
-	// len - 1: because list is zero-based
-	oLen := len(sl.secOrder) - 1
-	if 0 > oLen {
-		// empty list
-		return true
-	}
+	// the change hook may modify the list, so call it unlocked:
+	sl.notifyChanges(events)
 
-	// remove secOrder entry:
-	for idx, name := range sl.secOrder {
-		if name != aSection {
-			continue
-		}
-		switch idx {
-		case 0:
-			if 0 == oLen {
-				// the only list entry: replace by an empty list
-				sl.secOrder = make(tSectionOrder, 0, slDefCapacity)
-			} else {
-				// first list entry: move the remaining data
-				sl.secOrder = sl.secOrder[1:]
-			}
-
-		case oLen:
-			// last list entry
-			sl.secOrder = sl.secOrder[:idx]
-
-		default:
-			sl.secOrder = append(sl.secOrder[:idx], sl.secOrder[idx+1:]...)
-		}
-
-		return true
-	}
-
-	return false
+	return rExisted, rExisted
 } // RemoveSection()
 
 // `RemoveSectionKey()` removes aKey from aSection.
@@ -905,6 +1328,9 @@ func (sl *TSectionList) RemoveSection(aSection string) bool {
 // Returns:
 // - `bool`: `true` on success, `false` on failure.
 func (sl *TSectionList) RemoveSectionKey(aSection, aKey string) bool {
+	if sl.readOnly {
+		return false
+	}
 	if aKey = strings.TrimSpace(aKey); "" == aKey {
 		return true
 	}
@@ -913,14 +1339,192 @@ func (sl *TSectionList) RemoveSectionKey(aSection, aKey string) bool {
 		aSection = sl.defSect
 	}
 
+	var events []tChangeEvent
+	unlock := sl.lock()
 	if kl, exists := sl.sections[aSection]; exists {
-		return kl.RemoveKey(aKey)
+		sl.removeKey(aSection, kl, aKey, &events)
 	}
+	unlock()
+	sl.notifyChanges(events)
 
-	// section or key doesn't exist: assume successful removal
+	// a section or key that doesn't exist counts as removed
 	return true
 } // RemoveSectionKey()
 
+// `KeysMatching()` returns the section/key pairs whose keys match the
+// regular expression `aPattern`.
+//
+// The pairs are returned in the order of the sections and keys in
+// the INI file.
+//
+// Parameters:
+// - `aPattern` The regular expression to match the keys against.
+//
+// Returns:
+// - `[]TSectionKey`: A list of the matching section/key pairs.
+func (sl *TSectionList) KeysMatching(aPattern *regexp.Regexp) []TSectionKey {
+	var result []TSectionKey
+	if nil == aPattern {
+		return result
+	}
+
+	for _, ns := range sl.OrderedSections() {
+		for _, key := range ns.Section.Keys() {
+			if aPattern.MatchString(key) {
+				result = append(result, TSectionKey{Section: ns.Name, Key: key})
+			}
+		}
+	}
+
+	return result
+} // KeysMatching()
+
+// `SectionKeys()` returns a list of the keys in `aSection` in the
+// order they appear in the INI file.
+//
+// Parameters:
+// - `aSection` The name of the INI section (empty: default section).
+//
+// Returns:
+// - `[]string`: A list of the section's keys (`nil` if `aSection`
+// doesn't exist).
+// - `int`: The number of keys in the returned list.
+func (sl *TSectionList) SectionKeys(aSection string) ([]string, int) {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	kl, exists := sl.section(aSection)
+	if !exists {
+		return nil, 0
+	}
+	keys := kl.Keys()
+
+	return keys, len(keys)
+} // SectionKeys()
+
+// `RenameSection()` renames `aOldSection` to `aNewSection` keeping its
+// key/value pairs, comments, and position within the list.
+//
+// Parameters:
+// - `aOldSection` The current name of the INI section.
+// - `aNewSection` The new name of the INI section.
+//
+// Returns:
+// - `bool`: `true` if the section was renamed, `false` if `aOldSection`
+// doesn't exist or `aNewSection` already exists.
+func (sl *TSectionList) RenameSection(aOldSection, aNewSection string) bool {
+	if sl.readOnly {
+		return false
+	}
+	if aOldSection = strings.TrimSpace(aOldSection); "" == aOldSection {
+		aOldSection = sl.defSect
+	}
+	if aNewSection = strings.TrimSpace(aNewSection); "" == aNewSection {
+		aNewSection = sl.defSect
+	}
+	var events []tChangeEvent
+	unlock := sl.lock()
+	defer func() {
+		unlock()
+		sl.notifyChanges(events)
+	}()
+
+	kl, exists := sl.sections[aOldSection]
+	if !exists {
+		return false
+	}
+	if aOldSection == aNewSection {
+		return true
+	}
+	if _, exists = sl.sections[aNewSection]; exists {
+		return false
+	}
+
+	sl.InvalidateCache()
+	delete(sl.sections, aOldSection)
+	sl.sections[aNewSection] = kl
+	if idx := slices.Index(sl.secOrder, aOldSection); 0 <= idx {
+		sl.secOrder[idx] = aNewSection
+	}
+	if lines, ok := sl.comments[aOldSection]; ok {
+		delete(sl.comments, aOldSection)
+		sl.comments[aNewSection] = lines
+	}
+	sl.renameOrigins(aOldSection, aNewSection)
+	events = sl.noteRemoval(aOldSection, kl)
+	for _, kv := range *kl.data.copy() {
+		// encrypted values are bound to their section:
+		if sealed := sl.resealValue(aOldSection, kv.Key, aNewSection, kv.Key, kv.Value); sealed != kv.Value {
+			kl.AddKey(kv.Key, sealed)
+		}
+	}
+	for _, kv := range *kl.data.copy() {
+		if sl.recordChange(aNewSection, kv.Key, "", kv.Value, false) {
+			events = append(events, tChangeEvent{aNewSection, kv.Key, "", kv.Value})
+		}
+	}
+
+	return true
+} // RenameSection()
+
+// `section()` returns the INI section named `aSection`.
+//
+// Unlike `GetSection()` the name isn't normalised.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+//
+// Returns:
+// - `*TSection`: The requested section (`nil` if not found).
+// - `bool`: `true` if the section exists, `false` otherwise.
+func (sl *TSectionList) section(aSection string) (*TSection, bool) {
+	defer sl.rlock()()
+
+	kl, ok := sl.sections[aSection]
+
+	return kl, ok
+} // section()
+
+// `sectionComments()` returns the comment lines preceding the header
+// of `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+//
+// Returns:
+// - `[]string`: The section's comment lines.
+func (sl *TSectionList) sectionComments(aSection string) []string {
+	defer sl.rlock()()
+
+	return sl.comments[aSection]
+} // sectionComments()
+
+// `SectionComment()` returns the comment preceding the header of
+// `aSection` without the comment characters.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+//
+// Returns:
+// - `string`: The section's comment (empty if there's none).
+func (sl *TSectionList) SectionComment(aSection string) string {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	lines := sl.sectionComments(aSection)
+	if 0 == len(lines) {
+		return ""
+	}
+
+	text := make([]string, len(lines))
+	for idx, line := range lines {
+		line = strings.TrimLeft(strings.TrimSpace(line), ";#")
+		text[idx] = strings.TrimPrefix(line, " ")
+	}
+
+	return strings.Trim(strings.Join(text, "\n"), "\n")
+} // SectionComment()
+
 // `Sections()` returns a list of section names in the order they
 // appear in the INI file.
 //
@@ -931,12 +1535,144 @@ func (sl *TSectionList) RemoveSectionKey(aSection, aKey string) bool {
 // - `[]string`: A list of section names
 // - `int`: The number of sections in the returned list.
 func (sl *TSectionList) Sections() ([]string, int) {
+	defer sl.rlock()()
+
 	dest := make([]string, len(sl.secOrder))
 	len := copy(dest, sl.secOrder)
 
 	return dest, len
 } // Sections()
 
+// `SectionsWithPrefix()` returns the names of the sections starting
+// with `aPrefix` in the order they appear in the INI file.
+//
+// Parameters:
+// - `aPrefix` The prefix of the section names to return.
+//
+// Returns:
+// - `[]string`: A list of the matching section names.
+func (sl *TSectionList) SectionsWithPrefix(aPrefix string) []string {
+	defer sl.rlock()()
+
+	var result []string
+	for _, name := range sl.secOrder {
+		if strings.HasPrefix(name, aPrefix) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+} // SectionsWithPrefix()
+
+// `setSectionComment()` attaches the given comment lines to `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aComments` The comment lines preceding the section's header.
+func (sl *TSectionList) setSectionComment(aSection string, aComments []string) {
+	if aComments = trimComments(aComments); nil == aComments {
+		return
+	}
+	defer sl.lock()()
+
+	if nil == sl.comments {
+		sl.comments = make(tComments)
+	}
+	sl.comments[aSection] = aComments
+	sl.InvalidateCache()
+} // setSectionComment()
+
+// `SetSectionComment()` sets the comment emitted above the header of
+// `aSection` replacing a comment read from the INI file.
+//
+// Each line of `aText` not starting with a comment character (`;`
+// or `#`) is prefixed by `; `. An empty `aText` removes the comment.
+// This allows to write self-documenting INI files:
+//
+//	sl.AddSectionKey("server", "port", "8080")
+//	sl.SetSectionComment("server", "Settings of the HTTP server")
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aText` The comment to set.
+//
+// Returns:
+// - `bool`: `true` if the comment was set, `false` if the section
+// doesn't exist or the list is read-only.
+func (sl *TSectionList) SetSectionComment(aSection, aText string) bool {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	if _, exists := sl.section(aSection); !exists || sl.readOnly {
+		return false
+	}
+	if aText = strings.Trim(aText, "\r\n"); "" == strings.TrimSpace(aText) {
+		unlock := sl.lock()
+		delete(sl.comments, aSection)
+		unlock()
+		sl.InvalidateCache()
+		return true
+	}
+
+	lines := strings.Split(aText, "\n")
+	for idx, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, ";") && !strings.HasPrefix(line, "#") {
+			line = strings.TrimSpace("; " + line)
+		}
+		lines[idx] = line
+	}
+	sl.setSectionComment(aSection, lines)
+
+	return true
+} // SetSectionComment()
+
+// `SetDefaultSection()` sets the name of the list's default section.
+//
+// The default section is used whenever an empty section name is given
+// and it holds the key/value pairs preceding the first section header
+// of an INI file; so applications calling their global section e.g.
+// `[general]` or `[main]` can use that name instead of `DefSection`.
+//
+// Since key/value pairs without a section header are assigned while
+// reading, the name has to be set before the INI data is loaded
+// (see `Load()`); existing sections are not renamed (see
+// `RenameSection()` for that).
+//
+// Parameters:
+// - `aSection` The name of the default section (empty: `DefSection`).
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetDefaultSection(aSection string) *TSectionList {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = DefSection
+	}
+	sl.defSect = aSection
+
+	return sl
+} // SetDefaultSection()
+
+// `SetExpandEnv()` sets whether environment variables in the INI values
+// should be expanded.
+//
+// If enabled, values like `${HOME}/data` or `%APPDATA%\app` are
+// expanded whenever they are retrieved by one of the list's `AsXxx()`
+// or `GetXxx()` methods. The stored values themselves are not changed
+// so that `Store()` writes them back unexpanded. Write `$$` for a
+// literal dollar sign (e.g. in a password).
+//
+// Parameters:
+// - `aExpand` Whether to expand environment variables.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetExpandEnv(aExpand bool) *TSectionList {
+	sl.expandEnv = aExpand
+
+	return sl
+} // SetExpandEnv()
+
 // `SetFilename()` sets the filename of the INI file to use.
 //
 // Parameters:
@@ -947,6 +1683,21 @@ func (sl *TSectionList) SetFilename(aFilename string) *TSectionList {
 	return sl
 } // SetFilename()
 
+// `SetSortKeys()` determines whether `String()` (and hence `Store()`)
+// emits the keys of each section sorted by name instead of in the order
+// they were read or added.
+//
+// Parameters:
+// - `aSort` Whether to sort the keys on output.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetSortKeys(aSort bool) *TSectionList {
+	sl.fmtOpts.SortKeys = aSort
+
+	return sl
+} // SetSortKeys()
+
 // `Sort()` sorts the sections in the order they appear in the INI file.
 //
 // This method sorts the key/value pairs in each section.
@@ -954,48 +1705,65 @@ func (sl *TSectionList) SetFilename(aFilename string) *TSectionList {
 // Returns:
 // - `*TSectionList`: The sorted instance of the `TSectionList`.
 func (sl *TSectionList) Sort() *TSectionList {
+	if sl.readOnly {
+		return sl
+	}
 	// use the secOrder list to determine the order of sections
-	for _, name := range sl.secOrder {
-		if kl, exists := sl.sections[name]; exists {
-			sl.sections[name] = kl.Sort()
-		}
+	for _, ns := range sl.OrderedSections() {
+		ns.Section.Sort()
 	}
 
 	return sl
 } // Sort()
 
-// `Store()` writes all INI data to the configured filename.
+// `Store()` writes all INI data to the configured filename using the
+// list's layout (see `SetFormatOptions()`).
+//
+// If the atomic mode is enabled (see `SetAtomicStore()`) the data is
+// written to a temporary file which then replaces the INI file.
+// A successful write resets the list's modification tracking (see
+// `IsDirty()`). See `StoreAs()` to write to another file.
 //
 // Returns:
 // - `int`: The number of bytes written.
 // - `error`: An possible error during writing the data to file.
-func (sl *TSectionList) Store() (int, error) {
-	file, err := os.Create(sl.fName)
-	if err != nil {
-		return 0, err
+func (sl *TSectionList) Store() (rWritten int, rErr error) {
+	if rWritten, rErr = sl.storeFile(sl.fName, sl.fileMode()); nil == rErr {
+		sl.clearChanges()
 	}
-	defer file.Close()
 
-	return file.Write([]byte(sl.String()))
+	return
 } // Store()
 
 // `String()` returns a string representation of the INI section list.
 //
+// Comments read from the INI file are emitted in front of the section
+// headers and key/value pairs they were attached to. The keys are
+// emitted in the order they were read or added unless sorting is
+// enabled by `SetSortKeys()`; the list itself is never modified.
+// See `SetFormatOptions()` for other layout options.
+//
 // Returns:
 // - `string`: The string representation of the INI section list.
-func (sl *TSectionList) String() (rString string) {
-	// use the secOrder list to determine the order of sections
-	for _, name := range sl.secOrder {
-		if kl, exists := sl.sections[name]; exists {
-			// ensure that all sections are sorted internally
-			sl.sections[name] = kl.Sort()
+func (sl *TSectionList) String() string {
+	return sl.Format(sl.fmtOpts)
+} // String()
 
-			rString += "\n[" + name + "]\n" + kl.String()
-		}
-	}
+// `StringSorted()` returns a string representation of the INI section
+// list with the keys of each section sorted by name.
+//
+// The result equals that of `String()` after calling `Sort()` but
+// the list itself is never modified, so it's safe to be called while
+// other goroutines are reading the list.
+//
+// Returns:
+// - `string`: The string representation of the sorted INI section list.
+func (sl *TSectionList) StringSorted() string {
+	options := sl.fmtOpts
+	options.SortKeys = true
 
-	return
-} // String()
+	return sl.Format(options)
+} // StringSorted()
 
 // `updateSectKey()` updates the current value of `aKey` in `aSection`
 // by the provided new `aValue`.
@@ -1015,14 +1783,6 @@ func (sl *TSectionList) updateSectKey(aSection, aKey, aValue string) bool {
 		return false
 	}
 
-	if aSection = strings.TrimSpace(aSection); "" == aSection {
-		aSection = sl.defSect
-	}
-
-	if kl, exists := sl.sections[aSection]; exists {
-		return kl.UpdateKey(aKey, aValue)
-	}
-
 	// if `aSection` doesn't exist we create a new entry
 	return sl.AddSectionKey(aSection, aKey, aValue)
 } // updateSectKey()
@@ -1049,6 +1809,36 @@ func (sl *TSectionList) UpdateSectKeyBool(aSection, aKey string, aValue bool) bo
 	return sl.updateSectKey(aSection, aKey, `False`)
 } // UpdateSectKeyBool()
 
+// `UpdateSectKeyBytes()` replaces the current value of `aKey` in
+// `aSection` by the base64 encoded `aValue`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The binary data to store.
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeyBytes(aSection, aKey string, aValue []byte) bool {
+	return sl.updateSectKey(aSection, aKey, base64.StdEncoding.EncodeToString(aValue))
+} // UpdateSectKeyBytes()
+
+// `UpdateSectKeyDuration()` replaces the current value of `aKey` in
+// `aSection` by the provided new `aValue` duration.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The duration value of the key/value pair to update.
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeyDuration(aSection, aKey string, aValue time.Duration) bool {
+	return sl.updateSectKey(aSection, aKey, aValue.String())
+} // UpdateSectKeyDuration()
+
 // `UpdateSectKeyFloat()` replaces the current value of `aKey` in `aSection`
 // by the provided new `aValue` float.
 //
@@ -1079,6 +1869,23 @@ func (sl *TSectionList) UpdateSectKeyInt(aSection, aKey string, aValue int64) bo
 	return sl.updateSectKey(aSection, aKey, fmt.Sprintf("%d", aValue))
 } // UpdateSectKeyInt()
 
+// `UpdateSectKeyTime()` replaces the current value of `aKey` in
+// `aSection` by the provided new `aValue` time.
+//
+// The time is stored using the `time.RFC3339` layout.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The time value of the key/value pair to update.
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeyTime(aSection, aKey string, aValue time.Time) bool {
+	return sl.updateSectKey(aSection, aKey, aValue.Format(time.RFC3339))
+} // UpdateSectKeyTime()
+
 // `UpdateSectKeyUInt()` replaces the current value of `aKey` in `aSection`
 // by the provided new `aValue` unsigned integer.
 //
@@ -1114,17 +1921,25 @@ func (sl *TSectionList) UpdateSectKeyStr(aSection, aKey, aValue string) bool {
 // `Walk()` traverses through all entries in the INI list sections calling
 // `aFunc` for each entry.
 //
+// The order of the sections visited is undefined; use `WalkSorted()`
+// to follow the order of the sections in the INI file.
+// The entries of each section are a snapshot taken when the method is
+// called, so `aFunc` may safely modify the list without affecting the
+// traversal.
+//
 // Parameters:
 // - `aFunc` The function called for each key/value pair in all sections.
 func (sl *TSectionList) Walk(aFunc TIniWalkFunc) {
 	// We ignore the `secOrder` list because the
 	// order of sections doesn't matter here.
-	for name, kl := range sl.sections {
-		for _, kv := range kl.data {
+	unlock := sl.rlock()
+	sections := maps.Clone(sl.sections)
+	unlock()
+	for name, kl := range sections {
+		for _, kv := range kl.KeyVals() {
 			aFunc(name, kv.Key, kv.Value)
 		}
 	}
-
 } // Walk()
 
 // `Walker()` traverses through all entries in all INI sections
@@ -1136,6 +1951,24 @@ func (sl *TSectionList) Walker(aWalker TIniWalker) {
 	sl.Walk(aWalker.Walk)
 } // Walker()
 
+// `WalkSorted()` traverses through all entries in the INI list sections
+// calling `aFunc` for each entry.
+//
+// Other than `Walk()` this method visits the sections in the order
+// they appear in the INI file and the keys of each section in the
+// order they were read or added. Like `Walk()` it works on a snapshot
+// of the list's entries, so `aFunc` may safely modify the list.
+//
+// Parameters:
+// - `aFunc` The function called for each key/value pair in all sections.
+func (sl *TSectionList) WalkSorted(aFunc TIniWalkFunc) {
+	for _, ns := range sl.OrderedSections() {
+		for _, kv := range ns.Section.KeyVals() {
+			aFunc(ns.Name, kv.Key, kv.Value)
+		}
+	}
+} // WalkSorted()
+
 // ----------------------------------------------------------------
 
 // `NewSectionList()` creates a new instance of the `TSectionList`.
@@ -1146,10 +1979,26 @@ func (sl *TSectionList) Walker(aWalker TIniWalker) {
 // - *TSectionList: A new instance of the `TSectionList`.
 func NewSectionList() *TSectionList {
 	return &TSectionList{
+		cache:    &tRenderCache{},
 		defSect:  DefSection,
+		mtx:      &sync.RWMutex{},
 		secOrder: make(tSectionOrder, 0, slDefCapacity),
 		sections: make(tSections),
 	}
 } // NewSectionList()
 
+// `NewSectionListNamed()` creates a new instance of the `TSectionList`
+// using `aDefSect` as the name of the default section.
+//
+// See `SetDefaultSection()` for details.
+//
+// Parameters:
+// - `aDefSect` The name of the default section (empty: `DefSection`).
+//
+// Returns:
+// - *TSectionList: A new instance of the `TSectionList`.
+func NewSectionListNamed(aDefSect string) *TSectionList {
+	return NewSectionList().SetDefaultSection(aDefSect)
+} // NewSectionListNamed()
+
 /* _EoF_ */
@@ -7,10 +7,17 @@ Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
 package ini
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 //lint:file-ignore ST1017 - I prefer Yoda conditions
@@ -37,6 +44,9 @@ func Test_removeQuotes(t *testing.T) {
 	si3, ws3 := " \" this is a text ' ", "\" this is a text '"
 	si4, ws4 := " this is a text ", "this is a text"
 	si5, ws5 := " this is a text ' ", "this is a text '"
+	si6, ws6 := ` "a, b", "c" `, `a, b", "c`
+	si7, ws7 := `"say "hello""`, `say "hello"`
+	si8, ws8 := `'it's'`, `it's`
 	tests := []struct {
 		name        string
 		args        string
@@ -47,6 +57,9 @@ func Test_removeQuotes(t *testing.T) {
 		{"3", si3, ws3},
 		{"4", si4, ws4},
 		{"5", si5, ws5},
+		{"6", si6, ws6},
+		{"7", si7, ws7},
+		{"8", si8, ws8},
 		// TODO: Add test cases.
 	}
 	for _, tt := range tests {
@@ -59,6 +72,40 @@ func Test_removeQuotes(t *testing.T) {
 	}
 } // Test_removeQuotes
 
+func Test_expandEnv(t *testing.T) {
+	t.Setenv("INI_TEST_DIR", "/opt/ini")
+	t.Setenv("INI_TEST_PASS", "pa$word$$")
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"0", "", ""},
+		{"1", "${INI_TEST_DIR}/data", "/opt/ini/data"},
+		{"2", "$INI_TEST_DIR/data", "/opt/ini/data"},
+		{"3", `%INI_TEST_DIR%\app`, `/opt/ini\app`},
+		{"4", "%INI_TEST_NA%/app", "%INI_TEST_NA%/app"},
+		{"5", "${INI_TEST_NA}/app", "/app"},
+		{"6", "75%", "75%"},
+		{"7", "$$INI_TEST_DIR costs 5$", "$INI_TEST_DIR costs 5$"},
+		{"8", "pa$$word", "pa$word"},
+		{"9", "%INI_TEST_PASS%", "pa$word$$"},
+		{"10", "${INI_TEST_PASS}:$INI_TEST_PASS", "pa$word$$:pa$word$$"},
+		{"11", "%INI_TEST_DIR%%INI_TEST_DIR%", "/opt/ini/opt/ini"},
+		{"12", "5% of $ 100%", "5% of $ 100%"},
+		{"13", "${}${INI_TEST_DIR", "${}${INI_TEST_DIR"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandEnv(tt.args); got != tt.want {
+				t.Errorf("%q: expandEnv() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_expandEnv()
+
 func TestTSectionList_addSection(t *testing.T) {
 	sl := NewSectionList()
 	tests := []struct {
@@ -81,6 +128,27 @@ func TestTSectionList_addSection(t *testing.T) {
 	}
 } // TestTIniList_addSection()
 
+func TestTSectionList_AddSectionFromMap(t *testing.T) {
+	sl := NewSectionList()
+	if !sl.AddSectionFromMap("server", map[string]string{"port": "8080", "host": "localhost"}) {
+		t.Fatal("TSectionList.AddSectionFromMap() = false")
+	}
+	if !sl.AddSectionFromMap("server", map[string]string{"port": "443"}) {
+		t.Fatal("TSectionList.AddSectionFromMap() = false")
+	}
+	want := []TKeyVal{{"host", "localhost"}, {"port", "443"}}
+	if got := sl.GetSection("server").KeyVals(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TSectionList.AddSectionFromMap() = %v, want %v", got, want)
+	}
+
+	if sl.AddSectionFromMap("", map[string]string{" ": "empty"}) {
+		t.Error("TSectionList.AddSectionFromMap() = true for an empty key")
+	}
+	if !sl.HasSection("") {
+		t.Error("TSectionList.AddSectionFromMap() didn't add the default section")
+	}
+} // TestTSectionList_AddSectionFromMap()
+
 func TestTSectionList_AddSectionKey(t *testing.T) {
 	type tArgs struct {
 		aSection string
@@ -160,6 +228,43 @@ func TestTSectionList_AsBool(t *testing.T) {
 	}
 } // TestTSectionList_AsBool()
 
+func TestTSectionList_AsDuration(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+	}
+
+	sl := prepSectionList()
+	_ = sl.AddSectionKey("", "key1", "5m")
+	_ = sl.AddSectionKey("", "key2", "five minutes")
+
+	tests := []struct {
+		name  string
+		args  tArgs
+		want  time.Duration
+		want1 bool
+	}{
+		{"0", tArgs{"", "key0"}, 0, false},
+		{"1", tArgs{"", "key1"}, 5 * time.Minute, true},
+		{"2", tArgs{"", "key2"}, 0, false},
+		{"3", tArgs{"n.a.", "key1"}, 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sl.AsDuration(tt.args.aSection, tt.args.aKey)
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.AsDuration() got = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSectionList.AsDuration() got1 = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSectionList_AsDuration()
+
 //
 
 func TestTSectionList_AsFloat32(t *testing.T) {
@@ -490,6 +595,73 @@ func TestTSectionList_AsInt64(t *testing.T) {
 	}
 } // TestTSectionList_AsInt64()
 
+func TestTSectionList_AsNestedMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields *TSectionList
+		want   map[string]map[string]string
+	}{
+		{"1", prepSectionList(), map[string]map[string]string{
+			DefSection: {"key0": ""},
+			"s1":       {"bool": "nada"},
+			"s2":       {"float": "12345.6789"},
+			"s3":       {"int": "-12345"},
+			"s4":       {"uint": "1234567890"},
+		}},
+		{"2", NewSectionList(), map[string]map[string]string{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fields.AsNestedMap(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.AsNestedMap() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_AsNestedMap()
+
+func TestTSectionList_AsPercent(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+	}
+
+	sl := prepSectionList()
+	_ = sl.AddSectionKey("", "key1", "12.5 %")
+	_ = sl.AddSectionKey("", "key2", "0.125")
+	_ = sl.AddSectionKey("", "key3", "12.5")
+	_ = sl.AddSectionKey("", "key4", "101")
+
+	tests := []struct {
+		name  string
+		args  tArgs
+		want  float64
+		want1 bool
+	}{
+		{"0", tArgs{"", "key0"}, 0, false},
+		{"1", tArgs{"", "key1"}, 0.125, true},
+		{"2", tArgs{"", "key2"}, 0.125, true},
+		{"3", tArgs{"", "key3"}, 0.125, true},
+		{"4", tArgs{"", "key4"}, 0, false},
+		{"5", tArgs{"n.a.", "key1"}, 0, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sl.AsPercent(tt.args.aSection, tt.args.aKey)
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.AsPercent() got = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSectionList.AsPercent() got1 = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSectionList_AsPercent()
+
 //
 
 func TestTSectionList_AsString(t *testing.T) {
@@ -531,6 +703,47 @@ func TestTSectionList_AsString(t *testing.T) {
 	}
 } // TestTSectionList_AsString()
 
+func TestTSectionList_AsTime(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+		aLayouts []string
+	}
+
+	sl := prepSectionList()
+	_ = sl.AddSectionKey("", "key1", "2024-06-29T12:30:00+02:00")
+	_ = sl.AddSectionKey("", "key2", "29.06.2024")
+	want1 := time.Date(2024, 6, 29, 10, 30, 0, 0, time.UTC)
+	want2 := time.Date(2024, 6, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		args  tArgs
+		want  time.Time
+		want1 bool
+	}{
+		{"0", tArgs{"", "key0", nil}, time.Time{}, false},
+		{"1", tArgs{"", "key1", nil}, want1, true},
+		{"2", tArgs{"", "key2", nil}, time.Time{}, false},
+		{"3", tArgs{"", "key2", []string{"02.01.2006"}}, want2, true},
+		{"4", tArgs{"n.a.", "key1", nil}, time.Time{}, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sl.AsTime(tt.args.aSection, tt.args.aKey, tt.args.aLayouts...)
+			if !got.Equal(tt.want) {
+				t.Errorf("%q: TSectionList.AsTime() got = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSectionList.AsTime() got1 = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSectionList_AsTime()
+
 //
 
 func TestTSectionList_AsUInt(t *testing.T) {
@@ -897,29 +1110,97 @@ func TestTSectionList_HasSectionKey(t *testing.T) {
 	}
 } // TestTSectionList_HasSectionKey()
 
+func TestTSectionList_read(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"1", "k1 = v1\n", "\n[Default]\nk1 = v1\n"},
+		{"2", "# head\n\n[s1]\n; c1\n\n; c2\nk1 = v1\n\n# tail\n",
+			"\n# head\n[s1]\n; c1\n\n; c2\nk1 = v1\n\n# tail\n"},
+		{"3", "[s1]\nk1 = v \\\n\nk2 = w\n", "\n[s1]\nk1 = v\nk2 = w\n"},
+		{"4", "[s1]\nk1 = v \\\n# c\nk2 = w\n", "\n[s1]\nk1 = v\n# c\nk2 = w\n"},
+		{"5", "[s1]\nk1 = v \\\nw \\", "\n[s1]\nk1 = v w\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionList()
+			_, _ = sl.read(bufio.NewScanner(strings.NewReader(tt.data)))
+			if got := sl.String(); got != tt.want {
+				t.Errorf("%q: TSectionList.read() = %q,\nwant %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_read()
+
 func TestTSectionList_RemoveSection(t *testing.T) {
 	sl := prepSectionList()
 	tests := []struct {
-		name string
-		args string
-		want bool
+		name    string
+		args    string
+		want    bool
+		existed bool
 	}{
-		{"1", "", true},     // first
-		{"2", "s4", true},   // last
-		{"3", "s2", true},   // middle
-		{"4", "n.a.", true}, // n.a.
+		{"1", "", true, true},       // first
+		{"2", "s4", true, true},     // last
+		{"3", "s2", true, true},     // middle
+		{"4", "n.a.", false, false}, // n.a.
+		{"5", "s2", false, false},   // removed already
 		// TODO: Add test cases.
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := sl.RemoveSection(tt.args); got != tt.want {
+			got, existed := sl.RemoveSection(tt.args)
+			if got != tt.want {
 				t.Errorf("%q TSectionList.RemoveSection() = '%v', want '%v'",
 					tt.name, got, tt.want)
 			}
+			if existed != tt.existed {
+				t.Errorf("%q TSectionList.RemoveSection() existed = '%v', want '%v'",
+					tt.name, existed, tt.existed)
+			}
+			if sl.HasSection(tt.args) {
+				t.Errorf("%q TSectionList.HasSection() = true after removal",
+					tt.name)
+			}
 		})
 	}
+	if got, _ := sl.Sections(); !reflect.DeepEqual(got, []string{"s1", "s3"}) {
+		t.Errorf("TSectionList.Sections() = %v, want %v", got, []string{"s1", "s3"})
+	}
 } // TestTSectionList_RemoveSection()
 
+func TestTSectionList_RemoveSection_concurrent(t *testing.T) {
+	sl := NewSectionList()
+	for i := 0; i < 16; i++ {
+		sl.AddSectionKey(fmt.Sprintf("s%d", i), "key", "value")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func(aSection string) {
+			defer wg.Done()
+			sl.RemoveSection(aSection)
+		}(fmt.Sprintf("s%d", i))
+		go func(aSection string) {
+			defer wg.Done()
+			tx := sl.Begin()
+			tx.UpdateSectKeyStr(aSection, "key", "changed")
+			_ = tx.Commit()
+		}(fmt.Sprintf("t%d", i))
+	}
+	wg.Wait()
+
+	if order, n := sl.Sections(); (n != len(sl.sections)) || (16 != n) {
+		t.Errorf("TSectionList.RemoveSection() left %v for %d sections",
+			order, len(sl.sections))
+	}
+} // TestTSectionList_RemoveSection_concurrent()
+
 func TestTSectionList_RemoveSectionKey(t *testing.T) {
 	type tArgs struct {
 		aSection string
@@ -948,6 +1229,103 @@ func TestTSectionList_RemoveSectionKey(t *testing.T) {
 	}
 } // TestTSectionList_RemoveSectionKey()
 
+func TestTSectionList_KeysMatching(t *testing.T) {
+	sl := prepSectionList()
+	sl.AddSectionKey("s1", "int", "1")
+
+	tests := []struct {
+		name string
+		args *regexp.Regexp
+		want []TSectionKey
+	}{
+		{"1", regexp.MustCompile(`^int$`), []TSectionKey{{"s1", "int"}, {"s3", "int"}}},
+		{"2", regexp.MustCompile(`t$`), []TSectionKey{{"s2", "float"}, {"s1", "int"}, {"s4", "uint"}, {"s3", "int"}}},
+		{"3", regexp.MustCompile(`^none$`), nil},
+		{"4", nil, nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.KeysMatching(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.KeysMatching() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_KeysMatching()
+
+func TestTSectionList_SectionKeys(t *testing.T) {
+	sl := prepSectionList()
+	sl.AddSectionKey("s1", "int", "1")
+
+	tests := []struct {
+		name  string
+		args  string
+		want  []string
+		want1 int
+	}{
+		{"1", "", []string{"key0"}, 1},
+		{"2", "s1", []string{"bool", "int"}, 2},
+		{"3", "missing", nil, 0},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sl.SectionKeys(tt.args)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.SectionKeys() got = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSectionList.SectionKeys() got1 = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSectionList_SectionKeys()
+
+func TestTSectionList_RenameSection(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldName   string
+		newName   string
+		want      bool
+		wantOrder []string
+	}{
+		{"1", "s1", "first", true, []string{DefSection, "s2", "first", "s4", "s3"}},
+		{"2", "missing", "other", false, []string{DefSection, "s2", "s1", "s4", "s3"}},
+		{"3", "s1", "s2", false, []string{DefSection, "s2", "s1", "s4", "s3"}},
+		{"4", "s1", "s1", true, []string{DefSection, "s2", "s1", "s4", "s3"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := prepSectionList()
+			sl.setSectionComment("s1", []string{"; section one"})
+			sl.setOrigin("s1", "bool", "test.ini", 2)
+
+			if got := sl.RenameSection(tt.oldName, tt.newName); got != tt.want {
+				t.Errorf("%q: TSectionList.RenameSection() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got, _ := sl.Sections(); !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("%q: TSectionList.Sections() = %v, want %v",
+					tt.name, got, tt.wantOrder)
+			}
+			section := tt.wantOrder[2]
+			if value, _ := sl.AsString(section, "bool"); "nada" != value {
+				t.Errorf("%q: value = %q, want %q", tt.name, value, "nada")
+			}
+			if _, ok := sl.comments[section]; !ok {
+				t.Errorf("%q: comment of [%s] lost", tt.name, section)
+			}
+			if _, line, ok := sl.Origin(section, "bool"); !ok || (2 != line) {
+				t.Errorf("%q: origin of [%s] lost", tt.name, section)
+			}
+		})
+	}
+} // TestTSectionList_RenameSection()
+
 func TestTSectionList_Sections(t *testing.T) {
 	sl := prepSectionList()
 
@@ -980,6 +1358,90 @@ func TestTSectionList_Sections(t *testing.T) {
 	}
 } // TestTSectionList_Sections()
 
+func TestTSectionList_SectionsWithPrefix(t *testing.T) {
+	sl := prepSectionList()
+	sl.AddSectionKey("server-2", "port", "8082")
+	sl.AddSectionKey("server-1", "port", "8081")
+
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{"1", "server-", []string{"server-2", "server-1"}},
+		{"2", "s", []string{"s2", "s1", "s4", "s3", "server-2", "server-1"}},
+		{"3", "none", nil},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.SectionsWithPrefix(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.SectionsWithPrefix() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_SectionsWithPrefix()
+
+func TestTSectionList_SetDefaultSection(t *testing.T) {
+	data := "name = myApp\n\n[server]\nport = 8080\n"
+
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"1", "general", "general"},
+		{"2", " main ", "main"},
+		{"3", "", DefSection},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSectionListNamed(tt.args)
+			if got := sl.DefaultSection(); got != tt.want {
+				t.Errorf("%q: TSectionList.DefaultSection() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			_, _ = sl.read(bufio.NewScanner(strings.NewReader(data)))
+			if !sl.HasSection(tt.want) {
+				t.Errorf("%q: section [%s] missing", tt.name, tt.want)
+			}
+			if got, _ := sl.AsString("", "name"); "myApp" != got {
+				t.Errorf("%q: TSectionList.AsString() = %q, want %q",
+					tt.name, got, "myApp")
+			}
+		})
+	}
+} // TestTSectionList_SetDefaultSection()
+
+func TestTSectionList_SetExpandEnv(t *testing.T) {
+	t.Setenv("INI_TEST_DIR", "/opt/ini")
+	sl := prepSectionList()
+	sl.AddSectionKey("", "path", "${INI_TEST_DIR}/data")
+
+	tests := []struct {
+		name string
+		args bool
+		want string
+	}{
+		{"1", false, "${INI_TEST_DIR}/data"},
+		{"2", true, "/opt/ini/data"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := sl.SetExpandEnv(tt.args).AsString("", "path"); got != tt.want {
+				t.Errorf("%q: TSectionList.SetExpandEnv() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+	if !strings.Contains(sl.String(), "${INI_TEST_DIR}") {
+		t.Errorf("TSectionList.SetExpandEnv() modified the stored value")
+	}
+} // TestTSectionList_SetExpandEnv()
+
 func TestTSectionList_SetFilename(t *testing.T) {
 	sl := NewSectionList()
 	tests := []struct {
@@ -1000,6 +1462,82 @@ func TestTSectionList_SetFilename(t *testing.T) {
 	}
 } // TestTSectionList_SetFilename()
 
+func TestTSectionList_SetSectionComment(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("server", "port", "8080")
+
+	tests := []struct {
+		name    string
+		section string
+		text    string
+		want    bool
+		comment string
+		output  string
+	}{
+		{"1", "server", "HTTP server", true, "HTTP server",
+			"\n; HTTP server\n[server]\nport = 8080\n"},
+		{"2", "server", "first\n\n# second", true, "first\n\nsecond",
+			"\n; first\n;\n# second\n[server]\nport = 8080\n"},
+		{"3", "n.a.", "text", false, "",
+			"\n; first\n;\n# second\n[server]\nport = 8080\n"},
+		{"4", "server", "", true, "", "\n[server]\nport = 8080\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.SetSectionComment(tt.section, tt.text); got != tt.want {
+				t.Errorf("%q: TSectionList.SetSectionComment() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if got := sl.SectionComment(tt.section); got != tt.comment {
+				t.Errorf("%q: TSectionList.SectionComment() = %q, want %q",
+					tt.name, got, tt.comment)
+			}
+			if got := sl.String(); got != tt.output {
+				t.Errorf("%q: TSectionList.String() = %q, want %q",
+					tt.name, got, tt.output)
+			}
+		})
+	}
+
+	// the comment survives a round-trip:
+	sl.SetSectionComment("server", "HTTP server")
+	rl := NewSectionList()
+	if err := rl.UnmarshalText([]byte(sl.String())); nil != err {
+		t.Fatal(err)
+	}
+	if got := rl.SectionComment("server"); "HTTP server" != got {
+		t.Errorf("TSectionList.SectionComment() = %q, want %q", got, "HTTP server")
+	}
+} // TestTSectionList_SetSectionComment()
+
+func TestTSectionList_SetSortKeys(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("s", "zeta", "1")
+	sl.AddSectionKey("s", "alpha", "2")
+	sl.AddSectionKey("s", "mid", "3")
+	sl.UpdateSectKeyStr("s", "zeta", "4")
+
+	tests := []struct {
+		name string
+		sort bool
+		want string
+	}{
+		{"1", false, "\n[s]\nzeta = 4\nalpha = 2\nmid = 3\n"},
+		{"2", true, "\n[s]\nalpha = 2\nmid = 3\nzeta = 4\n"},
+		{"3", false, "\n[s]\nzeta = 4\nalpha = 2\nmid = 3\n"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.SetSortKeys(tt.sort).String(); got != tt.want {
+				t.Errorf("%q: TSectionList.String() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_SetSortKeys()
+
 func TestTSectionList_Sort(t *testing.T) {
 	sl := prepSectionList()
 	wl := prepSectionList().Sort()
@@ -1073,6 +1611,39 @@ key4 =
 	}
 } // TestTSectionList_String()
 
+func TestTSectionList_StringSorted(t *testing.T) {
+	sl := prepSectionList()
+	sl.AddSectionKey("s1", "aaa", "first")
+	wl := prepSectionList()
+	wl.AddSectionKey("s1", "aaa", "first")
+	want := wl.Sort().String()
+	unsorted := sl.String()
+	if unsorted == want {
+		t.Fatal("TSectionList.String() is sorted already")
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"1", want},
+		{"2", want},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.StringSorted(); got != tt.want {
+				t.Errorf("%q: TSectionList.StringSorted() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			if got := sl.String(); got != unsorted {
+				t.Errorf("%q: TSectionList.String() = %q, want %q",
+					tt.name, got, unsorted)
+			}
+		})
+	}
+} // TestTSectionList_StringSorted()
+
 func Benchmark_TSectionList_String(b *testing.B) {
 	sl, _ := NewIni(inFileName)
 	for n := 0; n < b.N*8*4; n++ {
@@ -1151,6 +1722,41 @@ func TestTSectionList_UpdateSectKeyBool(t *testing.T) {
 	}
 } // TestTSectionList_UpdateSectKeyBool()
 
+func TestTSectionList_UpdateSectKeyDuration(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+		aValue   time.Duration
+	}
+
+	sl := prepSectionList()
+	tests := []struct {
+		name string
+		args tArgs
+		want bool
+	}{
+		{"0", tArgs{"", "", 0}, false},
+		{"1", tArgs{"", "timeout", 45 * time.Second}, true},
+		{"2", tArgs{"general", "timeout", 2 * time.Hour}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.UpdateSectKeyDuration(tt.args.aSection, tt.args.aKey, tt.args.aValue); got != tt.want {
+				t.Errorf("%q: TSectionList.UpdateSectKeyDuration() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			if got, _ := sl.AsDuration(tt.args.aSection, tt.args.aKey); got != tt.args.aValue {
+				t.Errorf("%q: TSectionList.UpdateSectKeyDuration() stored %v, want %v",
+					tt.name, got, tt.args.aValue)
+			}
+		})
+	}
+} // TestTSectionList_UpdateSectKeyDuration()
+
 func TestTSectionList_UpdateSectKeyFloat(t *testing.T) {
 	type tArgs struct {
 		aSection string
@@ -1238,6 +1844,42 @@ func TestTSectionList_UpdateSectKeyUInt(t *testing.T) {
 	}
 } // TestTSectionList_UpdateSectKeyInt()
 
+func TestTSectionList_UpdateSectKeyTime(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+		aValue   time.Time
+	}
+
+	sl := prepSectionList()
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name string
+		args tArgs
+		want bool
+	}{
+		{"0", tArgs{"", "", now}, false},
+		{"1", tArgs{"", "started", now}, true},
+		{"2", tArgs{"general", "started", now.UTC()}, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.UpdateSectKeyTime(tt.args.aSection, tt.args.aKey, tt.args.aValue); got != tt.want {
+				t.Errorf("%q: TSectionList.UpdateSectKeyTime() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+			if !tt.want {
+				return
+			}
+			if got, _ := sl.AsTime(tt.args.aSection, tt.args.aKey); !got.Equal(tt.args.aValue) {
+				t.Errorf("%q: TSectionList.UpdateSectKeyTime() stored %v, want %v",
+					tt.name, got, tt.args.aValue)
+			}
+		})
+	}
+} // TestTSectionList_UpdateSectKeyTime()
+
 func TestTSectionList_UpdateSectKeyStr(t *testing.T) {
 	type tArgs struct {
 		aSection string
@@ -1292,6 +1934,39 @@ func TestTSectionList_Merge(t *testing.T) {
 	}
 } // TestTSectionList_Merge()
 
+func TestTSectionList_OrderedSections(t *testing.T) {
+	sl := prepSectionList()
+	sl.RemoveSection("s2")
+	sl.AddSectionKey("new", "key", "value")
+
+	tests := []struct {
+		name string
+		list *TSectionList
+		want []string
+	}{
+		{"1", sl, []string{sl.DefaultSection(), "s1", "s4", "s3", "new"}},
+		{"2", NewSectionList(), []string{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.list.OrderedSections()
+			names := make([]string, len(got))
+			for idx, ns := range got {
+				names[idx] = ns.Name
+				if ns.Section != tt.list.GetSection(ns.Name) {
+					t.Errorf("%q: TSectionList.OrderedSections() section %q differs",
+						tt.name, ns.Name)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("%q: TSectionList.OrderedSections() = %v, want %v",
+					tt.name, names, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_OrderedSections()
+
 func TestTSectionList_WriteFile(t *testing.T) {
 	ini, _ := NewIni(inFileName)
 	ini.SetFilename(outFilename)
@@ -1303,7 +1978,7 @@ func TestTSectionList_WriteFile(t *testing.T) {
 		wantErr    bool
 	}{
 		// TODO: Add test cases.
-		{"1", ini, 4062, false},
+		{"1", ini, 4116, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1351,6 +2026,62 @@ func TestTSections_Walk(t *testing.T) {
 	}
 } // TestTSections_Walk()
 
+func TestTSectionList_WalkSorted(t *testing.T) {
+	sl := prepSectionList()
+	sl.AddSectionKey("s1", "aaa", "first")
+
+	var want []string
+	order, _ := sl.Sections()
+	for _, name := range order {
+		for _, key := range sl.GetSection(name).Keys() {
+			want = append(want, name+"."+key)
+		}
+	}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"1", want},
+		{"2", want},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			sl.WalkSorted(func(aSect, aKey, aVal string) {
+				got = append(got, aSect+"."+aKey)
+				// modifying the list must not affect the traversal:
+				sl.AddSectionKey(aSect, "walked", "yes")
+			})
+			for _, name := range order {
+				sl.RemoveSectionKey(name, "walked")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.WalkSorted() = %v, want %v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_WalkSorted()
+
+func TestTSectionList_Walk_concurrent(t *testing.T) {
+	sl := prepSectionList()
+	kl := sl.GetSection("s1")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			kl.AddKey(fmt.Sprintf("key%d", i), "value")
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		sl.Walk(func(aSect, aKey, aVal string) {})
+		sl.WalkSorted(func(aSect, aKey, aVal string) {})
+	}
+	<-done
+} // TestTSectionList_Walk_concurrent()
+
 type tListWalk int
 
 func (tw tListWalk) Walk(aSect, aKey, aVal string) {
@@ -1377,4 +2108,60 @@ func TestTSectionList_Walker(t *testing.T) {
 	}
 } // TestTSectionList_Walker()
 
+func TestTSectionList_AsTextUnmarshaler(t *testing.T) {
+	sl := prepSectionList()
+	_ = sl.AddSectionKey("net", "ip", "10.0.0.1")
+	_ = sl.AddSectionKey("net", "bad", "10.0.0")
+
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr error
+	}{
+		{"1", "ip", "10.0.0.1", nil},
+		{"2", "bad", "", ErrParseValue},
+		{"3", "n.a.", "", ErrKeyNotFound},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ip net.IP
+			err := sl.AsTextUnmarshaler("net", tt.key, &ip)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%q: TSectionList.AsTextUnmarshaler() error = %v, want %v",
+					tt.name, err, tt.wantErr)
+			}
+			if (nil == err) && (tt.want != ip.String()) {
+				t.Errorf("%q: TSectionList.AsTextUnmarshaler() = %v, want %v",
+					tt.name, ip, tt.want)
+			}
+		})
+	}
+
+	var ip net.IP
+	if err := sl.GetSection("net").AsTextUnmarshaler("ip", &ip); (nil != err) || ("10.0.0.1" != ip.String()) {
+		t.Errorf("TSection.AsTextUnmarshaler() = %v, %v", ip, err)
+	}
+} // TestTSectionList_AsTextUnmarshaler()
+
+func TestTSectionList_AsBytes(t *testing.T) {
+	sl := prepSectionList()
+	data := []byte("secret token")
+	if !sl.UpdateSectKeyBytes("keys", "token", data) {
+		t.Fatal("TSectionList.UpdateSectKeyBytes() = false, want true")
+	}
+	_ = sl.AddSectionKey("keys", "bad", "***")
+
+	if got, ok := sl.AsBytes("keys", "token"); !ok || !reflect.DeepEqual(got, data) {
+		t.Errorf("TSectionList.AsBytes() = %q, %v", got, ok)
+	}
+	if _, ok := sl.AsBytes("keys", "bad"); ok {
+		t.Error("TSectionList.AsBytes() ok = true, want false")
+	}
+	if _, err := sl.GetBytes("keys", "bad"); !errors.Is(err, ErrParseValue) {
+		t.Errorf("TSectionList.GetBytes() error = %v, want %v", err, ErrParseValue)
+	}
+} // TestTSectionList_AsBytes()
+
 /* _EoF_ */
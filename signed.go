@@ -0,0 +1,150 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `SignatureExt` is the filename extension of the signature files
+	// written by `StoreSigned()`.
+	SignatureExt = ".sig"
+)
+
+var (
+	// `ErrSignature` is returned by `LoadVerified()` if the INI file's
+	// signature is missing or invalid.
+	ErrSignature = errors.New("ini: invalid signature")
+)
+
+// `signData()` returns the signature of `aData`.
+//
+// Ed25519 keys sign the data themselves, all other keys (i.e. ECDSA
+// and RSA with PKCS #1 v1.5) sign their SHA-256 digest.
+//
+// Parameters:
+// - `aSigner` The private key to sign with.
+// - `aData` The data to sign.
+//
+// Returns:
+// - `[]byte`: The signature.
+// - `error`: A possible error condition.
+func signData(aSigner crypto.Signer, aData []byte) ([]byte, error) {
+	if _, ok := aSigner.Public().(ed25519.PublicKey); ok {
+		return aSigner.Sign(rand.Reader, aData, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(aData)
+
+	return aSigner.Sign(rand.Reader, digest[:], crypto.SHA256)
+} // signData()
+
+// `verifyData()` checks the signature `aSignature` of `aData`
+// (see `signData()`).
+//
+// Parameters:
+// - `aKey` The public key to verify with.
+// - `aData` The signed data.
+// - `aSignature` The signature to check.
+//
+// Returns:
+// - `bool`: Whether the signature is valid.
+func verifyData(aKey crypto.PublicKey, aData, aSignature []byte) bool {
+	digest := sha256.Sum256(aData)
+
+	switch key := aKey.(type) {
+	case ed25519.PublicKey:
+		return (ed25519.PublicKeySize == len(key)) &&
+			ed25519.Verify(key, aData, aSignature)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], aSignature)
+	case *rsa.PublicKey:
+		return nil == rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], aSignature)
+	}
+
+	return false
+} // verifyData()
+
+// `LoadVerified()` reads the configured INI file like `Load()` after
+// checking its detached signature written by `StoreSigned()`.
+//
+// Nothing is read if the signature file is missing or doesn't match
+// the INI file. Supported keys are Ed25519, ECDSA, and RSA.
+//
+// Parameters:
+// - `aKey` The public key to verify the signature with.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+// - `error`: `ErrSignature` or another possible error condition.
+func (sl *TSectionList) LoadVerified(aKey crypto.PublicKey) (*TSectionList, error) {
+	data, err := os.ReadFile(sl.fName)
+	if nil != err {
+		return sl, err
+	}
+	text, err := os.ReadFile(sl.fName + SignatureExt)
+	if nil != err {
+		return sl, fmt.Errorf("%w: %v", ErrSignature, err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(text)))
+	if (nil != err) || !verifyData(aKey, data, signature) {
+		return sl, fmt.Errorf("%w: %s", ErrSignature, sl.fName)
+	}
+
+	_, err = sl.read(sl.newScanner(context.Background(), bytes.NewReader(data)))
+
+	return sl, err
+} // LoadVerified()
+
+// `StoreSigned()` writes all INI data to the configured filename like
+// `Store()` does along with a detached signature.
+//
+// The Base64 encoded signature is written to a file named like the
+// INI file with `SignatureExt` appended; see `LoadVerified()`.
+//
+// Parameters:
+// - `aSigner` The private key to sign the INI data with.
+//
+// Returns:
+// - `int`: The number of bytes written to the INI file.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreSigned(aSigner crypto.Signer) (rWritten int, rErr error) {
+	data, err := sl.storeData()
+	if nil != err {
+		return 0, err
+	}
+	signature, err := signData(aSigner, data)
+	if nil != err {
+		return 0, err
+	}
+
+	mode := sl.fileMode()
+	if rWritten, rErr = sl.writeData(sl.fName, data, mode); nil != rErr {
+		return
+	}
+	text := base64.StdEncoding.EncodeToString(signature) + "\n"
+	if _, rErr = sl.writeData(sl.fName+SignatureExt, []byte(text), mode); nil == rErr {
+		sl.clearChanges()
+	}
+
+	return
+} // StoreSigned()
+
+/* _EoF_ */
@@ -0,0 +1,98 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_StoreSigned(t *testing.T) {
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name    string
+		signer  crypto.Signer
+		pub     crypto.PublicKey
+		wantErr bool
+	}{
+		{"ed25519", edKey, edPub, false},
+		{"ecdsa", ecKey, ecKey.Public(), false},
+		{"rsa", rsaKey, rsaKey.Public(), false},
+		{"wrong key", edKey, otherPub, true},
+		{"wrong type", ecKey, rsaKey.Public(), true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fName := filepath.Join(t.TempDir(), "signed.ini")
+			sl := NewSectionList().SetFilename(fName)
+			sl.AddSectionKey("server", "port", "8080")
+			if _, err := sl.StoreSigned(tt.signer); nil != err {
+				t.Fatalf("TSectionList.StoreSigned() error = %v", err)
+			}
+			if sl.IsDirty() {
+				t.Error("TSectionList.IsDirty() = true after StoreSigned()")
+			}
+
+			back, err := NewSectionList().SetFilename(fName).LoadVerified(tt.pub)
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("TSectionList.LoadVerified() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrSignature) {
+					t.Errorf("TSectionList.LoadVerified() error = %v, want %v", err, ErrSignature)
+				}
+				if 0 != back.Len() {
+					t.Error("TSectionList.LoadVerified() read unverified data")
+				}
+				return
+			}
+			if got, _ := back.AsInt("server", "port"); 8080 != got {
+				t.Errorf("TSectionList.AsInt() = %d, want 8080", got)
+			}
+		})
+	}
+} // TestTSectionList_StoreSigned()
+
+func TestTSectionList_LoadVerified(t *testing.T) {
+	pub, key, _ := ed25519.GenerateKey(rand.Reader)
+	fName := filepath.Join(t.TempDir(), "signed.ini")
+	sl := NewSectionList().SetFilename(fName)
+	sl.AddSectionKey("server", "port", "8080")
+	if _, err := sl.StoreSigned(key); nil != err {
+		t.Fatalf("TSectionList.StoreSigned() error = %v", err)
+	}
+
+	// tampering with the INI file:
+	data, _ := os.ReadFile(fName)
+	_ = os.WriteFile(fName, append(data, "[admin]\n"...), 0600)
+	if _, err := NewSectionList().SetFilename(fName).LoadVerified(pub); !errors.Is(err, ErrSignature) {
+		t.Errorf("TSectionList.LoadVerified() error = %v, want %v", err, ErrSignature)
+	}
+
+	// missing signature:
+	_ = os.Remove(fName + SignatureExt)
+	if _, err := NewSectionList().SetFilename(fName).LoadVerified(pub); !errors.Is(err, ErrSignature) {
+		t.Errorf("TSectionList.LoadVerified() error = %v, want %v", err, ErrSignature)
+	}
+} // TestTSectionList_LoadVerified()
+
+/* _EoF_ */
@@ -0,0 +1,236 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tSizeUnit` is a unit of a human-readable size.
+	tSizeUnit struct {
+		name   string
+		factor uint64
+	}
+)
+
+var (
+	// `errInvalidSize` is returned for values that aren't sizes.
+	errInvalidSize = errors.New("invalid size")
+
+	// the size units in order of their preference for formatting
+	sizeUnits = []tSizeUnit{
+		{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40},
+		{"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+		{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12},
+		{"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3},
+	}
+
+	// the factors of the (lowercased) unit names accepted by `parseSize()`
+	sizeFactors = map[string]uint64{
+		"": 1, "b": 1,
+		"k": 1 << 10, "kib": 1 << 10, "kb": 1e3,
+		"m": 1 << 20, "mib": 1 << 20, "mb": 1e6,
+		"g": 1 << 30, "gib": 1 << 30, "gb": 1e9,
+		"t": 1 << 40, "tib": 1 << 40, "tb": 1e12,
+		"p": 1 << 50, "pib": 1 << 50, "pb": 1e15,
+		"e": 1 << 60, "eib": 1 << 60, "eb": 1e18,
+	}
+)
+
+// `formatSize()` returns the most compact representation of `aSize`.
+//
+// All units are tried and the shortest exact representation is
+// returned, e.g. `1GiB` for 1073741824 and `1536` for 1536. If a
+// plain number is as short as a number with unit, the latter is used.
+//
+// Parameters:
+// - `aSize` The number of bytes to format.
+//
+// Returns:
+// - `string`: The human-readable size.
+func formatSize(aSize uint64) string {
+	result := strconv.FormatUint(aSize, 10)
+	maxLen := len(result) + 1 // prefer a unit over a plain number
+	for _, unit := range sizeUnits {
+		if aSize < unit.factor {
+			continue
+		}
+		num := strconv.FormatFloat(float64(aSize)/float64(unit.factor), 'f', -1, 64)
+		candidate := num + unit.name
+		if len(candidate) < maxLen {
+			if size, err := parseSize(candidate); (nil == err) && (size == aSize) {
+				result, maxLen = candidate, len(candidate)
+			}
+		}
+	}
+
+	return result
+} // formatSize()
+
+// `parseSize()` interprets `aValue` as a human-readable size.
+//
+// The number may be followed by a unit: SI units (`kB`, `MB`, `GB`,
+// `TB`, `PB`, `EB`) are powers of 1000 while IEC units (`KiB`, `MiB`,
+// `GiB`, `TiB`, `PiB`, `EiB`) and the single letters `k`, `M`, `G`,
+// `T`, `P`, and `E` are powers of 1024. Units are case insensitive,
+// and a plain number or a `B` suffix means bytes.
+//
+// Parameters:
+// - `aValue` The string to parse.
+//
+// Returns:
+// - `uint64`: The number of bytes represented by `aValue`.
+// - `error`: A possible parsing error.
+func parseSize(aValue string) (uint64, error) {
+	aValue = strings.TrimSpace(aValue)
+	idx := strings.IndexFunc(aValue, func(aRune rune) bool {
+		return !(('0' <= aRune && '9' >= aRune) || ('.' == aRune) || ('_' == aRune))
+	})
+	if 0 > idx {
+		idx = len(aValue)
+	}
+	num := strings.ReplaceAll(aValue[:idx], "_", "")
+	factor, ok := sizeFactors[strings.ToLower(strings.TrimSpace(aValue[idx:]))]
+	if ("" == num) || !ok {
+		return 0, errInvalidSize
+	}
+
+	if !strings.Contains(num, ".") {
+		ui64, err := strconv.ParseUint(num, 10, 64)
+		if nil != err {
+			return 0, err
+		}
+		hi, lo := bits.Mul64(ui64, factor)
+		if 0 != hi {
+			return 0, strconv.ErrRange
+		}
+		return lo, nil
+	}
+
+	f64, err := strconv.ParseFloat(num, 64)
+	if nil != err {
+		return 0, err
+	}
+	f64 = math.Round(f64 * float64(factor))
+	if float64(math.MaxUint64) <= f64 {
+		return 0, strconv.ErrRange
+	}
+
+	return uint64(f64), nil
+} // parseSize()
+
+// --------------------------------------------------------------------------
+
+// `AsSizeBytes()` returns the value of `aKey` as a number of bytes.
+//
+// Values like `10MB`, `1.5GiB`, or `512k` are accepted; see
+// `UpdateKeySizeBytes()` for the units.
+//
+// If the given `aKey` doesn't exist or its value isn't a valid size
+// then the second return value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint64`: The value of `aKey` as a number of bytes.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsSizeBytes(aKey string) (uint64, bool) {
+	value, ok := kl.AsString(aKey)
+	if !ok {
+		return 0, false
+	}
+	result, err := parseSize(value)
+
+	return result, (nil == err)
+} // AsSizeBytes()
+
+// `UpdateKeySizeBytes()` replaces the current value of `aKey` by the
+// provided number of bytes using the most compact unit.
+//
+// SI units (`kB`, `MB`, `GB`, `TB`, `PB`, `EB`) are powers of 1000
+// while IEC units (`KiB`, `MiB`, `GiB`, `TiB`, `PiB`, `EiB`) are
+// powers of 1024.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The number of bytes to store.
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeySizeBytes(aKey string, aValue uint64) bool {
+	return kl.UpdateKey(aKey, formatSize(aValue))
+} // UpdateKeySizeBytes()
+
+// --------------------------------------------------------------------------
+
+// `AsSizeBytes()` returns the value of `aKey` in `aSection` as a number
+// of bytes.
+//
+// If the given `aKey` in `aSection` doesn't exist or its value isn't a
+// valid size (like `10MB`, `1.5GiB`, or `512k`) then the second return
+// value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint64`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsSizeBytes(aSection, aKey string) (uint64, bool) {
+	result, err := sl.GetSizeBytes(aSection, aKey)
+
+	return result, (nil == err)
+} // AsSizeBytes()
+
+// `GetSizeBytes()` returns the value of `aKey` in `aSection` as a
+// number of bytes.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `uint64`: The value associated with `aKey`.
+// - `error`: `ErrSectionNotFound`, `ErrKeyNotFound`, `ErrParseValue`, or `nil`.
+func (sl *TSectionList) GetSizeBytes(aSection, aKey string) (uint64, error) {
+	section, value, err := sl.lookup(aSection, aKey)
+	if nil != err {
+		return 0, err
+	}
+	result, err := parseSize(value)
+	if nil != err {
+		return 0, parseError(section, aKey, value, err)
+	}
+
+	return result, nil
+} // GetSizeBytes()
+
+// `UpdateSectKeySizeBytes()` replaces the current value of `aKey` in
+// `aSection` by the provided number of bytes using the most compact unit.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The number of bytes to store.
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeySizeBytes(aSection, aKey string, aValue uint64) bool {
+	return sl.updateSectKey(aSection, aKey, formatSize(aValue))
+} // UpdateSectKeySizeBytes()
+
+/* _EoF_ */
@@ -0,0 +1,113 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_parseSize(t *testing.T) {
+	tests := []struct {
+		args    string
+		want    uint64
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"1536", 1536, false},
+		{"100 B", 100, false},
+		{"512k", 512 << 10, false},
+		{"10MB", 10e6, false},
+		{"10mb", 10e6, false},
+		{"10MiB", 10 << 20, false},
+		{"1.5GiB", 1536 << 20, false},
+		{"1.5 G", 1536 << 20, false},
+		{"2TB", 2e12, false},
+		{"1_000kB", 1e6, false},
+		{"16EiB", 0, true},
+		{"-1k", 0, true},
+		{"1.2.3k", 0, true},
+		{"ten MB", 0, true},
+		{"10XB", 0, true},
+		{"", 0, true},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, err := parseSize(tt.args)
+			if (nil != err) != tt.wantErr {
+				t.Fatalf("parseSize(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSize(%q) = %d, want %d", tt.args, got, tt.want)
+			}
+		})
+	}
+} // Test_parseSize()
+
+func Test_formatSize(t *testing.T) {
+	tests := []struct {
+		args uint64
+		want string
+	}{
+		{0, "0"},
+		{1000, "1kB"},
+		{1024, "1KiB"},
+		{1536, "1536"},
+		{1536 << 20, "1.5GiB"},
+		{10e6, "10MB"},
+		{2e12, "2TB"},
+		{1 << 60, "1EiB"},
+		{math.MaxUint64, "18446744073709551615"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatSize(tt.args); got != tt.want {
+				t.Errorf("formatSize(%d) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+} // Test_formatSize()
+
+func TestTSection_AsSizeBytes(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("cache", "1.5GiB")
+	_ = kl.AddKey("bad", "lots")
+
+	if got, ok := kl.AsSizeBytes("cache"); !ok || (1536<<20 != got) {
+		t.Errorf("TSection.AsSizeBytes() = %d, %v", got, ok)
+	}
+	if _, ok := kl.AsSizeBytes("bad"); ok {
+		t.Error("TSection.AsSizeBytes() ok = true, want false")
+	}
+	_ = kl.UpdateKeySizeBytes("upload", 64<<20)
+	if got, _ := kl.AsString("upload"); "64MiB" != got {
+		t.Errorf("TSection.UpdateKeySizeBytes() stored %q, want %q", got, "64MiB")
+	}
+} // TestTSection_AsSizeBytes()
+
+func TestTSectionList_AsSizeBytes(t *testing.T) {
+	sl := prepSectionList()
+	_ = sl.UpdateSectKeySizeBytes("limits", "upload", 10e6)
+	_ = sl.AddSectionKey("limits", "bad", "10 parsecs")
+
+	if got, ok := sl.AsSizeBytes("limits", "upload"); !ok || (10e6 != got) {
+		t.Errorf("TSectionList.AsSizeBytes() = %d, %v", got, ok)
+	}
+	if _, err := sl.GetSizeBytes("limits", "bad"); !errors.Is(err, ErrParseValue) {
+		t.Errorf("TSectionList.GetSizeBytes() error = %v, want %v", err, ErrParseValue)
+	}
+	if _, err := sl.GetSizeBytes("limits", "n.a."); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TSectionList.GetSizeBytes() error = %v, want %v", err, ErrKeyNotFound)
+	}
+} // TestTSectionList_AsSizeBytes()
+
+/* _EoF_ */
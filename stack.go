@@ -0,0 +1,205 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tStackLayer` is a single named configuration source.
+	tStackLayer struct {
+		name string        // the layer's name (e.g. a filename)
+		list *TSectionList // the layer's INI data
+	}
+
+	// `TConfigStack` is an ordered list of INI sources (e.g. defaults,
+	// system, user, command line) which are searched top-down without
+	// physically merging them.
+	//
+	// The layer pushed last has the highest priority.
+	TConfigStack struct {
+		layers []tStackLayer
+	}
+)
+
+// `NewConfigStack()` returns a new (empty) configuration stack.
+//
+// Returns:
+// - `*TConfigStack`: The new stack.
+func NewConfigStack() *TConfigStack {
+	return &TConfigStack{}
+} // NewConfigStack()
+
+// `AsString()` returns the value of `aKey` in `aSection` from the
+// topmost layer providing it.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found in any layer, `false` otherwise.
+func (cs *TConfigStack) AsString(aSection, aKey string) (string, bool) {
+	result, err := cs.GetString(aSection, aKey)
+
+	return result, (nil == err)
+} // AsString()
+
+// `Explain()` returns the name of the layer supplying the value of
+// `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The name of the layer providing `aKey`.
+// - `bool`: `true` if `aKey` was found in any layer, `false` otherwise.
+func (cs *TConfigStack) Explain(aSection, aKey string) (string, bool) {
+	if layer := cs.resolve(aSection, aKey); nil != layer {
+		return layer.name, true
+	}
+
+	return "", false
+} // Explain()
+
+// `Flatten()` merges all layers (bottom-up) into a new section list.
+//
+// Returns:
+// - `*TSectionList`: The merged INI data of all layers.
+func (cs *TConfigStack) Flatten() *TSectionList {
+	result := NewSectionList()
+	for _, layer := range cs.layers {
+		result.Merge(layer.list)
+	}
+
+	return result
+} // Flatten()
+
+// `StoreEffective()` writes the merged INI data of all layers to
+// `aFilename` telling for each key which file supplied its value.
+//
+// Keys not read from a file are attributed to the layer's name;
+// see `TSectionList.StoreEffective()` for details.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (cs *TConfigStack) StoreEffective(aFilename string) (int, error) {
+	flat := cs.Flatten()
+
+	return flat.storeEffective(aFilename, func(aSection, aKey string) (string, bool) {
+		if result, ok := flat.originString(aSection, aKey); ok {
+			return result, true
+		}
+		// not read from a file: use the layer's name
+		return cs.Explain(aSection, aKey)
+	})
+} // StoreEffective()
+
+// `GetString()` returns the value of `aKey` in `aSection` from the
+// topmost layer providing it.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `string`: The value associated with `aKey`.
+// - `error`: `ErrKeyNotFound` or `nil`.
+func (cs *TConfigStack) GetString(aSection, aKey string) (string, error) {
+	if layer := cs.resolve(aSection, aKey); nil != layer {
+		return layer.list.GetString(aSection, aKey)
+	}
+
+	return "", fmt.Errorf("[%s] %s: %w",
+		strings.TrimSpace(aSection), strings.TrimSpace(aKey), ErrKeyNotFound)
+} // GetString()
+
+// `Layer()` returns the section list of the layer named `aName`.
+//
+// Parameters:
+// - `aName` The name of the layer to return.
+//
+// Returns:
+// - `*TSectionList`: The layer's INI data or `nil` if not found.
+func (cs *TConfigStack) Layer(aName string) *TSectionList {
+	for idx := len(cs.layers) - 1; 0 <= idx; idx-- {
+		if aName == cs.layers[idx].name {
+			return cs.layers[idx].list
+		}
+	}
+
+	return nil
+} // Layer()
+
+// `Layers()` returns the names of all layers from bottom to top.
+//
+// Returns:
+// - `[]string`: The list of layer names.
+func (cs *TConfigStack) Layers() []string {
+	result := make([]string, 0, len(cs.layers))
+	for _, layer := range cs.layers {
+		result = append(result, layer.name)
+	}
+
+	return result
+} // Layers()
+
+// `Len()` returns the number of layers in the stack.
+//
+// Returns:
+// - `int`: The number of layers.
+func (cs *TConfigStack) Len() int {
+	return len(cs.layers)
+} // Len()
+
+// `Push()` adds `aList` as the new topmost layer named `aName`.
+//
+// A `nil` list is silently ignored.
+//
+// Parameters:
+// - `aName` The name of the layer (e.g. the filename it was read from).
+// - `aList` The INI data of the new layer.
+//
+// Returns:
+// - `*TConfigStack`: The current stack.
+func (cs *TConfigStack) Push(aName string, aList *TSectionList) *TConfigStack {
+	if nil != aList {
+		cs.layers = append(cs.layers, tStackLayer{aName, aList})
+	}
+
+	return cs
+} // Push()
+
+// `resolve()` returns the topmost layer providing `aKey` in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+//
+// Returns:
+// - `*tStackLayer`: The layer found or `nil` if none provides `aKey`.
+func (cs *TConfigStack) resolve(aSection, aKey string) *tStackLayer {
+	for idx := len(cs.layers) - 1; 0 <= idx; idx-- {
+		if _, _, err := cs.layers[idx].list.rawValue(aSection, aKey); nil == err {
+			return &cs.layers[idx]
+		}
+	}
+
+	return nil
+} // resolve()
+
+/* _EoF_ */
@@ -0,0 +1,113 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepConfigStack() *TConfigStack {
+	defaults := NewSectionList()
+	defaults.AddSectionKey("", "name", "default")
+	defaults.AddSectionKey("", "port", "80")
+	defaults.AddSectionKey("log", "level", "info")
+
+	user := NewSectionList()
+	user.AddSectionKey("", "port", "8080")
+
+	cmdline := NewSectionList()
+	cmdline.AddSectionKey("log", "level", "debug")
+
+	return NewConfigStack().
+		Push("defaults", defaults).
+		Push("user", user).
+		Push("cmdline", cmdline)
+} // prepConfigStack()
+
+func TestTConfigStack_Explain(t *testing.T) {
+	cs := prepConfigStack()
+	tests := []struct {
+		name      string
+		section   string
+		key       string
+		wantLayer string
+		wantValue string
+		wantOK    bool
+	}{
+		{"1", "", "name", "defaults", "default", true},
+		{"2", "", "port", "user", "8080", true},
+		{"3", "log", "level", "cmdline", "debug", true},
+		{"4", "log", "n.a.", "", "", false},
+		{"5", "n.a.", "port", "", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layer, ok := cs.Explain(tt.section, tt.key)
+			if (layer != tt.wantLayer) || (ok != tt.wantOK) {
+				t.Errorf("%q: TConfigStack.Explain() = %q, %v, want %q, %v",
+					tt.name, layer, ok, tt.wantLayer, tt.wantOK)
+			}
+			value, ok := cs.AsString(tt.section, tt.key)
+			if (value != tt.wantValue) || (ok != tt.wantOK) {
+				t.Errorf("%q: TConfigStack.AsString() = %q, %v, want %q, %v",
+					tt.name, value, ok, tt.wantValue, tt.wantOK)
+			}
+		})
+	}
+
+	if _, err := cs.GetString("log", "n.a."); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("TConfigStack.GetString() error = %v, want %v", err, ErrKeyNotFound)
+	}
+} // TestTConfigStack_Explain()
+
+func TestTConfigStack_Flatten(t *testing.T) {
+	cs := prepConfigStack()
+	if 3 != cs.Len() {
+		t.Errorf("TConfigStack.Len() = %d, want 3", cs.Len())
+	}
+	if nil == cs.Layer("user") || nil != cs.Layer("n.a.") {
+		t.Error("TConfigStack.Layer() returned unexpected result")
+	}
+
+	sl := cs.Flatten()
+	for _, want := range [][3]string{
+		{"", "name", "default"},
+		{"", "port", "8080"},
+		{"log", "level", "debug"},
+	} {
+		if got, _ := sl.AsString(want[0], want[1]); got != want[2] {
+			t.Errorf("TConfigStack.Flatten() [%s] %s = %q, want %q",
+				want[0], want[1], got, want[2])
+		}
+	}
+} // TestTConfigStack_Flatten()
+
+func TestTConfigStack_StoreEffective(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "effective.ini")
+	if _, err := prepConfigStack().StoreEffective(fName); nil != err {
+		t.Fatalf("TConfigStack.StoreEffective() error = %v", err)
+	}
+	data, _ := os.ReadFile(fName)
+	for _, want := range []string{
+		"; source: defaults\nname = default\n",
+		"; source: user\nport = 8080\n",
+		"; source: cmdline\nlevel = debug\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("TConfigStack.StoreEffective() wrote\n%s\nwant %q", data, want)
+		}
+	}
+} // TestTConfigStack_StoreEffective()
+
+/* _EoF_ */
@@ -0,0 +1,55 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "time"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TStats` holds statistics about a list's size.
+	//
+	// see `Stats()`
+	TStats struct {
+		Keys           int           // total number of keys
+		LargestKeys    int           // number of keys of the largest section
+		LargestSection string        // name of the section with the most keys
+		ParseDuration  time.Duration // time spent reading the INI data last
+		Sections       int           // number of sections
+		ValueBytes     int           // total length of all values
+	}
+)
+
+// `Stats()` returns statistics about the list's size for capacity
+// monitoring.
+//
+// The keys of all sections are indexed, so their lookup doesn't
+// slow down with the sections' growing size; the number of keys is
+// relevant for the memory used, though.
+//
+// Returns:
+// - `TStats`: The list's current statistics.
+func (sl *TSectionList) Stats() TStats {
+	result := TStats{
+		ParseDuration: sl.parseTime,
+	}
+	for _, ns := range sl.OrderedSections() {
+		kvl := ns.Section.KeyVals()
+		result.Sections++
+		result.Keys += len(kvl)
+		for _, kv := range kvl {
+			result.ValueBytes += len(kv.Value)
+		}
+		if ("" == result.LargestSection) || (len(kvl) > result.LargestKeys) {
+			result.LargestSection, result.LargestKeys = ns.Name, len(kvl)
+		}
+	}
+
+	return result
+} // Stats()
+
+/* _EoF_ */
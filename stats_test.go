@@ -0,0 +1,49 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import "testing"
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Stats(t *testing.T) {
+	parsed, err := ParseBytes([]byte("[a]\nk1 = one\n[b]\nk1 = 1\nk2 = 22\nk3 = 333\n"))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		list *TSectionList
+		want TStats
+	}{
+		{"1", NewSectionList(), TStats{}},
+		{"2", parsed, TStats{
+			Keys:           4,
+			LargestKeys:    3,
+			LargestSection: "b",
+			Sections:       2,
+			ValueBytes:     9,
+		}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.list.Stats()
+			got.ParseDuration = 0
+			if got != tt.want {
+				t.Errorf("%q: TSectionList.Stats() = %+v, want %+v",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+	if 0 >= parsed.Stats().ParseDuration {
+		t.Error("TSectionList.Stats() ParseDuration = 0 after reading")
+	}
+} // TestTSectionList_Stats()
+
+/* _EoF_ */
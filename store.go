@@ -0,0 +1,407 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tFileMode` determines the permissions and ownership of the
+	// INI files written.
+	tFileMode struct {
+		force     bool        // apply `perm` to existing files as well
+		keepOwner bool        // (try to) preserve an existing file's owner
+		perm      fs.FileMode // the permissions of new files
+	}
+)
+
+const (
+	// `DefFileMode` is the file mode used for newly created INI files.
+	//
+	// Since configuration files often contain credentials, they are
+	// readable by their owner only.
+	DefFileMode fs.FileMode = 0600
+)
+
+// `permissions()` returns the permissions to use for a new file.
+//
+// Returns:
+// - `fs.FileMode`: The configured permissions or `DefFileMode`.
+func (fm tFileMode) permissions() fs.FileMode {
+	if 0 == fm.perm.Perm() {
+		return DefFileMode
+	}
+
+	return fm.perm.Perm()
+} // permissions()
+
+// `atomicWriteFile()` writes `aData` to `aFilename` by way of a
+// temporary file in the same directory which is synced to disk and
+// then renamed to `aFilename`.
+//
+// Thus, the file either holds the old or the new data but never a
+// partially written state. The original file's mode is preserved
+// unless `aMode` enforces its permissions; new files are created with
+// the permissions of `aMode`. If `aFilename` is a symbolic link the
+// file it points to is replaced while the link is kept.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+// - `aData` The data to write.
+// - `aMode` The file's permissions and ownership.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func atomicWriteFile(aFilename string, aData []byte, aMode tFileMode) (int, error) {
+	if "" == aFilename {
+		return 0, fs.ErrNotExist
+	}
+	// replace the link's target instead of the link itself:
+	if target, err := filepath.EvalSymlinks(aFilename); nil == err {
+		aFilename = target
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+	mode, fi := aMode.permissions(), fs.FileInfo(nil)
+	if info, err := os.Stat(aFilename); nil == err {
+		fi = info
+		if !aMode.force {
+			mode = info.Mode().Perm()
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+
+	dir, base := filepath.Split(aFilename)
+	if "" == dir {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".*.tmp")
+	if nil != err {
+		return 0, err
+	}
+	tmpName := tmp.Name()
+	// clean up in case of errors:
+	defer os.Remove(tmpName)
+
+	n, err := tmp.Write(aData)
+	if nil == err {
+		err = tmp.Sync()
+	}
+	if cErr := tmp.Close(); nil == err {
+		err = cErr
+	}
+	if nil == err {
+		err = os.Chmod(tmpName, mode)
+	}
+	if nil != err {
+		return n, err
+	}
+	if aMode.keepOwner && (nil != fi) {
+		// Changing the ownership usually requires special privileges;
+		// failing to do so shouldn't prevent storing the data.
+		if uid, gid, ok := fileOwner(fi); ok {
+			_ = os.Chown(tmpName, uid, gid)
+		}
+	}
+
+	if err = os.Rename(tmpName, aFilename); nil != err {
+		return n, err
+	}
+	syncDir(dir)
+
+	return n, nil
+} // atomicWriteFile()
+
+// `writeFile()` writes `aData` to `aFilename`.
+//
+// An existing file keeps its mode and owner unless `aMode` enforces
+// its permissions; new files are created with the permissions of
+// `aMode`.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+// - `aData` The data to write.
+// - `aMode` The file's permissions.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func writeFile(aFilename string, aData []byte, aMode tFileMode) (int, error) {
+	file, err := os.OpenFile(aFilename,
+		os.O_WRONLY|os.O_CREATE|os.O_TRUNC, aMode.permissions())
+	if nil != err {
+		return 0, err
+	}
+
+	n, err := file.Write(aData)
+	if (nil == err) && aMode.force {
+		err = file.Chmod(aMode.permissions())
+	}
+	if cErr := file.Close(); nil == err {
+		err = cErr
+	}
+
+	return n, err
+} // writeFile()
+
+// `rotateBackups()` rotates the backups of `aFilename` and copies the
+// file to its first backup.
+//
+// Parameters:
+// - `aFilename` The name of the file to backup.
+// - `aCount` The number of backups to keep.
+//
+// Returns:
+// - `error`: A possible error condition.
+func rotateBackups(aFilename string, aCount int) error {
+	if 0 >= aCount {
+		return nil
+	}
+	data, err := os.ReadFile(aFilename)
+	if nil != err {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil // nothing to backup
+		}
+		return err
+	}
+	info, err := os.Stat(aFilename)
+	if nil != err {
+		return err
+	}
+
+	backup := func(aNum int) string {
+		return aFilename + "." + strconv.Itoa(aNum)
+	}
+	if err = os.Remove(backup(aCount)); (nil != err) && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for num := aCount - 1; 0 < num; num-- {
+		err = os.Rename(backup(num), backup(num+1))
+		if (nil != err) && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	_, err = writeFile(backup(1), data, tFileMode{force: true, perm: info.Mode()})
+
+	return err
+} // rotateBackups()
+
+// `syncDir()` flushes the directory entry of `aDir` to disk (where
+// supported) so that a rename survives a crash.
+//
+// Parameters:
+// - `aDir` The directory to sync.
+func syncDir(aDir string) {
+	if dir, err := os.Open(aDir); nil == err {
+		_ = dir.Sync() // not supported on all platforms
+		_ = dir.Close()
+	}
+} // syncDir()
+
+// `fileMode()` returns the permissions and ownership of the INI files
+// written by the list.
+//
+// Returns:
+// - `tFileMode`: The list's file mode.
+func (sl *TSectionList) fileMode() tFileMode {
+	return tFileMode{
+		force:     sl.forcePerm,
+		keepOwner: sl.keepOwner,
+		perm:      sl.filePerm,
+	}
+} // fileMode()
+
+// `SetFileMode()` sets the permissions of the INI files written by
+// `Store()` and `StoreAs()`.
+//
+// New files are always created with `aPerm` (or `DefFileMode` if zero).
+// If `aPreserve` is `true` an existing file keeps its mode and (if
+// possible) its owner and group, even when it's replaced in atomic
+// mode (see `SetAtomicStore()`); otherwise its permissions are set to
+// `aPerm`. By default new files get `DefFileMode` while existing files
+// keep their mode.
+//
+// Parameters:
+// - `aPerm` The permissions of the INI files.
+// - `aPreserve` Whether to keep the mode and owner of existing files.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetFileMode(aPerm fs.FileMode, aPreserve bool) *TSectionList {
+	sl.filePerm = aPerm.Perm()
+	sl.forcePerm = !aPreserve
+	sl.keepOwner = aPreserve
+
+	return sl
+} // SetFileMode()
+
+// `storeFile()` writes all INI data to `aFilename` using the list's
+// layout, encoding, and atomic mode.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+// - `aMode` The file's permissions and ownership.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) storeFile(aFilename string, aMode tFileMode) (int, error) {
+	data, err := sl.storeData()
+	if nil != err {
+		return 0, err
+	}
+
+	return sl.writeData(aFilename, data, aMode)
+} // storeFile()
+
+// `storeData()` returns the INI data to write using the list's layout,
+// encoding, and integrity settings.
+//
+// Returns:
+// - `[]byte`: The INI data to write.
+// - `error`: A possible encoding error.
+func (sl *TSectionList) storeData() ([]byte, error) {
+	return sl.encode(sl.integrityText(sl.String()))
+} // storeData()
+
+// `writeData()` writes `aData` to `aFilename` observing the list's
+// atomic mode (see `SetAtomicStore()`).
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+// - `aData` The data to write.
+// - `aMode` The file's permissions and ownership.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) writeData(aFilename string, aData []byte, aMode tFileMode) (int, error) {
+	if sl.atomicStore {
+		return atomicWriteFile(aFilename, aData, aMode)
+	}
+
+	return writeFile(aFilename, aData, aMode)
+} // writeData()
+
+// `SetAtomicStore()` sets whether `Store()` should write the INI file
+// atomically.
+//
+// In atomic mode the data is written to a temporary file in the same
+// directory which, after syncing it to disk, replaces the INI file.
+// So a crash while writing can't corrupt the INI file. The new file
+// gets the permissions and ownership configured by `SetFileMode()`.
+//
+// Parameters:
+// - `aAtomic` Whether to write the INI file atomically.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetAtomicStore(aAtomic bool) *TSectionList {
+	sl.atomicStore = aAtomic
+
+	return sl
+} // SetAtomicStore()
+
+// `StoreAs()` writes all INI data to `aFilename` like `Store()` does.
+//
+// Unlike `SetFilename()` followed by `Store()` this neither changes the
+// list's filename nor its modification tracking (see `IsDirty()`), so
+// it can be used to export a copy of the list.
+//
+// Parameters:
+// - `aFilename` The name of the file to write.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreAs(aFilename string) (int, error) {
+	if aFilename = strings.TrimSpace(aFilename); "" == aFilename {
+		return 0, fs.ErrNotExist
+	}
+
+	return sl.storeFile(aFilename, sl.fileMode())
+} // StoreAs()
+
+// `StoreWithMode()` writes all INI data to the configured filename
+// like `Store()` does, setting the file's permissions to `aPerm`.
+//
+// This applies to an existing file as well; the list's settings (see
+// `SetFileMode()`) are not changed.
+//
+// Parameters:
+// - `aPerm` The permissions of the INI file.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreWithMode(aPerm fs.FileMode) (rWritten int, rErr error) {
+	mode := sl.fileMode()
+	mode.force, mode.perm = true, aPerm
+
+	if rWritten, rErr = sl.storeFile(sl.fName, mode); nil == rErr {
+		sl.clearChanges()
+	}
+
+	return
+} // StoreWithMode()
+
+// `StoreWithBackup()` writes all INI data to the configured filename
+// like `Store()` does after keeping up to `aCount` backups of the
+// current INI file.
+//
+// The backups are rotated, i.e. `file.ini` is copied to `file.ini.1`,
+// a former `file.ini.1` is renamed to `file.ini.2` and so on up to
+// `file.ini.<aCount>`; older backups are removed. The backups keep the
+// INI file's mode. If `aCount` isn't positive no backup is made.
+//
+// Parameters:
+// - `aCount` The number of backups to keep.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreWithBackup(aCount int) (int, error) {
+	if err := rotateBackups(sl.fName, aCount); nil != err {
+		return 0, err
+	}
+
+	return sl.Store()
+} // StoreWithBackup()
+
+// `StoreTo()` writes all INI data to `aWriter` using the list's layout
+// and encoding (see `SetFormatOptions()` and `SetEncoding()`).
+//
+// The list's state (e.g. its modification tracking) isn't changed.
+//
+// Parameters:
+// - `aWriter` The writer to use.
+//
+// Returns:
+// - `int`: The number of bytes written.
+// - `error`: A possible error condition.
+func (sl *TSectionList) StoreTo(aWriter io.Writer) (int, error) {
+	data, err := sl.storeData()
+	if nil != err {
+		return 0, err
+	}
+
+	return aWriter.Write(data)
+} // StoreTo()
+
+/* _EoF_ */
@@ -0,0 +1,234 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_atomicWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	fName := filepath.Join(dir, "atomic.ini")
+	if err := os.WriteFile(fName, []byte("old"), 0600); nil != err {
+		t.Fatal(err)
+	}
+
+	data := []byte("[s]\nkey = new\n")
+	n, err := atomicWriteFile(fName, data, tFileMode{keepOwner: true})
+	if nil != err {
+		t.Fatalf("atomicWriteFile() error = %v", err)
+	}
+	if len(data) != n {
+		t.Errorf("atomicWriteFile() = %d, want %d", n, len(data))
+	}
+	if got, _ := os.ReadFile(fName); string(data) != string(got) {
+		t.Errorf("atomicWriteFile() wrote %q, want %q", got, data)
+	}
+	if "windows" != runtime.GOOS {
+		if fi, _ := os.Stat(fName); 0600 != fi.Mode().Perm() {
+			t.Errorf("atomicWriteFile() mode = %v, want %v",
+				fi.Mode().Perm(), os.FileMode(0600))
+		}
+	}
+	if entries, _ := os.ReadDir(dir); 1 != len(entries) {
+		t.Errorf("atomicWriteFile() left %d files, want 1", len(entries))
+	}
+
+	if _, err := atomicWriteFile("", data, tFileMode{}); nil == err {
+		t.Error("atomicWriteFile(\"\") expected an error")
+	}
+} // Test_atomicWriteFile()
+
+func Test_atomicWriteFile_symlink(t *testing.T) {
+	targetDir, linkDir := t.TempDir(), t.TempDir()
+	target := filepath.Join(targetDir, "app.ini")
+	if err := os.WriteFile(target, []byte("old"), 0600); nil != err {
+		t.Fatal(err)
+	}
+	link := filepath.Join(linkDir, "link.ini")
+	if err := os.Symlink(target, link); nil != err {
+		t.Skipf("symbolic links not supported: %v", err)
+	}
+
+	sl := NewSectionList().SetFilename(link).SetAtomicStore(true)
+	_ = sl.AddSectionKey("s", "key", "value")
+	if _, err := sl.Store(); nil != err {
+		t.Fatalf("TSectionList.Store() error = %v", err)
+	}
+
+	if fi, err := os.Lstat(link); (nil != err) || (0 == fi.Mode()&os.ModeSymlink) {
+		t.Fatalf("atomicWriteFile() replaced the symbolic link: %v", err)
+	}
+	got, err := NewIni(target)
+	if nil != err {
+		t.Fatalf("NewIni() error = %v", err)
+	}
+	if value, _ := got.AsString("s", "key"); "value" != value {
+		t.Errorf("atomicWriteFile() target value = %q, want %q", value, "value")
+	}
+	for _, dir := range []string{targetDir, linkDir} {
+		if entries, _ := os.ReadDir(dir); 1 != len(entries) {
+			t.Errorf("atomicWriteFile() left %d files in %q, want 1", len(entries), dir)
+		}
+	}
+} // Test_atomicWriteFile_symlink()
+
+func TestTSectionList_SetAtomicStore(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "new.ini")
+	sl := NewSectionList().SetFilename(fName).SetAtomicStore(true)
+	_ = sl.AddSectionKey("s", "key", "value")
+
+	if _, err := sl.Store(); nil != err {
+		t.Fatalf("TSectionList.Store() error = %v", err)
+	}
+	got, err := NewIni(fName)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if val, _ := got.AsString("s", "key"); "value" != val {
+		t.Errorf("TSectionList.Store() stored %q, want %q", val, "value")
+	}
+
+	// the owner preservation is set by `SetFileMode()` only:
+	sl.SetFileMode(0, true).SetAtomicStore(false)
+	if !sl.fileMode().keepOwner {
+		t.Error("TSectionList.SetAtomicStore() reset the owner preservation")
+	}
+} // TestTSectionList_SetAtomicStore()
+
+func TestTSectionList_StoreAs(t *testing.T) {
+	dir := t.TempDir()
+	fName := filepath.Join(dir, "orig.ini")
+	sl := NewSectionList().SetFilename(fName)
+	_ = sl.AddSectionKey("s", "key", "value")
+
+	copyName := filepath.Join(dir, "copy.ini")
+	if _, err := sl.StoreAs(copyName); nil != err {
+		t.Fatalf("TSectionList.StoreAs() error = %v", err)
+	}
+	if got := sl.Filename(); fName != got {
+		t.Errorf("TSectionList.Filename() = %q, want %q", got, fName)
+	}
+	if !sl.IsDirty() {
+		t.Error("TSectionList.StoreAs() reset the modification tracking")
+	}
+	if _, err := os.Stat(fName); nil == err {
+		t.Error("TSectionList.StoreAs() wrote the list's own file")
+	}
+	got, err := NewIni(copyName)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !sl.CompareTo(got) {
+		t.Errorf("TSectionList.StoreAs() stored\n%s\nwant\n%s", got, sl)
+	}
+
+	if _, err = sl.StoreAs(" "); nil == err {
+		t.Error("TSectionList.StoreAs(\"\") expected an error")
+	}
+} // TestTSectionList_StoreAs()
+
+func TestTSectionList_StoreWithMode(t *testing.T) {
+	if "windows" == runtime.GOOS {
+		t.Skip("file modes are not supported on Windows")
+	}
+	for _, atomic := range []bool{false, true} {
+		fName := filepath.Join(t.TempDir(), "mode.ini")
+		sl := NewSectionList().SetFilename(fName).SetAtomicStore(atomic)
+		_ = sl.AddSectionKey("s", "password", "secret")
+
+		// new files are created with `DefFileMode`:
+		if _, err := sl.Store(); nil != err {
+			t.Fatalf("TSectionList.Store() error = %v", err)
+		}
+		if fi, _ := os.Stat(fName); DefFileMode != fi.Mode().Perm() {
+			t.Errorf("TSectionList.Store() mode = %v, want %v", fi.Mode().Perm(), DefFileMode)
+		}
+
+		// existing files keep their mode:
+		_ = os.Chmod(fName, 0640)
+		if _, err := sl.Store(); nil != err {
+			t.Fatalf("TSectionList.Store() error = %v", err)
+		}
+		if fi, _ := os.Stat(fName); 0640 != fi.Mode().Perm() {
+			t.Errorf("TSectionList.Store() mode = %v, want %v", fi.Mode().Perm(), os.FileMode(0640))
+		}
+
+		// unless enforced:
+		if _, err := sl.StoreWithMode(0400); nil != err {
+			t.Fatalf("TSectionList.StoreWithMode() error = %v", err)
+		}
+		if fi, _ := os.Stat(fName); 0400 != fi.Mode().Perm() {
+			t.Errorf("TSectionList.StoreWithMode() mode = %v, want %v", fi.Mode().Perm(), os.FileMode(0400))
+		}
+		_ = os.Chmod(fName, 0644)
+		if _, err := sl.SetFileMode(0600, false).Store(); nil != err {
+			t.Fatalf("TSectionList.Store() error = %v", err)
+		}
+		if fi, _ := os.Stat(fName); 0600 != fi.Mode().Perm() {
+			t.Errorf("TSectionList.SetFileMode() mode = %v, want %v", fi.Mode().Perm(), os.FileMode(0600))
+		}
+	}
+} // TestTSectionList_StoreWithMode()
+
+func TestTSectionList_StoreWithBackup(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "backup.ini")
+	sl := NewSectionList().SetFilename(fName)
+
+	for _, value := range []string{"1", "2", "3", "4"} {
+		sl.AddSectionKey("s", "version", value)
+		if _, err := sl.StoreWithBackup(2); nil != err {
+			t.Fatalf("TSectionList.StoreWithBackup() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		file  string
+		want  string
+		exist bool
+	}{
+		{"1", fName, "4", true},
+		{"2", fName + ".1", "3", true},
+		{"3", fName + ".2", "2", true},
+		{"4", fName + ".3", "", false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := NewIni(tt.file)
+			if (nil == err) != tt.exist {
+				t.Fatalf("NewIni(%q) error = %v, want existence %v", tt.file, err, tt.exist)
+			}
+			if got, _ := list.AsString("s", "version"); got != tt.want {
+				t.Errorf("NewIni(%q) version = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_StoreWithBackup()
+
+func TestTSectionList_StoreTo(t *testing.T) {
+	sl := NewSectionList().SetEncoding(EncodingISO8859_1)
+	_ = sl.AddSectionKey("s", "key", "Grüße")
+
+	var buf bytes.Buffer
+	n, err := sl.StoreTo(&buf)
+	if nil != err {
+		t.Fatalf("TSectionList.StoreTo() error = %v", err)
+	}
+	if want := "\n[s]\nkey = Gr\xFC\xDFe\n"; (buf.String() != want) || (len(want) != n) {
+		t.Errorf("TSectionList.StoreTo() = %q (%d), want %q", buf.String(), n, want)
+	}
+} // TestTSectionList_StoreTo()
+
+/* _EoF_ */
@@ -0,0 +1,193 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TSectionTree` is a nested view of hierarchical INI sections.
+	//
+	// Section names like `[server.http.tls]` or `[parent "child"]`
+	// (git-config style) are split into their path elements and
+	// each element becomes a node of the tree.
+	TSectionTree struct {
+		Name     string          // the last element of the section path
+		Section  *TSection       // the section's data (`nil` if not in the INI file)
+		Children []*TSectionTree // the nested sections in file order
+	}
+)
+
+// `sectionPath()` splits the given section name into its path elements.
+//
+// Elements are separated by dots (`server.http.tls`) or given as a
+// double-quoted subsection name (`parent "child"`) which may contain
+// dots and whitespace; within quotes a backslash escapes the next
+// character.
+//
+// Parameters:
+// - `aName` The section name to split.
+//
+// Returns:
+// - `[]string`: The section's path elements.
+func sectionPath(aName string) []string {
+	var (
+		elem    strings.Builder
+		inQuote bool
+		result  []string
+	)
+	flush := func() {
+		if name := strings.TrimSpace(elem.String()); "" != name {
+			result = append(result, name)
+		}
+		elem.Reset()
+	}
+
+	for i := 0; i < len(aName); i++ {
+		c := aName[i]
+		switch {
+		case inQuote:
+			if ('\\' == c) && (i+1 < len(aName)) {
+				i++
+				elem.WriteByte(aName[i])
+			} else if '"' == c {
+				inQuote = false
+				result = append(result, elem.String())
+				elem.Reset()
+			} else {
+				elem.WriteByte(c)
+			}
+
+		case '"' == c:
+			flush()
+			inQuote = true
+
+		case '.' == c:
+			flush()
+
+		default:
+			elem.WriteByte(c)
+		}
+	}
+	flush()
+
+	return result
+} // sectionPath()
+
+// `isSubPath()` checks whether `aPath` is nested below `aPrefix`.
+//
+// Parameters:
+// - `aPath` The path to check.
+// - `aPrefix` The parent path.
+//
+// Returns:
+// - `bool`: `true` if `aPath` is longer than and starts with `aPrefix`.
+func isSubPath(aPath, aPrefix []string) bool {
+	if len(aPath) <= len(aPrefix) {
+		return false
+	}
+	for idx, elem := range aPrefix {
+		if aPath[idx] != elem {
+			return false
+		}
+	}
+
+	return true
+} // isSubPath()
+
+// `Child()` returns the node nested below the current one by the
+// given path elements.
+//
+// Parameters:
+// - `aPath` The path elements to follow.
+//
+// Returns:
+// - `*TSectionTree`: The requested node or `nil` if not found.
+func (st *TSectionTree) Child(aPath ...string) *TSectionTree {
+	node := st
+	for _, name := range aPath {
+		var next *TSectionTree
+		for _, child := range node.Children {
+			if child.Name == name {
+				next = child
+				break
+			}
+		}
+		if nil == next {
+			return nil
+		}
+		node = next
+	}
+
+	return node
+} // Child()
+
+// --------------------------------------------------------------------------
+
+// `GetSubsections()` returns the names of all sections nested below
+// the section named `aPrefix`.
+//
+// Both dotted (`[server.http]`) and git-config style (`[server "http"]`)
+// section names are supported, so `GetSubsections("server")` returns
+// both of them. The returned names are in file order and can be used
+// with e.g. `GetSection()`.
+//
+// Parameters:
+// - `aPrefix` The name of the parent section.
+//
+// Returns:
+// - `[]string`: The names of the nested sections.
+func (sl *TSectionList) GetSubsections(aPrefix string) []string {
+	prefix := sectionPath(aPrefix)
+	result := []string{}
+	names, _ := sl.Sections()
+	for _, name := range names {
+		if isSubPath(sectionPath(name), prefix) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+} // GetSubsections()
+
+// `Tree()` returns a nested view of all sections.
+//
+// The returned root node has an empty name and no section data; its
+// children are the top-level sections. Intermediate nodes which don't
+// exist as a section of their own (e.g. `server` for a lone
+// `[server.http]` section) have a `nil` section.
+//
+// The tree shares the sections with the list, so modifications of a
+// node's section are visible in the list as well.
+//
+// Returns:
+// - `*TSectionTree`: The root node of the section tree.
+func (sl *TSectionList) Tree() *TSectionTree {
+	root := &TSectionTree{}
+	for _, ns := range sl.OrderedSections() {
+		name := ns.Name
+		node := root
+		for _, elem := range sectionPath(name) {
+			next := node.Child(elem)
+			if nil == next {
+				next = &TSectionTree{Name: elem}
+				node.Children = append(node.Children, next)
+			}
+			node = next
+		}
+		if node != root {
+			node.Section = ns.Section
+		}
+	}
+
+	return root
+} // Tree()
+
+/* _EoF_ */
@@ -0,0 +1,114 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func prepSubsectionList() *TSectionList {
+	sl := NewSectionList()
+	_, _ = sl.read(bufio.NewScanner(strings.NewReader(`
+[server]
+name = main
+[server.http]
+port = 80
+[server.http.tls]
+port = 443
+[remote "origin"]
+url = https://example.com/repo.git
+[remote "up.stream"]
+url = https://example.org/repo.git
+[my section]
+key = value
+`)))
+
+	return sl
+} // prepSubsectionList()
+
+func Test_sectionPath(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{"0", "", nil},
+		{"1", "server", []string{"server"}},
+		{"2", "server.http.tls", []string{"server", "http", "tls"}},
+		{"3", `remote "origin"`, []string{"remote", "origin"}},
+		{"4", `remote "up.stream"`, []string{"remote", "up.stream"}},
+		{"5", `a.b "c \"d\""`, []string{"a", "b", `c "d"`}},
+		{"6", "my section", []string{"my section"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sectionPath(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: sectionPath() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_sectionPath()
+
+func TestTSectionList_GetSubsections(t *testing.T) {
+	sl := prepSubsectionList()
+	tests := []struct {
+		name string
+		args string
+		want []string
+	}{
+		{"1", "server", []string{"server.http", "server.http.tls"}},
+		{"2", "server.http", []string{"server.http.tls"}},
+		{"3", `server "http"`, []string{"server.http.tls"}},
+		{"4", "remote", []string{`remote "origin"`, `remote "up.stream"`}},
+		{"5", "my section", []string{}},
+		{"6", "n.a.", []string{}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sl.GetSubsections(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.GetSubsections() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // TestTSectionList_GetSubsections()
+
+func TestTSectionList_Tree(t *testing.T) {
+	sl := prepSubsectionList()
+	root := sl.Tree()
+
+	if got := len(root.Children); 3 != got {
+		t.Fatalf("TSectionList.Tree() top-level nodes = %d, want 3", got)
+	}
+	tls := root.Child("server", "http", "tls")
+	if nil == tls {
+		t.Fatal("TSectionList.Tree() missing node server.http.tls")
+	}
+	if got, _ := tls.Section.AsString("port"); "443" != got {
+		t.Errorf("TSectionList.Tree() tls port = %q, want %q", got, "443")
+	}
+	remote := root.Child("remote")
+	if nil == remote || nil != remote.Section {
+		t.Fatal("TSectionList.Tree() expected intermediate node remote")
+	}
+	if got := remote.Child("up.stream"); nil == got || nil == got.Section {
+		t.Error("TSectionList.Tree() missing node remote/up.stream")
+	}
+	if got := root.Child("n.a."); nil != got {
+		t.Errorf("TSectionList.Tree() unexpected node %v", got)
+	}
+} // TestTSectionList_Tree()
+
+/* _EoF_ */
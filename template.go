@@ -0,0 +1,160 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"reflect"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `commentTag` is the name of the struct tag holding a field's
+	// description used by `GenerateDefault()`.
+	commentTag = `comment`
+
+	// `defaultTag` is the name of the struct tag holding a field's
+	// default value used by `GenerateDefault()`.
+	defaultTag = `default`
+)
+
+// `tagComment()` returns the comment lines of `aField`'s comment tag.
+//
+// Parameters:
+// - `aField` The struct field to inspect.
+//
+// Returns:
+// - `[]string`: The comment lines (or `nil` if there's no comment).
+func tagComment(aField reflect.StructField) []string {
+	text := strings.TrimSpace(aField.Tag.Get(commentTag))
+	if "" == text {
+		return nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = "; " + strings.TrimSpace(line)
+	}
+
+	return lines
+} // tagComment()
+
+// `templateSection()` applies the default and comment tags of the key
+// fields of `aStruct` to `aSection` of `aList`.
+//
+// Parameters:
+// - `aList` The section list to update.
+// - `aSection` The name of the INI section to use.
+// - `aStruct` The struct value to inspect.
+func templateSection(aList *TSectionList, aSection string, aStruct reflect.Value) {
+	kl := aList.GetSection(aSection)
+	sType := aStruct.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if !ok || isSectionField(field.Type) {
+			continue
+		}
+		if def, found := field.Tag.Lookup(defaultTag); found && aStruct.Field(i).IsZero() {
+			aList.updateSectKey(aSection, name, def)
+		}
+		if comments := tagComment(field); nil != comments {
+			kl.setComment(name, comments)
+		}
+	}
+} // templateSection()
+
+// `GenerateDefault()` returns a section list holding the default
+// configuration described by the given struct.
+//
+// The struct is converted like by `Marshal()`; additionally a field's
+// `default:"..."` tag provides the value of fields holding their zero
+// value while a `comment:"..."` tag (where `\n` separates lines) is
+// attached as a comment to the key or section. Sections represented by
+// `nil` pointers are included as well.
+//
+// Example:
+//
+//	type tConfig struct {
+//		Name   string `ini:"name" default:"myApp" comment:"The application's name"`
+//		Server struct {
+//			Port int `ini:"port" default:"8080" comment:"The port to listen on"`
+//		} `ini:"server" comment:"Web server settings"`
+//	}
+//	sl, err := ini.GenerateDefault(tConfig{})
+//
+// Parameters:
+// - `aStruct` The struct (or pointer to struct) describing the configuration.
+//
+// Returns:
+// - `*TSectionList`: The default configuration.
+// - `error`: A possible conversion error.
+func GenerateDefault(aStruct any) (*TSectionList, error) {
+	result, err := Marshal(aStruct)
+	if nil != err {
+		return result, err
+	}
+
+	sValue := reflect.ValueOf(aStruct)
+	if reflect.Pointer == sValue.Kind() {
+		sValue = sValue.Elem()
+	}
+	templateSection(result, result.defSect, sValue)
+
+	sType := sValue.Type()
+	for i := 0; i < sType.NumField(); i++ {
+		field := sType.Field(i)
+		name, ok := fieldName(field)
+		if !ok || !isSectionField(field.Type) {
+			continue
+		}
+
+		fValue := sValue.Field(i)
+		if reflect.Pointer == fValue.Kind() {
+			if fValue.IsNil() {
+				fValue = reflect.New(fValue.Type().Elem())
+				result.addSection(name)
+				if err = marshalSection(result, name, fValue.Elem(), true); nil != err {
+					return result, err
+				}
+			}
+			fValue = fValue.Elem()
+		}
+		templateSection(result, name, fValue)
+		if comments := tagComment(field); nil != comments {
+			result.setSectionComment(name, comments)
+		}
+	}
+
+	return result, nil
+} // GenerateDefault()
+
+// `GenerateCommentedTemplate()` returns the INI text of the default
+// configuration described by the given struct including the comments
+// of the fields' `comment:"..."` tags.
+//
+// This can be used to write an example configuration file (e.g. for
+// a `--write-default-config` commandline option).
+// See `GenerateDefault()` for the supported struct tags.
+//
+// Parameters:
+// - `aStruct` The struct (or pointer to struct) describing the configuration.
+//
+// Returns:
+// - `string`: The commented INI text.
+// - `error`: A possible conversion error.
+func GenerateCommentedTemplate(aStruct any) (string, error) {
+	sl, err := GenerateDefault(aStruct)
+	if nil != err {
+		return "", err
+	}
+
+	return sl.String(), nil
+} // GenerateCommentedTemplate()
+
+/* _EoF_ */
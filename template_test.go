@@ -0,0 +1,98 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type tTemplateConfig struct {
+	Name   string `ini:"name" default:"myApp" comment:"The application's name"`
+	Debug  bool   `ini:"debug"`
+	Server struct {
+		Host string `ini:"host" default:"localhost"`
+		Port int    `ini:"port" default:"8080" comment:"The port to listen on\nUse 0 for a random port"`
+	} `ini:"server" comment:"Web server settings"`
+	Log *struct {
+		Level string `ini:"level" default:"info"`
+	} `ini:"log"`
+}
+
+func TestGenerateDefault(t *testing.T) {
+	cfg := tTemplateConfig{Name: "custom"}
+	sl, err := GenerateDefault(&cfg)
+	if nil != err {
+		t.Fatalf("GenerateDefault() error = %v", err)
+	}
+
+	tests := []struct {
+		section string
+		key     string
+		want    string
+	}{
+		{"", "name", "custom"}, // non-zero value wins
+		{"", "debug", "false"},
+		{"server", "host", "localhost"},
+		{"server", "port", "8080"},
+		{"log", "level", "info"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got, _ := sl.AsString(tt.section, tt.key); got != tt.want {
+				t.Errorf("GenerateDefault() [%s] %s = %q, want %q",
+					tt.section, tt.key, got, tt.want)
+			}
+		})
+	}
+
+	if _, err = GenerateDefault(42); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("GenerateDefault() error = %v, want %v", err, ErrInvalidTarget)
+	}
+} // TestGenerateDefault()
+
+func TestGenerateCommentedTemplate(t *testing.T) {
+	want := `
+[Default]
+; The application's name
+name = myApp
+debug = false
+
+; Web server settings
+[server]
+host = localhost
+; The port to listen on
+; Use 0 for a random port
+port = 8080
+
+[log]
+level = info
+`
+	got, err := GenerateCommentedTemplate(tTemplateConfig{})
+	if nil != err {
+		t.Fatalf("GenerateCommentedTemplate() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("GenerateCommentedTemplate() =\n%s\nwant\n%s", got, want)
+	}
+
+	// and the template can be read back:
+	back := NewSectionList()
+	if _, err = back.read(back.newScanner(context.Background(), strings.NewReader(got))); nil != err {
+		t.Fatalf("reading template: %v", err)
+	}
+	if port, _ := back.AsInt("server", "port"); 8080 != port {
+		t.Errorf("template port = %d, want 8080", port)
+	}
+} // TestGenerateCommentedTemplate()
+
+/* _EoF_ */
@@ -0,0 +1,626 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+var (
+	// `ErrInvalidTOML` is returned if the TOML data can't be parsed
+	// or doesn't fit into the INI structure.
+	ErrInvalidTOML = errors.New("ini: invalid TOML data")
+)
+
+type (
+	// `tTOMLParser` is a minimal TOML reader for the subset of TOML
+	// which maps to INI sections: tables holding scalar values and
+	// arrays of scalar values.
+	tTOMLParser struct {
+		src  string // the TOML data to parse
+		pos  int    // the current read position
+		line int    // the current line number (for error messages)
+	}
+)
+
+// `isBareKey()` checks whether `aKey` can be written as a TOML bare key.
+//
+// Parameters:
+// - `aKey` The key to check.
+//
+// Returns:
+// - `bool`: `true` if no quoting is needed, `false` otherwise.
+func isBareKey(aKey string) bool {
+	if "" == aKey {
+		return false
+	}
+	for _, c := range aKey {
+		if !(('a' <= c && 'z' >= c) || ('A' <= c && 'Z' >= c) ||
+			('0' <= c && '9' >= c) || ('_' == c) || ('-' == c)) {
+			return false
+		}
+	}
+
+	return true
+} // isBareKey()
+
+// `tomlQuote()` returns `aString` as a TOML basic string.
+//
+// Parameters:
+// - `aString` The string to quote.
+//
+// Returns:
+// - `string`: The quoted string.
+func tomlQuote(aString string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+	for _, c := range aString {
+		switch c {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if (0x20 > c) || (0x7f == c) {
+				fmt.Fprintf(&sb, `\u%04X`, c)
+			} else {
+				sb.WriteRune(c)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+} // tomlQuote()
+
+// `tomlKey()` returns `aKey` either as a bare or as a quoted TOML key.
+//
+// Parameters:
+// - `aKey` The key to convert.
+//
+// Returns:
+// - `string`: The TOML key.
+func tomlKey(aKey string) string {
+	if isBareKey(aKey) {
+		return aKey
+	}
+
+	return tomlQuote(aKey)
+} // tomlKey()
+
+// `tomlTableName()` returns the TOML table header name of `aSection`.
+//
+// Dotted section names consisting of bare keys (`server.http`) are
+// written as they are, thus becoming nested TOML tables; all other
+// names are quoted.
+//
+// Parameters:
+// - `aSection` The section name to convert.
+//
+// Returns:
+// - `string`: The TOML table name.
+func tomlTableName(aSection string) string {
+	for _, part := range strings.Split(aSection, ".") {
+		if !isBareKey(part) {
+			return tomlQuote(aSection)
+		}
+	}
+
+	return aSection
+} // tomlTableName()
+
+// `tomlValue()` returns `aValue` as a TOML value.
+//
+// Integers (in canonical notation) and the booleans `true` and `false`
+// are written as they are; everything else becomes a TOML string.
+//
+// Parameters:
+// - `aValue` The INI value to convert.
+//
+// Returns:
+// - `string`: The TOML value.
+func tomlValue(aValue string) string {
+	if ("true" == aValue) || ("false" == aValue) {
+		return aValue
+	}
+	if i64, err := strconv.ParseInt(aValue, 10, 64); (nil == err) &&
+		(strconv.FormatInt(i64, 10) == aValue) {
+		return aValue
+	}
+
+	return tomlQuote(aValue)
+} // tomlValue()
+
+// --------------------------------------------------------------------------
+
+// `errorf()` returns an `ErrInvalidTOML` error mentioning the current line.
+//
+// Parameters:
+// - `aFormat` The format string of the error message.
+// - `aArgs` The arguments of the format string.
+//
+// Returns:
+// - `error`: The error wrapping `ErrInvalidTOML`.
+func (tp *tTOMLParser) errorf(aFormat string, aArgs ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidTOML, tp.line,
+		fmt.Sprintf(aFormat, aArgs...))
+} // errorf()
+
+// `eof()` returns whether all data was read.
+func (tp *tTOMLParser) eof() bool {
+	return tp.pos >= len(tp.src)
+} // eof()
+
+// `peek()` returns the next byte without consuming it (`0` at EOF).
+func (tp *tTOMLParser) peek() byte {
+	if tp.eof() {
+		return 0
+	}
+
+	return tp.src[tp.pos]
+} // peek()
+
+// `skipSpace()` skips blanks and tabs.
+func (tp *tTOMLParser) skipSpace() {
+	for c := tp.peek(); (' ' == c) || ('\t' == c); c = tp.peek() {
+		tp.pos++
+	}
+} // skipSpace()
+
+// `skipComment()` skips a comment up to (but not including) the
+// line's end.
+func (tp *tTOMLParser) skipComment() {
+	if '#' != tp.peek() {
+		return
+	}
+	if idx := strings.IndexByte(tp.src[tp.pos:], '\n'); 0 <= idx {
+		tp.pos += idx
+	} else {
+		tp.pos = len(tp.src)
+	}
+} // skipComment()
+
+// `skipBlank()` skips whitespace, line ends, and comments.
+func (tp *tTOMLParser) skipBlank() {
+	for {
+		tp.skipSpace()
+		tp.skipComment()
+		switch tp.peek() {
+		case '\n':
+			tp.line++
+			tp.pos++
+		case '\r':
+			tp.pos++
+		default:
+			return
+		}
+	}
+} // skipBlank()
+
+// `endOfLine()` consumes the rest of the current line which may only
+// hold whitespace and a comment.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) endOfLine() error {
+	tp.skipSpace()
+	tp.skipComment()
+	switch tp.peek() {
+	case 0, '\n':
+		return nil
+	case '\r':
+		tp.pos++
+		if '\n' == tp.peek() {
+			return nil
+		}
+	}
+
+	return tp.errorf("unexpected %q", tp.peek())
+} // endOfLine()
+
+// `readString()` reads a single line basic (`"`) or literal (`'`)
+// string or their multi-line variants using triple quotes.
+//
+// Returns:
+// - `string`: The string's value.
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) readString() (string, error) {
+	quote := tp.peek()
+	delim := string(quote)
+	if strings.HasPrefix(tp.src[tp.pos:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	tp.pos += len(delim)
+	multiLine := 3 == len(delim)
+	if multiLine {
+		// a newline immediately following the delimiter is trimmed:
+		if strings.HasPrefix(tp.src[tp.pos:], "\r\n") {
+			tp.pos += 2
+			tp.line++
+		} else if '\n' == tp.peek() {
+			tp.pos++
+			tp.line++
+		}
+	}
+
+	var sb strings.Builder
+	for !tp.eof() {
+		if strings.HasPrefix(tp.src[tp.pos:], delim) {
+			tp.pos += len(delim)
+			return sb.String(), nil
+		}
+		c := tp.src[tp.pos]
+		if '\n' == c {
+			if !multiLine {
+				break
+			}
+			tp.line++
+		}
+		if ('\\' == c) && ('"' == quote) {
+			if err := tp.readEscape(&sb, multiLine); nil != err {
+				return "", err
+			}
+			continue
+		}
+		sb.WriteByte(c)
+		tp.pos++
+	}
+
+	return "", tp.errorf("unterminated string")
+} // readString()
+
+// `readEscape()` reads an escape sequence within a basic string.
+//
+// Parameters:
+// - `aBuilder` The builder to write the unescaped character to.
+// - `aMultiLine` Whether a line ending backslash is allowed.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) readEscape(aBuilder *strings.Builder, aMultiLine bool) error {
+	tp.pos++ // skip the backslash
+	c := tp.peek()
+	tp.pos++
+	switch c {
+	case 'b':
+		aBuilder.WriteByte('\b')
+	case 'e':
+		aBuilder.WriteByte(0x1b)
+	case 'f':
+		aBuilder.WriteByte('\f')
+	case 'n':
+		aBuilder.WriteByte('\n')
+	case 'r':
+		aBuilder.WriteByte('\r')
+	case 't':
+		aBuilder.WriteByte('\t')
+	case '"', '\\':
+		aBuilder.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if 'U' == c {
+			size = 8
+		}
+		if tp.pos+size > len(tp.src) {
+			return tp.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(tp.src[tp.pos:tp.pos+size], 16, 32)
+		if (nil != err) || !utf8.ValidRune(rune(code)) {
+			return tp.errorf("invalid unicode escape")
+		}
+		aBuilder.WriteRune(rune(code))
+		tp.pos += size
+	case ' ', '\t', '\r', '\n':
+		if !aMultiLine {
+			return tp.errorf("invalid escape sequence")
+		}
+		// line ending backslash: trim all following whitespace
+		tp.pos--
+		for c = tp.peek(); (' ' == c) || ('\t' == c) || ('\r' == c) || ('\n' == c); c = tp.peek() {
+			if '\n' == c {
+				tp.line++
+			}
+			tp.pos++
+		}
+	default:
+		return tp.errorf("invalid escape sequence")
+	}
+
+	return nil
+} // readEscape()
+
+// `readKey()` reads a (possibly dotted) TOML key.
+//
+// Parameters:
+// - `aEnd` The character terminating the key (`=` or `]`).
+//
+// Returns:
+// - `[]string`: The key's parts.
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) readKey(aEnd byte) ([]string, error) {
+	var result []string
+	for {
+		tp.skipSpace()
+		switch c := tp.peek(); {
+		case ('"' == c) || ('\'' == c):
+			part, err := tp.readString()
+			if nil != err {
+				return nil, err
+			}
+			result = append(result, part)
+
+		default:
+			start := tp.pos
+			for c = tp.peek(); isBareKey(string(c)); c = tp.peek() {
+				tp.pos++
+			}
+			if start == tp.pos {
+				return nil, tp.errorf("invalid key")
+			}
+			result = append(result, tp.src[start:tp.pos])
+		}
+
+		tp.skipSpace()
+		switch tp.peek() {
+		case '.':
+			tp.pos++
+		case aEnd:
+			tp.pos++
+			return result, nil
+		default:
+			return nil, tp.errorf("unexpected %q in key", tp.peek())
+		}
+	}
+} // readKey()
+
+// `readValue()` reads a TOML value returning its INI representation.
+//
+// Arrays of scalar values are returned as a list (see `AsStringSlice()`).
+//
+// Parameters:
+// - `aInArray` Whether the value is an array element.
+//
+// Returns:
+// - `string`: The value read.
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) readValue(aInArray bool) (string, error) {
+	switch c := tp.peek(); c {
+	case '"', '\'':
+		return tp.readString()
+
+	case '[':
+		if aInArray {
+			return "", tp.errorf("nested arrays are not supported")
+		}
+		return tp.readArray()
+
+	case '{':
+		return "", tp.errorf("inline tables are not supported")
+
+	case 0, '\n', '\r', '#':
+		return "", tp.errorf("missing value")
+	}
+
+	start := tp.pos
+	for c := tp.peek(); (0 != c) && !strings.ContainsRune(" \t\r\n#,]", rune(c)); c = tp.peek() {
+		tp.pos++
+		// local date/time values may use a blank as separator:
+		if (' ' == tp.peek()) && (10 == tp.pos-start) &&
+			(tp.pos+1 < len(tp.src)) && ('0' <= tp.src[tp.pos+1]) && ('9' >= tp.src[tp.pos+1]) {
+			tp.pos++
+		}
+	}
+	result := tp.src[start:tp.pos]
+	if c := result[0]; ('0' <= c && '9' >= c) || ('+' == c) || ('-' == c) {
+		// remove the digit separators of numbers:
+		result = strings.ReplaceAll(result, "_", "")
+	}
+
+	return result, nil
+} // readValue()
+
+// `readArray()` reads an array of scalar values.
+//
+// Returns:
+// - `string`: The array's items as a list.
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) readArray() (string, error) {
+	tp.pos++ // skip the opening bracket
+	var items []string
+	for {
+		tp.skipBlank()
+		if ']' == tp.peek() {
+			tp.pos++
+			return joinList(items, DefListSeparator), nil
+		}
+		item, err := tp.readValue(true)
+		if nil != err {
+			return "", err
+		}
+		items = append(items, item)
+
+		tp.skipBlank()
+		switch tp.peek() {
+		case ',':
+			tp.pos++
+		case ']':
+		default:
+			return "", tp.errorf("unexpected %q in array", tp.peek())
+		}
+	}
+} // readArray()
+
+// `parse()` reads all TOML data into `aList`.
+//
+// Parameters:
+// - `aList` The list to fill.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (tp *tTOMLParser) parse(aList *TSectionList) error {
+	section := aList.defSect
+	for {
+		tp.skipBlank()
+		if tp.eof() {
+			return nil
+		}
+
+		if '[' == tp.peek() {
+			tp.pos++
+			if '[' == tp.peek() {
+				return tp.errorf("arrays of tables are not supported")
+			}
+			path, err := tp.readKey(']')
+			if nil != err {
+				return err
+			}
+			section = tomlSectionName(path)
+			aList.addSection(section)
+		} else {
+			key, err := tp.readKey('=')
+			if nil != err {
+				return err
+			}
+			tp.skipSpace()
+			value, err := tp.readValue(false)
+			if nil != err {
+				return err
+			}
+			aList.AddSectionKey(section, strings.Join(key, "."), value)
+		}
+
+		if err := tp.endOfLine(); nil != err {
+			return err
+		}
+	}
+} // parse()
+
+// `tomlSectionName()` returns the INI section name for the given TOML
+// table path.
+//
+// The path's parts are joined by dots; parts which contain dots or
+// whitespace themselves are written as quoted subsection names (see
+// `GetSubsections()`).
+//
+// Parameters:
+// - `aPath` The table's path.
+//
+// Returns:
+// - `string`: The section name.
+func tomlSectionName(aPath []string) string {
+	if 1 == len(aPath) {
+		return aPath[0]
+	}
+
+	var sb strings.Builder
+	for idx, part := range aPath {
+		if strings.ContainsAny(part, ". \t\"") {
+			part = strings.ReplaceAll(part, `\`, `\\`)
+			sb.WriteString(` "` + strings.ReplaceAll(part, `"`, `\"`) + `"`)
+			continue
+		}
+		if 0 < idx {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(part)
+	}
+
+	return strings.TrimSpace(sb.String())
+} // tomlSectionName()
+
+// --------------------------------------------------------------------------
+
+// `ToTOML()` writes the list's sections as TOML tables to `aWriter`.
+//
+// The key/value pairs of the default section are written first as
+// the TOML root table's keys. Integer and boolean values are written
+// as TOML integers and booleans, all other values as TOML strings.
+//
+// Parameters:
+// - `aWriter` The writer to write the TOML data to.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) ToTOML(aWriter io.Writer) error {
+	var sb strings.Builder
+
+	writeKeys := func(aSection *TSection) {
+		aSection.mtx.RLock()
+		defer aSection.mtx.RUnlock()
+
+		for _, kv := range aSection.data {
+			sb.WriteString(tomlKey(kv.Key) + " = " + tomlValue(kv.Value) + "\n")
+		}
+	}
+
+	if kl, ok := sl.section(sl.defSect); ok {
+		writeKeys(kl)
+	}
+	for _, ns := range sl.OrderedSections() {
+		if ns.Name == sl.defSect {
+			continue
+		}
+		if 0 < sb.Len() {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("[" + tomlTableName(ns.Name) + "]\n")
+		writeKeys(ns.Section)
+	}
+
+	_, err := io.WriteString(aWriter, sb.String())
+
+	return err
+} // ToTOML()
+
+// `FromTOML()` returns a new list with the data read from `aReader`.
+//
+// TOML tables become INI sections and keys outside of any table are
+// stored in the default section. Dotted keys are kept as they are
+// (e.g. `a.b = 1` is stored as key `a.b`), and arrays of scalar
+// values are stored as a list (see `AsStringSlice()`). Arrays of
+// tables and inline tables are not supported.
+//
+// Parameters:
+// - `aReader` The reader to read the TOML data from.
+//
+// Returns:
+// - `*TSectionList`: The list read from `aReader`.
+// - `error`: A possible error condition.
+func FromTOML(aReader io.Reader) (*TSectionList, error) {
+	data, err := io.ReadAll(aReader)
+	if nil != err {
+		return nil, err
+	}
+
+	result := NewSectionList()
+	parser := &tTOMLParser{src: string(data), line: 1}
+	if err = parser.parse(result); nil != err {
+		return nil, err
+	}
+
+	return result, nil
+} // FromTOML()
+
+/* _EoF_ */
@@ -0,0 +1,117 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_ToTOML(t *testing.T) {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("server.http", "port", "80")
+	_ = sl.AddSectionKey("", "title", `say "hi"`)
+	_ = sl.AddSectionKey(`remote "origin"`, "url", "https://example.com")
+	_ = sl.AddSectionKey(`remote "origin"`, "fetch all", "true")
+
+	want := `title = "say \"hi\""
+
+[server.http]
+port = 80
+
+["remote \"origin\""]
+url = "https://example.com"
+"fetch all" = true
+`
+	var sb strings.Builder
+	if err := sl.ToTOML(&sb); nil != err {
+		t.Fatalf("TSectionList.ToTOML() error = %v", err)
+	}
+	if got := sb.String(); want != got {
+		t.Errorf("TSectionList.ToTOML() =\n%s\nwant\n%s", got, want)
+	}
+
+	// and back again:
+	back, err := FromTOML(strings.NewReader(sb.String()))
+	if nil != err {
+		t.Fatalf("FromTOML() error = %v", err)
+	}
+	if !sl.CompareTo(back) {
+		t.Errorf("FromTOML() round trip mismatch:\n%s", back.String())
+	}
+} // TestTSectionList_ToTOML()
+
+func TestFromTOML(t *testing.T) {
+	type tWant struct {
+		section string
+		key     string
+		value   string
+	}
+	src := `# a comment
+title = 'literal \ string'
+
+[owner]
+name = "Tom Ø Preston"   # trailing comment
+dob = 1979-05-27 07:32:00-08:00
+bio = """
+Line one \
+  continued"""
+
+[database.settings]
+ports = [ 8000, 8001,
+  8002 ]
+max = 5_000
+enabled = false
+site."google.com" = true
+
+[a."b.c"]
+key = 'x'
+`
+	tests := []tWant{
+		{"", "title", `literal \ string`},
+		{"owner", "name", "Tom Ø Preston"},
+		{"owner", "dob", "1979-05-27 07:32:00-08:00"},
+		{"owner", "bio", "Line one continued"},
+		{"database.settings", "ports", "8000, 8001, 8002"},
+		{"database.settings", "max", "5000"},
+		{"database.settings", "enabled", "false"},
+		{"database.settings", "site.google.com", "true"},
+		{`a "b.c"`, "key", "x"},
+		// TODO: Add test cases.
+	}
+
+	sl, err := FromTOML(strings.NewReader(src))
+	if nil != err {
+		t.Fatalf("FromTOML() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := sl.AsString(tt.section, tt.key)
+			if !ok || (tt.value != got) {
+				t.Errorf("FromTOML() [%s] %s = %q, want %q",
+					tt.section, tt.key, got, tt.value)
+			}
+		})
+	}
+
+	for _, bad := range []string{
+		"[[products]]\nname = 'x'\n",
+		"point = { x = 1 }\n",
+		"key = \"unterminated\n",
+		"key = 1 2\n",
+		"= 1\n",
+	} {
+		if _, err := FromTOML(strings.NewReader(bad)); !errors.Is(err, ErrInvalidTOML) {
+			t.Errorf("FromTOML(%q) error = %v, want %v", bad, err, ErrInvalidTOML)
+		}
+	}
+} // TestFromTOML()
+
+/* _EoF_ */
@@ -0,0 +1,364 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `tTxKind` is the kind of a buffered transaction operation.
+	tTxKind int
+
+	// `tTxOperation` is a single buffered modification.
+	tTxOperation struct {
+		kind    tTxKind
+		section string
+		key     string
+		value   string
+	}
+
+	// `TChange` is a single modification applied by
+	// `TSectionList.Apply()`.
+	TChange struct {
+		Section string // the INI section to modify
+		Key     string // the key to set or remove
+		Value   string // the key's new value
+		Remove  bool   // remove the key instead of setting it
+	}
+
+	// `TTransaction` buffers modifications of a `TSectionList` which
+	// are applied all at once by `Commit()` or discarded by `Rollback()`.
+	//
+	// see `TSectionList.Begin()`
+	TTransaction struct {
+		list *TSectionList  // the list to modify
+		ops  []tTxOperation // the buffered modifications
+		done bool           // committed or rolled back
+	}
+)
+
+const (
+	txAddKey tTxKind = iota
+	txUpdateKey
+	txRemoveKey
+	txRemoveSection
+)
+
+var (
+	// `ErrTxDone` is returned when committing a transaction which
+	// was already committed or rolled back.
+	ErrTxDone = errors.New("ini: transaction already finished")
+
+	// `ErrTxFailed` is returned for a transaction's modification which
+	// couldn't be applied.
+	ErrTxFailed = errors.New("ini: transaction modification failed")
+)
+
+// `apply()` applies the (prepared) modification to `aList`.
+//
+// The caller has to hold the list's lock (see `lock()`).
+//
+// Parameters:
+// - `aList` The list to modify.
+// - `aEvents` Collects the change notifications to send.
+//
+// Returns:
+// - `bool`: `true` on success, `false` otherwise.
+func (op *tTxOperation) apply(aList *TSectionList, aEvents *[]tChangeEvent) bool {
+	switch op.kind {
+	case txAddKey, txUpdateKey:
+		return aList.putKey(op.section, aList.newSection(op.section), op.key, op.value, aEvents)
+
+	case txRemoveKey:
+		if kl, exists := aList.sections[op.section]; exists {
+			aList.removeKey(op.section, kl, op.key, aEvents)
+		}
+
+	case txRemoveSection:
+		aList.dropSection(op.section, aEvents)
+	}
+
+	return true
+} // apply()
+
+// `prepare()` checks the modification returning a copy ready to be
+// applied to `aList` (see `apply()`).
+//
+// The section's name is normalised, the new value checked by the
+// list's validators and encrypted if its key is a secret one.
+//
+// Parameters:
+// - `aList` The list to modify.
+//
+// Returns:
+// - `tTxOperation`: The modification to apply.
+// - `error`: A wrapped `ErrTxFailed` (and validator's error), or `nil`.
+func (op tTxOperation) prepare(aList *TSectionList) (tTxOperation, error) {
+	if op.section = strings.TrimSpace(op.section); "" == op.section {
+		op.section = aList.defSect
+	}
+	if (txAddKey != op.kind) && (txUpdateKey != op.kind) {
+		return op, nil
+	}
+
+	err := aList.checkValue(op.section, op.key, op.value)
+	if nil == err {
+		op.value, err = aList.encryptValue(op.section, op.key, op.value)
+	}
+	if nil != err {
+		return op, fmt.Errorf("[%s] %s: %w: %w", op.section, op.key, ErrTxFailed, err)
+	}
+
+	return op, nil
+} // prepare()
+
+// --------------------------------------------------------------------------
+
+// `Apply()` applies all `aChanges` to the list in the given order.
+//
+// Unlike a transaction (see `Begin()`) each change is applied on its
+// own and the result of each of them is returned. The changes are
+// checked first and then applied all at once while holding the list's
+// lock, so readers rendering the whole list (e.g. `String()`) never
+// see a part of them only; concurrent calls of `Apply()` or `Commit()`
+// are serialised.
+//
+// Parameters:
+// - `aChanges` The modifications to apply.
+//
+// Returns:
+// - `[]bool`: Whether each of the changes was applied successfully.
+func (sl *TSectionList) Apply(aChanges []TChange) []bool {
+	result := make([]bool, len(aChanges))
+	if sl.readOnly {
+		return result
+	}
+
+	ops := make([]tTxOperation, len(aChanges))
+	for idx, change := range aChanges {
+		key := strings.TrimSpace(change.Key)
+		if "" == key {
+			continue
+		}
+		op := tTxOperation{txUpdateKey, change.Section, key, change.Value}
+		if change.Remove {
+			op.kind = txRemoveKey
+		}
+		if prepared, err := op.prepare(sl); nil == err {
+			ops[idx], result[idx] = prepared, true
+		}
+	}
+
+	var events []tChangeEvent
+	unlock := sl.lock()
+	for idx := range ops {
+		if result[idx] {
+			result[idx] = ops[idx].apply(sl, &events)
+		}
+	}
+	unlock()
+	sl.notifyChanges(events)
+
+	return result
+} // Apply()
+
+// `Begin()` starts a new transaction on the list.
+//
+// The modifications made through the returned transaction are buffered
+// and don't affect the list until `Commit()` is called; `Rollback()`
+// discards them.
+//
+// Example:
+//
+//	tx := iniList.Begin()
+//	tx.UpdateSectKeyStr("db", "host", "db2.example.com")
+//	tx.UpdateSectKeyStr("db", "port", "5433")
+//	if err := tx.Commit(); nil != err {
+//		// …
+//	}
+//
+// Returns:
+// - `*TTransaction`: The new transaction.
+func (sl *TSectionList) Begin() *TTransaction {
+	return &TTransaction{list: sl}
+} // Begin()
+
+// `add()` buffers a modification after normalising its names.
+//
+// Parameters:
+// - `aKind` The kind of modification.
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key to use.
+// - `aValue` The value to use.
+//
+// Returns:
+// - `bool`: `true` if the modification was buffered, `false` otherwise.
+func (tx *TTransaction) add(aKind tTxKind, aSection, aKey, aValue string) bool {
+	if tx.done {
+		return false
+	}
+	aKey = strings.TrimSpace(aKey)
+	if ("" == aKey) && (txRemoveSection != aKind) {
+		return false
+	}
+	tx.ops = append(tx.ops, tTxOperation{aKind, aSection, aKey, aValue})
+
+	return true
+} // add()
+
+// `AddSectionKey()` buffers adding a key/value pair to `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The key of the key/value pair to add.
+// - `aValue` The value of the key/value pair to add.
+//
+// Returns:
+// - `bool`: `true` if the modification was buffered, `false` if
+// `aKey` is empty or the transaction is finished.
+func (tx *TTransaction) AddSectionKey(aSection, aKey, aValue string) bool {
+	return tx.add(txAddKey, aSection, aKey, aValue)
+} // AddSectionKey()
+
+// `Commit()` applies all buffered modifications to the list.
+//
+// All modifications are checked (see `AddValidator()`) before any of
+// them is applied, so either all or none of them take effect. They are
+// applied in place while holding the list's lock: sections returned
+// by `GetSection()` stay part of the list, and readers rendering the
+// whole list (e.g. `String()`) never see a part of the modifications
+// only. Concurrent transactions on the same list are serialised.
+// The change notifications (see `OnChange()`) are sent after the
+// list's lock was released, so the change function may modify the list.
+//
+// Returns:
+// - `error`: `ErrTxDone` if the transaction was already finished,
+// `ErrReadOnly` for a read-only list, the joined errors of all failed
+// modifications (wrapping `ErrTxFailed` and e.g. `ErrValidation`),
+// or `nil`.
+func (tx *TTransaction) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	sl := tx.list
+	if sl.readOnly {
+		return ErrReadOnly
+	}
+	ops := tx.ops
+	tx.done, tx.ops = true, nil
+
+	var errs []error
+	for idx, op := range ops {
+		prepared, err := op.prepare(sl)
+		if nil != err {
+			errs = append(errs, err)
+		}
+		ops[idx] = prepared
+	}
+	if 0 < len(errs) {
+		return errors.Join(errs...)
+	}
+
+	var events []tChangeEvent
+	unlock := sl.lock()
+	for idx := range ops {
+		ops[idx].apply(sl, &events)
+	}
+	unlock()
+	sl.notifyChanges(events)
+
+	return nil
+} // Commit()
+
+// `Len()` returns the number of buffered modifications.
+//
+// Returns:
+// - `int`: The number of modifications to apply on `Commit()`.
+func (tx *TTransaction) Len() int {
+	return len(tx.ops)
+} // Len()
+
+// `lock()` acquires the list's lock (if any).
+//
+// Returns:
+// - `func()`: The function to release the lock.
+func (sl *TSectionList) lock() func() {
+	if nil == sl.mtx {
+		return func() {}
+	}
+	sl.mtx.Lock()
+
+	return sl.mtx.Unlock
+} // lock()
+
+// `rlock()` acquires the list's read lock (if any).
+//
+// Returns:
+// - `func()`: The function to release the lock.
+func (sl *TSectionList) rlock() func() {
+	if nil == sl.mtx {
+		return func() {}
+	}
+	sl.mtx.RLock()
+
+	return sl.mtx.RUnlock
+} // rlock()
+
+// `RemoveSection()` buffers removing `aSection` from the list.
+//
+// Parameters:
+// - `aSection` The name of the INI section to remove.
+//
+// Returns:
+// - `bool`: `true` if the modification was buffered, `false` if
+// the transaction is finished.
+func (tx *TTransaction) RemoveSection(aSection string) bool {
+	return tx.add(txRemoveSection, aSection, "", "")
+} // RemoveSection()
+
+// `RemoveSectionKey()` buffers removing `aKey` from `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key/value pair to remove.
+//
+// Returns:
+// - `bool`: `true` if the modification was buffered, `false` if
+// `aKey` is empty or the transaction is finished.
+func (tx *TTransaction) RemoveSectionKey(aSection, aKey string) bool {
+	return tx.add(txRemoveKey, aSection, aKey, "")
+} // RemoveSectionKey()
+
+// `Rollback()` discards all buffered modifications.
+//
+// Calling `Rollback()` after `Commit()` has no effect.
+func (tx *TTransaction) Rollback() {
+	tx.done = true
+	tx.ops = nil
+} // Rollback()
+
+// `UpdateSectKeyStr()` buffers replacing the value of `aKey` in
+// `aSection` by `aValue`.
+//
+// Parameters:
+// - `aSection` The name of the INI section to use.
+// - `aKey` The name of the key/value pair to use.
+// - `aValue` The new value of the key/value pair.
+//
+// Returns:
+// - `bool`: `true` if the modification was buffered, `false` if
+// `aKey` is empty or the transaction is finished.
+func (tx *TTransaction) UpdateSectKeyStr(aSection, aKey, aValue string) bool {
+	return tx.add(txUpdateKey, aSection, aKey, aValue)
+} // UpdateSectKeyStr()
+
+/* _EoF_ */
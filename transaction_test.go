@@ -0,0 +1,270 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_Apply(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "host", "db1")
+	sl.AddSectionKey("db", "port", "5432")
+
+	got := sl.Apply([]TChange{
+		{Section: "db", Key: "host", Value: "db2"},
+		{Section: "db", Key: "port", Remove: true},
+		{Section: "new", Key: "key", Value: "value"},
+		{Section: "db", Key: " ", Value: "x"},
+	})
+	want := []bool{true, true, true, false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TSectionList.Apply() = %v, want %v", got, want)
+	}
+	if got, _ := sl.AsString("db", "host"); "db2" != got {
+		t.Errorf("TSectionList.Apply() host = %q, want %q", got, "db2")
+	}
+	if sl.HasSectionKey("db", "port") {
+		t.Error("TSectionList.Apply() didn't remove the key")
+	}
+	if got, _ := sl.AsString("new", "key"); "value" != got {
+		t.Errorf("TSectionList.Apply() key = %q, want %q", got, "value")
+	}
+	if 3 != len(sl.ChangedKeys()) {
+		t.Errorf("TSectionList.ChangedKeys() = %v", sl.ChangedKeys())
+	}
+} // TestTSectionList_Apply()
+
+func TestTTransaction_Commit(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "host", "db1")
+	sl.AddSectionKey("db", "port", "5432")
+	sl.AddSectionKey("old", "key", "value")
+
+	tx := sl.Begin()
+	if !tx.UpdateSectKeyStr("db", "host", "db2") ||
+		!tx.AddSectionKey("db", "user", "admin") ||
+		!tx.RemoveSectionKey("db", "port") ||
+		!tx.RemoveSection("old") {
+		t.Fatal("TTransaction: buffering failed")
+	}
+	if tx.AddSectionKey("db", " ", "x") {
+		t.Error("TTransaction.AddSectionKey() accepted an empty key")
+	}
+	if 4 != tx.Len() {
+		t.Errorf("TTransaction.Len() = %d, want 4", tx.Len())
+	}
+	if got, _ := sl.AsString("db", "host"); "db1" != got {
+		t.Errorf("uncommitted transaction changed value to %q", got)
+	}
+
+	if err := tx.Commit(); nil != err {
+		t.Fatalf("TTransaction.Commit() error = %v", err)
+	}
+	if got, _ := sl.AsString("db", "host"); "db2" != got {
+		t.Errorf("TTransaction.Commit() host = %q, want %q", got, "db2")
+	}
+	if got, _ := sl.AsString("db", "user"); "admin" != got {
+		t.Errorf("TTransaction.Commit() user = %q, want %q", got, "admin")
+	}
+	if sl.HasSectionKey("db", "port") || sl.HasSection("old") {
+		t.Error("TTransaction.Commit() didn't remove entries")
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("TTransaction.Commit() error = %v, want %v", err, ErrTxDone)
+	}
+	if tx.AddSectionKey("db", "late", "x") {
+		t.Error("finished TTransaction accepted a modification")
+	}
+} // TestTTransaction_Commit()
+
+func TestTTransaction_Commit_failure(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "host", "db1")
+	sl.AddSectionKey("db", "port", "5432")
+	sl.SetValidator("db", "port", func(aValue string) error {
+		if "" == aValue {
+			return errors.New("empty port")
+		}
+		return nil
+	})
+	var notified []string
+	sl.OnChange(func(aSection, aKey, aOld, aNew string) {
+		notified = append(notified, aKey)
+	})
+
+	tx := sl.Begin()
+	tx.UpdateSectKeyStr("db", "host", "db2")
+	tx.UpdateSectKeyStr("db", "port", "")
+	err := tx.Commit()
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("TTransaction.Commit() error = %v, want %v", err, ErrValidation)
+	}
+	if got, _ := sl.AsString("db", "host"); "db1" != got {
+		t.Errorf("failed TTransaction.Commit() host = %q, want %q", got, "db1")
+	}
+	if 0 != len(notified) {
+		t.Errorf("failed TTransaction.Commit() notified %v", notified)
+	}
+
+	tx = sl.Begin()
+	tx.UpdateSectKeyStr("db", "host", "db3")
+	tx.UpdateSectKeyStr("db", "port", "5433")
+	if err = tx.Commit(); nil != err {
+		t.Fatalf("TTransaction.Commit() error = %v", err)
+	}
+	if want := []string{"host", "port"}; !reflect.DeepEqual(notified, want) {
+		t.Errorf("TTransaction.Commit() notified %v, want %v", notified, want)
+	}
+	if got, _ := sl.AsString("db", "port"); "5433" != got {
+		t.Errorf("TTransaction.Commit() port = %q, want %q", got, "5433")
+	}
+} // TestTTransaction_Commit_failure()
+
+func TestTTransaction_Commit_hook(t *testing.T) {
+	sl := NewSectionList()
+	sl.OnChange(func(aSection, aKey, aOld, aNew string) {
+		if "db" == aSection {
+			// modifying the list from the hook mustn't deadlock:
+			sl.Apply([]TChange{{Section: "audit", Key: aKey, Value: aNew}})
+		}
+	})
+
+	tx := sl.Begin()
+	tx.UpdateSectKeyStr("db", "host", "db2")
+	if err := tx.Commit(); nil != err {
+		t.Fatalf("TTransaction.Commit() error = %v", err)
+	}
+	if got, _ := sl.AsString("audit", "host"); "db2" != got {
+		t.Errorf("TTransaction.Commit() hook value = %q, want %q", got, "db2")
+	}
+} // TestTTransaction_Commit_hook()
+
+func TestTTransaction_Rollback(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("", "key", "value")
+
+	tx := sl.Begin()
+	tx.UpdateSectKeyStr("", "key", "changed")
+	tx.RemoveSection("")
+	tx.Rollback()
+
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("TTransaction.Commit() error = %v, want %v", err, ErrTxDone)
+	}
+	if got, _ := sl.AsString("", "key"); "value" != got {
+		t.Errorf("TTransaction.Rollback() value = %q, want %q", got, "value")
+	}
+} // TestTTransaction_Rollback()
+
+func TestTTransaction_concurrent(t *testing.T) {
+	sl := NewSectionList()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(aValue string) {
+			defer wg.Done()
+			tx := sl.Begin()
+			tx.UpdateSectKeyStr("s", "a", aValue)
+			tx.UpdateSectKeyStr("s", "b", aValue)
+			_ = tx.Commit()
+		}(string(rune('0' + i)))
+	}
+	wg.Wait()
+
+	a, _ := sl.AsString("s", "a")
+	b, _ := sl.AsString("s", "b")
+	if ("" == a) || (a != b) {
+		t.Errorf("concurrent transactions interleaved: a = %q, b = %q", a, b)
+	}
+} // TestTTransaction_concurrent()
+
+func TestTTransaction_Commit_attached(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("db", "host", "db1")
+	section := sl.GetSection("db")
+
+	tx := sl.Begin()
+	tx.UpdateSectKeyStr("db", "host", "db2")
+	tx.AddSectionKey("db", "user", "admin")
+	if err := tx.Commit(); nil != err {
+		t.Fatalf("TTransaction.Commit() error = %v", err)
+	}
+
+	if got, _ := section.AsString("host"); "db2" != got {
+		t.Errorf("TSection.AsString() = %q, want %q", got, "db2")
+	}
+	section.AddKey("port", "5432")
+	if got, _ := sl.AsString("db", "port"); "5432" != got {
+		t.Errorf("TSectionList.AsString() = %q, want %q", got, "5432")
+	}
+} // TestTTransaction_Commit_attached()
+
+func TestTTransaction_Commit_readers(t *testing.T) {
+	sl := NewSectionList()
+	sl.AddSectionKey("s", "a", "0")
+	sl.AddSectionKey("s", "b", "0")
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, _ = sl.AsString("s", "a")
+				_ = sl.GetSection("s").Len()
+				sl.Walk(func(aSection, aKey, aValue string) {})
+				if text := sl.String(); strings.Contains(text, "a = 1") != strings.Contains(text, "b = 1") {
+					t.Errorf("TSectionList.String() saw a partial transaction:\n%s", text)
+				}
+			}
+		}()
+	}
+
+	var writes sync.WaitGroup
+	writes.Add(2)
+	go func() {
+		defer writes.Done()
+		for i := 0; i < 100; i++ {
+			tx := sl.Begin()
+			tx.UpdateSectKeyStr("s", "a", string(rune('0'+i%2)))
+			tx.UpdateSectKeyStr("s", "b", string(rune('0'+i%2)))
+			_ = tx.Commit()
+			sl.Apply([]TChange{{Section: "t", Key: "k", Value: "v"}})
+		}
+	}()
+	go func() {
+		defer writes.Done()
+		for i := 0; i < 100; i++ {
+			sl.AddSectionKey("s", fmt.Sprintf("key%d", i), "value")
+		}
+	}()
+	writes.Wait()
+	close(done)
+	wg.Wait()
+
+	for i := 0; i < 100; i++ {
+		if key := fmt.Sprintf("key%d", i); !sl.HasSectionKey("s", key) {
+			t.Errorf("TSectionList.AddSectionKey() %q was lost", key)
+		}
+	}
+} // TestTTransaction_Commit_readers()
+
+/* _EoF_ */
@@ -0,0 +1,124 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+type (
+	// `TValidateFunc()` checks the value of a key returning an error
+	// if it's invalid.
+	//
+	// see `SetValidator()`
+	TValidateFunc func(aValue string) error
+
+	// `tValidators` maps section/key pairs to their validators.
+	tValidators map[string]TValidateFunc
+)
+
+var (
+	// `ErrValidation` is returned by `Validate()` for values rejected
+	// by their validators.
+	ErrValidation = errors.New("ini: validation failed")
+)
+
+// `checkValue()` returns whether `aValue` is acceptable for `aKey`
+// in `aSection`.
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aValue` The key's new value.
+//
+// Returns:
+// - `error`: The validator's error or `nil`.
+func (sl *TSectionList) checkValue(aSection, aKey, aValue string) error {
+	validate, ok := sl.validators[originID(aSection, aKey)]
+	if !ok {
+		return nil
+	}
+	if err := validate(strings.TrimSpace(aValue)); nil != err {
+		return fmt.Errorf("[%s] %s = %q: %w: %w", aSection, aKey, aValue, ErrValidation, err)
+	}
+
+	return nil
+} // checkValue()
+
+// `SetValidator()` sets the function to check all new values of `aKey`
+// in `aSection`.
+//
+// Values rejected by `aFunc` are not set, i.e. `AddSectionKey()`,
+// `UpdateSectKeyStr()` and its siblings return `false`. Values read
+// from an INI file are not checked; use `Validate()` for that.
+// Passing `nil` removes a previously set validator.
+//
+// Example:
+//
+//	sl.SetValidator("server", "port", func(aValue string) error {
+//		_, err := strconv.ParseUint(aValue, 10, 16)
+//		return err
+//	})
+//
+// Parameters:
+// - `aSection` The name of the INI section.
+// - `aKey` The name of the key.
+// - `aFunc` The function to check the key's values.
+//
+// Returns:
+// - `*TSectionList`: The current list.
+func (sl *TSectionList) SetValidator(aSection, aKey string, aFunc TValidateFunc) *TSectionList {
+	if aSection = strings.TrimSpace(aSection); "" == aSection {
+		aSection = sl.defSect
+	}
+	id := originID(aSection, strings.TrimSpace(aKey))
+
+	if nil == aFunc {
+		delete(sl.validators, id)
+		return sl
+	}
+	if nil == sl.validators {
+		sl.validators = make(tValidators)
+	}
+	sl.validators[id] = aFunc
+
+	return sl
+} // SetValidator()
+
+// `Validate()` checks the current values of all keys having a
+// validator (see `SetValidator()`).
+//
+// Missing keys are not considered an error.
+//
+// Returns:
+// - `error`: The joined errors of all invalid values or `nil`.
+func (sl *TSectionList) Validate() error {
+	var errs []error
+	for _, ns := range sl.OrderedSections() {
+		name, kl := ns.Name, ns.Section
+		for _, key := range kl.Keys() {
+			if _, ok := sl.validators[originID(name, key)]; !ok {
+				continue
+			}
+			_, value, err := sl.resolveValue(name, key)
+			if nil == err {
+				err = sl.checkValue(name, key, value)
+			}
+			if nil != err {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+} // Validate()
+
+/* _EoF_ */
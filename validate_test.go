@@ -0,0 +1,83 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func checkPort(aValue string) error {
+	_, err := strconv.ParseUint(aValue, 10, 16)
+
+	return err
+} // checkPort()
+
+func TestTSectionList_SetValidator(t *testing.T) {
+	sl := NewSectionList().SetValidator("server", "port", checkPort)
+
+	tests := []struct {
+		name  string
+		call  func() bool
+		want  bool
+		value string
+	}{
+		{"1", func() bool { return sl.AddSectionKey("server", "port", " 8080 ") }, true, "8080"},
+		{"2", func() bool { return sl.AddSectionKey("server", "port", "http") }, false, "8080"},
+		{"3", func() bool { return sl.UpdateSectKeyStr("server", "port", "65536") }, false, "8080"},
+		{"4", func() bool { return sl.UpdateSectKeyInt("server", "port", 443) }, true, "443"},
+		{"5", func() bool { return sl.UpdateSectKeyInt("server", "port", -1) }, false, "443"},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.call(); got != tt.want {
+				t.Errorf("%q: update = %v, want %v", tt.name, got, tt.want)
+			}
+			if got, _ := sl.AsString("server", "port"); got != tt.value {
+				t.Errorf("%q: TSectionList.AsString() = %q, want %q", tt.name, got, tt.value)
+			}
+		})
+	}
+
+	// other keys aren't affected:
+	if !sl.AddSectionKey("server", "host", "http") {
+		t.Error("TSectionList.AddSectionKey() rejected an unchecked key")
+	}
+
+	// removing the validator:
+	sl.SetValidator("server", "port", nil)
+	if !sl.AddSectionKey("server", "port", "http") {
+		t.Error("TSectionList.AddSectionKey() rejected a value after removing the validator")
+	}
+} // TestTSectionList_SetValidator()
+
+func TestTSectionList_Validate(t *testing.T) {
+	sl := NewSectionList().SetValidator("server", "port", checkPort)
+	src := "[server]\nport = http\nhost = localhost\n"
+	if _, err := sl.read(sl.newScanner(context.Background(), strings.NewReader(src))); nil != err {
+		t.Fatalf("TSectionList.read() error = %v", err)
+	}
+	if got, _ := sl.AsString("server", "port"); "http" != got {
+		t.Errorf("TSectionList.read() port = %q, want %q", got, "http")
+	}
+	if err := sl.Validate(); !errors.Is(err, ErrValidation) {
+		t.Errorf("TSectionList.Validate() error = %v, want %v", err, ErrValidation)
+	}
+
+	sl.RemoveSectionKey("server", "port")
+	if err := sl.Validate(); nil != err {
+		t.Errorf("TSectionList.Validate() error = %v", err)
+	}
+} // TestTSectionList_Validate()
+
+/* _EoF_ */
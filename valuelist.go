@@ -0,0 +1,415 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"strconv"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `DefListSeparator` is the separator used for list values if
+	// none is given.
+	DefListSeparator = `,`
+)
+
+// `joinList()` returns the given `aItems` as a single value delimited
+// by `aSeparator`.
+//
+// Backslashes, quotes at the start of an item, and separators within
+// the items are escaped by a backslash so that `splitList()` returns
+// the original items.
+//
+// Parameters:
+// - `aItems` The list of items to join.
+// - `aSeparator` The separator to use (`DefListSeparator` if empty).
+//
+// Returns:
+// - `string`: The joined list.
+func joinList(aItems []string, aSeparator string) string {
+	if "" == aSeparator {
+		aSeparator = DefListSeparator
+	}
+	glue := aSeparator
+	if "" != strings.TrimSpace(aSeparator) {
+		glue += " " // improve readability
+	}
+	sepEscaped := `\` + aSeparator
+
+	escaped := make([]string, 0, len(aItems))
+	for _, item := range aItems {
+		item = strings.ReplaceAll(strings.TrimSpace(item), `\`, `\\`)
+		item = strings.ReplaceAll(item, aSeparator, sepEscaped)
+		if strings.HasPrefix(item, `"`) || strings.HasPrefix(item, `'`) {
+			item = `\` + item
+		}
+		escaped = append(escaped, item)
+	}
+
+	return strings.Join(escaped, glue)
+} // joinList()
+
+// `scanList()` returns the items of `aValue` delimited by `aSeparator`
+// and whether the list ends with a quoted item while all quoted items
+// were closed properly, i.e. without any text following their closing
+// quote.
+//
+// Parameters:
+// - `aValue` The (trimmed) value to split.
+// - `aSeparator` The separator to use.
+//
+// Returns:
+// - `[]string`: The list of items.
+// - `bool`: `true` if the quoted items are well-formed, `false` otherwise.
+func scanList(aValue, aSeparator string) ([]string, bool) {
+	var (
+		item   strings.Builder
+		quote  byte // the currently open quote character
+		quoted bool // whether the current item was quoted
+	)
+	result, clean := []string{}, true
+	flush := func() {
+		if quoted {
+			result = append(result, item.String())
+		} else {
+			result = append(result, strings.TrimSpace(item.String()))
+		}
+		item.Reset()
+		quoted = false
+	}
+
+	for i := 0; i < len(aValue); i++ {
+		c := aValue[i]
+		if ('\\' == c) && (i+1 < len(aValue)) {
+			next := aValue[i+1:]
+			if strings.HasPrefix(next, aSeparator) {
+				item.WriteString(aSeparator)
+				i += len(aSeparator)
+				continue
+			}
+			if n := next[0]; ('\\' == n) || ('"' == n) || ('\'' == n) {
+				item.WriteByte(n)
+				i++
+				continue
+			}
+		}
+		if 0 != quote {
+			if c == quote {
+				quote = 0
+			} else {
+				item.WriteByte(c)
+			}
+			continue
+		}
+		if (('"' == c) || ('\'' == c)) && ("" == strings.TrimSpace(item.String())) {
+			item.Reset()
+			quote, quoted = c, true
+			continue
+		}
+		if strings.HasPrefix(aValue[i:], aSeparator) {
+			flush()
+			i += len(aSeparator) - 1
+			continue
+		}
+		if quoted {
+			if (' ' == c) || ('\t' == c) {
+				continue // whitespace after the closing quote
+			}
+			clean = false
+		}
+		item.WriteByte(c)
+	}
+	clean = clean && quoted && (0 == quote)
+	flush()
+
+	return result, clean
+} // scanList()
+
+// `splitList()` returns the items of `aValue` delimited by `aSeparator`.
+//
+// The items are trimmed of leading and trailing whitespace. An item
+// may be enclosed in single or double quotes to protect separators
+// and whitespace, and a backslash escapes a following separator,
+// quote, or backslash. Other backslashes are kept as they are (e.g.
+// in Windows paths).
+//
+// Since reading an INI file removes the quotes enclosing a value, a
+// list like `"a", "b"` arrives here as `a", "b`; if restoring these
+// quotes yields well-formed quoted items those are returned.
+//
+// Parameters:
+// - `aValue` The value to split.
+// - `aSeparator` The separator to use (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]string`: The list of items.
+func splitList(aValue, aSeparator string) []string {
+	if "" == aSeparator {
+		aSeparator = DefListSeparator
+	}
+	if aValue = strings.TrimSpace(aValue); "" == aValue {
+		return []string{}
+	}
+
+	if c := aValue[0]; ('"' != c) && ('\'' != c) {
+		for _, quote := range []string{`"`, `'`} {
+			if !strings.Contains(aValue, quote) {
+				continue
+			}
+			if result, ok := scanList(quote+aValue+quote, aSeparator); ok && (1 < len(result)) {
+				return result
+			}
+		}
+	}
+	result, _ := scanList(aValue, aSeparator)
+
+	return result
+} // splitList()
+
+// --------------------------------------------------------------------------
+
+// `AsFloat64Slice()` returns the value of `aKey` as a list of 64bit
+// floating points.
+//
+// If the given `aKey` doesn't exist or one of the list's items isn't
+// a valid floating point number then the second return value will
+// be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]float64`: The value of `aKey` as a list of floating points.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsFloat64Slice(aKey, aSeparator string) ([]float64, bool) {
+	items, ok := kl.AsStringSlice(aKey, aSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	return parseFloatList(items)
+} // AsFloat64Slice()
+
+// `AsIntSlice()` returns the value of `aKey` as a list of integers.
+//
+// If the given `aKey` doesn't exist or one of the list's items isn't
+// a valid integer then the second return value will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]int`: The value of `aKey` as a list of integers.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsIntSlice(aKey, aSeparator string) ([]int, bool) {
+	items, ok := kl.AsStringSlice(aKey, aSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	return parseIntList(items)
+} // AsIntSlice()
+
+// `AsStringSlice()` returns the value of `aKey` as a list of strings.
+//
+// A value like `a.example, b.example, "c, example"` results in the
+// three items `a.example`, `b.example`, and `c, example`.
+// An empty value results in an empty list.
+//
+// If the given `aKey` doesn't exist then the second return value
+// will be `false`.
+//
+// Parameters:
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]string`: The value of `aKey` as a list of strings.
+// - `bool`: `true` if `aKey` was found, `false` otherwise.
+func (kl *TSection) AsStringSlice(aKey, aSeparator string) ([]string, bool) {
+	value, ok := kl.AsString(aKey)
+	if !ok {
+		return nil, false
+	}
+
+	return splitList(value, aSeparator), true
+} // AsStringSlice()
+
+// `UpdateKeySlice()` replaces the current value of `aKey` by the
+// provided `aValues` list delimited by `aSeparator`.
+//
+// Parameters:
+// - `aKey` The name of the key/value pair to use.
+// - `aValues` The list of values to store.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `bool`: `true` if `aKey` was updated successfully, `false` otherwise.
+func (kl *TSection) UpdateKeySlice(aKey string, aValues []string, aSeparator string) bool {
+	return kl.UpdateKey(aKey, joinList(aValues, aSeparator))
+} // UpdateKeySlice()
+
+// `parseFloatList()` converts all `aItems` to 64bit floating points.
+//
+// Parameters:
+// - `aItems` The list of strings to convert.
+//
+// Returns:
+// - `[]float64`: The list of converted values.
+// - `bool`: `true` if all items were converted, `false` otherwise.
+func parseFloatList(aItems []string) ([]float64, bool) {
+	result := make([]float64, 0, len(aItems))
+	for _, item := range aItems {
+		f64, err := strconv.ParseFloat(item, 64)
+		if (nil != err) || (f64 != f64) {
+			return nil, false
+		}
+		result = append(result, f64)
+	}
+
+	return result, true
+} // parseFloatList()
+
+// `parseIntList()` converts all `aItems` to integers.
+//
+// Parameters:
+// - `aItems` The list of strings to convert.
+//
+// Returns:
+// - `[]int`: The list of converted values.
+// - `bool`: `true` if all items were converted, `false` otherwise.
+func parseIntList(aItems []string) ([]int, bool) {
+	result := make([]int, 0, len(aItems))
+	for _, item := range aItems {
+		i64, err := strconv.ParseInt(item, 10, 0)
+		if nil != err {
+			return nil, false
+		}
+		result = append(result, int(i64))
+	}
+
+	return result, true
+} // parseIntList()
+
+// --------------------------------------------------------------------------
+
+// `AsFloat64Slice()` returns the value of `aKey` in `aSection` as a list
+// of 64bit floating points.
+//
+// If the given `aKey` in `aSection` doesn't exist or one of the list's
+// items isn't a valid floating point number then the second return
+// value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]float64`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsFloat64Slice(aSection, aKey, aSeparator string) ([]float64, bool) {
+	items, ok := sl.AsStringSlice(aSection, aKey, aSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	return parseFloatList(items)
+} // AsFloat64Slice()
+
+// `AsIntSlice()` returns the value of `aKey` in `aSection` as a list
+// of integers.
+//
+// If the given `aKey` in `aSection` doesn't exist or one of the list's
+// items isn't a valid integer then the second return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]int`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsIntSlice(aSection, aKey, aSeparator string) ([]int, bool) {
+	items, ok := sl.AsStringSlice(aSection, aKey, aSeparator)
+	if !ok {
+		return nil, false
+	}
+
+	return parseIntList(items)
+} // AsIntSlice()
+
+// `AsStringSlice()` returns the value of `aKey` in `aSection` as a list
+// of strings.
+//
+// See `TSection.AsStringSlice()` for the list syntax. With the
+// `DialectSystemd` and `DialectPHP` dialects an empty `aSeparator`
+// returns the values of a repeated (or array) key.
+//
+// If the given `aKey` in `aSection` doesn't exist then the second
+// return value will be `false`.
+//
+// Parameters:
+// - `aSection` the name of the INI section to lookup.
+// - `aKey` The name of the key to lookup.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - `[]string`: The value associated with `aKey`.
+// - `bool`: `true` if `aKey` was found, or false otherwise.
+func (sl *TSectionList) AsStringSlice(aSection, aKey, aSeparator string) ([]string, bool) {
+	if key, repeated := sl.listKey(aKey); repeated && ("" == aSeparator) {
+		// the values of repeated keys
+		value, ok := sl.AsString(aSection, key)
+		if !ok {
+			return nil, false
+		}
+		if "" == value {
+			return []string{}, true
+		}
+		return strings.Split(value, "\n"), true
+	}
+
+	value, ok := sl.AsString(aSection, aKey)
+	if !ok {
+		return nil, false
+	}
+
+	return splitList(value, aSeparator), true
+} // AsStringSlice()
+
+// `UpdateSectKeySlice()` replaces the current value of `aKey` in
+// `aSection` by the provided `aValues` list delimited by `aSeparator`.
+//
+// With the `DialectSystemd` and `DialectPHP` dialects an empty
+// `aSeparator` stores the values to be written as a repeated (or
+// array) key.
+//
+// Parameters:
+// - `aSection` The name of the INI section to lookup.
+// - `aKey` The name of the key/value pair to use.
+// - `aValues` The list of values to store.
+// - `aSeparator` The separator of the list items (`DefListSeparator` if empty).
+//
+// Returns:
+// - bool: `true` if the key/value pair was successfully updated,
+// or `false` otherwise.
+func (sl *TSectionList) UpdateSectKeySlice(aSection, aKey string, aValues []string, aSeparator string) bool {
+	if key, repeated := sl.listKey(aKey); repeated && ("" == aSeparator) {
+		// written as repeated keys
+		return sl.updateSectKey(aSection, key, strings.Join(aValues, "\n"))
+	}
+
+	return sl.updateSectKey(aSection, aKey, joinList(aValues, aSeparator))
+} // UpdateSectKeySlice()
+
+/* _EoF_ */
@@ -0,0 +1,267 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"reflect"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func Test_joinList(t *testing.T) {
+	type tArgs struct {
+		aItems     []string
+		aSeparator string
+	}
+	tests := []struct {
+		name string
+		args tArgs
+		want string
+	}{
+		{"0", tArgs{nil, ""}, ""},
+		{"1", tArgs{[]string{"a", "b", "c"}, ""}, "a, b, c"},
+		{"2", tArgs{[]string{"a, b", "c"}, ","}, `a\, b, c`},
+		{"3", tArgs{[]string{"a", "b"}, ":"}, "a: b"},
+		{"4", tArgs{[]string{"a", "b"}, " "}, "a b"},
+		{"5", tArgs{[]string{`"a"`, `c:\tmp`}, ";"}, `\"a"; c:\\tmp`},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinList(tt.args.aItems, tt.args.aSeparator); got != tt.want {
+				t.Errorf("%q: joinList() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_joinList()
+
+func Test_splitList(t *testing.T) {
+	type tArgs struct {
+		aValue     string
+		aSeparator string
+	}
+	tests := []struct {
+		name string
+		args tArgs
+		want []string
+	}{
+		{"0", tArgs{"", ""}, []string{}},
+		{"1", tArgs{"a.example, b.example, c.example", ""},
+			[]string{"a.example", "b.example", "c.example"}},
+		{"2", tArgs{`"a, b", ' c ', d`, ","}, []string{"a, b", " c ", "d"}},
+		{"3", tArgs{`a\, b, c`, ","}, []string{"a, b", "c"}},
+		{"4", tArgs{`a : b:c`, ":"}, []string{"a", "b", "c"}},
+		{"5", tArgs{`a, , c`, ""}, []string{"a", "", "c"}},
+		{"6", tArgs{`c:\tmp; \"x"`, ";"}, []string{`c:\tmp`, `"x"`}},
+		{"7", tArgs{`a || b`, "||"}, []string{"a", "b"}},
+		{"8", tArgs{`a, b", "c`, ","}, []string{"a, b", "c"}},
+		{"9", tArgs{`a', 'b`, ","}, []string{"a", "b"}},
+		{"10", tArgs{`say "hi", bye`, ","}, []string{`say "hi"`, "bye"}},
+		{"11", tArgs{`it's, fine`, ","}, []string{`it's`, "fine"}},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitList(tt.args.aValue, tt.args.aSeparator); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: splitList() = %q, want %q",
+					tt.name, got, tt.want)
+			}
+		})
+	}
+} // Test_splitList()
+
+func Test_listRoundTrip(t *testing.T) {
+	items := []string{`a, b`, `"quoted"`, `c:\tmp`, `x`}
+	for _, sep := range []string{",", ";", " ", "|"} {
+		if got := splitList(joinList(items, sep), sep); !reflect.DeepEqual(got, items) {
+			t.Errorf("%q: round trip = %q, want %q", sep, got, items)
+		}
+	}
+} // Test_listRoundTrip()
+
+func Test_listQuotedRead(t *testing.T) {
+	sl, err := ParseBytes([]byte("[list]\nhosts = \"a, b\", \"c\"\ntitle = \"say \"hello\"\"\n"))
+	if nil != err {
+		t.Fatalf("ParseBytes() error = %v", err)
+	}
+	if got, _ := sl.AsStringSlice("list", "hosts", ","); !reflect.DeepEqual(got, []string{"a, b", "c"}) {
+		t.Errorf("AsStringSlice() = %q, want %q", got, []string{"a, b", "c"})
+	}
+	if got, _ := sl.AsString("list", "title"); `say "hello"` != got {
+		t.Errorf("AsString() = %q, want %q", got, `say "hello"`)
+	}
+} // Test_listQuotedRead()
+
+func TestTSection_AsFloat64Slice(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key0", "")
+	_ = kl.AddKey("key1", "1.5, 2, -3.25")
+	_ = kl.AddKey("key2", "1.5, two")
+
+	tests := []struct {
+		args  string
+		want  []float64
+		want1 bool
+	}{
+		{"key0", []float64{}, true},
+		{"key1", []float64{1.5, 2, -3.25}, true},
+		{"key2", nil, false},
+		{"n.a.", nil, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsFloat64Slice(tt.args, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TSection.AsFloat64Slice(%q) val = %v, want %v",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsFloat64Slice(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsFloat64Slice()
+
+func TestTSection_AsIntSlice(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("key1", "1; 2; 3")
+	_ = kl.AddKey("key2", "1; 2.5")
+
+	tests := []struct {
+		args  string
+		want  []int
+		want1 bool
+	}{
+		{"key1", []int{1, 2, 3}, true},
+		{"key2", nil, false},
+		{"n.a.", nil, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsIntSlice(tt.args, ";")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TSection.AsIntSlice(%q) val = %v, want %v",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsIntSlice(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsIntSlice()
+
+func TestTSection_AsStringSlice(t *testing.T) {
+	kl := prepSection()
+	_ = kl.AddKey("hosts", "a.example, b.example, c.example")
+	_ = kl.AddKey("names", `"Doe, John", 'Roe, Jane'`)
+
+	tests := []struct {
+		args  string
+		want  []string
+		want1 bool
+	}{
+		{"hosts", []string{"a.example", "b.example", "c.example"}, true},
+		{"names", []string{"Doe, John", "Roe, Jane"}, true},
+		{"n.a.", nil, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, got1 := kl.AsStringSlice(tt.args, ",")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TSection.AsStringSlice(%q) val = %q, want %q",
+					tt.args, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("TSection.AsStringSlice(%q) ok = %v, want %v",
+					tt.args, got1, tt.want1)
+			}
+		})
+	}
+} // TestTSection_AsStringSlice()
+
+func TestTSection_UpdateKeySlice(t *testing.T) {
+	kl := prepSection()
+	values := []string{"a, b", "c"}
+
+	if !kl.UpdateKeySlice("list", values, "") {
+		t.Fatal("TSection.UpdateKeySlice() = false, want true")
+	}
+	if got, _ := kl.AsString("list"); `a\, b, c` != got {
+		t.Errorf("TSection.UpdateKeySlice() stored %q, want %q",
+			got, `a\, b, c`)
+	}
+	if got, _ := kl.AsStringSlice("list", ""); !reflect.DeepEqual(got, values) {
+		t.Errorf("TSection.UpdateKeySlice() read back %q, want %q",
+			got, values)
+	}
+} // TestTSection_UpdateKeySlice()
+
+func TestTSectionList_AsStringSlice(t *testing.T) {
+	type tArgs struct {
+		aSection string
+		aKey     string
+	}
+
+	sl := prepSectionList()
+	_ = sl.AddSectionKey("", "key1", "a.example, b.example")
+	_ = sl.AddSectionKey("", "key2", "1, 2, 3")
+	_ = sl.AddSectionKey("", "key3", "0.5, 1e3")
+
+	tests := []struct {
+		name  string
+		args  tArgs
+		want  []string
+		want1 bool
+	}{
+		{"0", tArgs{"", "key0"}, []string{}, true},
+		{"1", tArgs{"", "key1"}, []string{"a.example", "b.example"}, true},
+		{"2", tArgs{"n.a.", "key1"}, nil, false},
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sl.AsStringSlice(tt.args.aSection, tt.args.aKey, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%q: TSectionList.AsStringSlice() got = %q, want %q",
+					tt.name, got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("%q: TSectionList.AsStringSlice() got1 = %v, want %v",
+					tt.name, got1, tt.want1)
+			}
+		})
+	}
+
+	if got, ok := sl.AsIntSlice("", "key2", ""); !ok || !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("TSectionList.AsIntSlice() = %v, %v", got, ok)
+	}
+	if got, ok := sl.AsFloat64Slice("", "key3", ""); !ok || !reflect.DeepEqual(got, []float64{0.5, 1000}) {
+		t.Errorf("TSectionList.AsFloat64Slice() = %v, %v", got, ok)
+	}
+} // TestTSectionList_AsStringSlice()
+
+func TestTSectionList_UpdateSectKeySlice(t *testing.T) {
+	sl := prepSectionList()
+	values := []string{"x", "y|z"}
+
+	if !sl.UpdateSectKeySlice("", "list", values, "|") {
+		t.Fatal("TSectionList.UpdateSectKeySlice() = false, want true")
+	}
+	if got, _ := sl.AsStringSlice("", "list", "|"); !reflect.DeepEqual(got, values) {
+		t.Errorf("TSectionList.UpdateSectKeySlice() read back %q, want %q",
+			got, values)
+	}
+} // TestTSectionList_UpdateSectKeySlice()
+
+/* _EoF_ */
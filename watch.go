@@ -0,0 +1,151 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `DefWatchInterval` is the default polling interval used by `Watch()`.
+	DefWatchInterval = 2 * time.Second
+)
+
+type (
+	// `tFileState` holds the file properties used to detect changes.
+	tFileState struct {
+		modTime time.Time
+		size    int64
+	}
+)
+
+// `fileState()` returns the current state of `aFilename`.
+//
+// Parameters:
+// - `aFilename` The name of the file to check.
+//
+// Returns:
+// - `tFileState`: The file's modification time and size.
+// - `error`: A possible error condition.
+func fileState(aFilename string) (tFileState, error) {
+	fi, err := os.Stat(aFilename)
+	if nil != err {
+		return tFileState{}, err
+	}
+
+	return tFileState{modTime: fi.ModTime(), size: fi.Size()}, nil
+} // fileState()
+
+// `reload()` returns a new list read from the current list's file
+// using the same settings as the current list.
+//
+// The current list is not modified.
+//
+// Returns:
+// - `*TSectionList`: The newly read list.
+// - `error`: A possible error condition.
+func (sl *TSectionList) reload() (*TSectionList, error) {
+	result := sl.copySettings(NewSectionList())
+	var err error
+	if 0 < len(sl.defaults) {
+		result, err = result.loadWithDefaults()
+	} else {
+		result, err = result.load()
+	}
+	result.readOnly = sl.readOnly
+
+	return result, err
+} // reload()
+
+// `Watch()` monitors the list's INI file and calls `aOnChange` with a
+// freshly read list whenever the file was modified.
+//
+// The file is polled every `DefWatchInterval`; see `WatchInterval()`
+// to use a different interval. A modified file is reloaded only once
+// it stayed unchanged for two polls in a row, so a file still being
+// written isn't read. The new list is read completely before it is
+// published, so `aOnChange` never sees a partially read configuration;
+// if the modified file can't be read or parsed the change is dropped
+// until the file is modified again.
+//
+// This method blocks until `aCtx` is cancelled; so it's usually
+// called in a goroutine of its own.
+//
+// Parameters:
+// - `aCtx` The context to stop watching.
+// - `aOnChange` The function to call with the reloaded list.
+//
+// Returns:
+// - `error`: `fs.ErrNotExist` if no filename is set, the error of the
+// initial file check, or the context's error once it's done.
+func (sl *TSectionList) Watch(aCtx context.Context, aOnChange func(*TSectionList)) error {
+	return sl.WatchInterval(aCtx, DefWatchInterval, aOnChange)
+} // Watch()
+
+// `WatchInterval()` monitors the list's INI file and calls `aOnChange`
+// with a freshly read list whenever the file was modified.
+//
+// See `Watch()` for details.
+//
+// Parameters:
+// - `aCtx` The context to stop watching.
+// - `aInterval` The polling interval (`DefWatchInterval` if not positive).
+// - `aOnChange` The function to call with the reloaded list.
+//
+// Returns:
+// - `error`: `fs.ErrNotExist` if no filename is set, the error of the
+// initial file check, or the context's error once it's done.
+func (sl *TSectionList) WatchInterval(aCtx context.Context, aInterval time.Duration, aOnChange func(*TSectionList)) error {
+	if "" == sl.fName {
+		return fs.ErrNotExist
+	}
+	if 0 >= aInterval {
+		aInterval = DefWatchInterval
+	}
+	lastState, err := fileState(sl.fName)
+	if nil != err {
+		return err
+	}
+
+	ticker := time.NewTicker(aInterval)
+	defer ticker.Stop()
+
+	var pending tFileState // the modified state seen last
+	current := sl
+	for {
+		select {
+		case <-aCtx.Done():
+			return aCtx.Err()
+
+		case <-ticker.C:
+			state, err := fileState(current.fName)
+			if (nil != err) || (state == lastState) {
+				continue
+			}
+			if state != pending {
+				pending = state // wait for the file to settle
+				continue
+			}
+			list, err := current.reload()
+			lastState = state
+			if nil != err {
+				continue // drop it until the next modification
+			}
+			current = list
+			if nil != aOnChange {
+				aOnChange(list)
+			}
+		}
+	}
+} // WatchInterval()
+
+/* _EoF_ */
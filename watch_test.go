@@ -0,0 +1,69 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_WatchInterval(t *testing.T) {
+	fName := filepath.Join(t.TempDir(), "watch.ini")
+	if err := os.WriteFile(fName, []byte("[s]\nkey = one\n"), 0644); nil != err {
+		t.Fatal(err)
+	}
+	sl, err := NewIni(fName)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan *TSectionList, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- sl.WatchInterval(ctx, 10*time.Millisecond, func(aList *TSectionList) {
+			changes <- aList
+		})
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(fName, []byte("[s]\nkey = two\n"), 0644); nil != err {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	_ = os.Chtimes(fName, later, later)
+
+	select {
+	case list := <-changes:
+		if got, _ := list.AsString("s", "key"); "two" != got {
+			t.Errorf("TSectionList.WatchInterval() reloaded %q, want %q", got, "two")
+		}
+		if got, _ := sl.AsString("s", "key"); "one" != got {
+			t.Errorf("TSectionList.WatchInterval() modified original: %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("TSectionList.WatchInterval() didn't report the change")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("TSectionList.WatchInterval() = %v, want %v", err, context.Canceled)
+	}
+
+	if err := NewSectionList().Watch(ctx, nil); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("TSectionList.Watch() = %v, want %v", err, os.ErrNotExist)
+	}
+} // TestTSectionList_WatchInterval()
+
+/* _EoF_ */
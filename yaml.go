@@ -0,0 +1,491 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+const (
+	// `YAMLOrderKey` is the top-level YAML key holding the list of
+	// section names in their INI order.
+	YAMLOrderKey = `__order__`
+)
+
+var (
+	// `ErrInvalidYAML` is returned if the YAML data can't be parsed
+	// or doesn't fit into the INI structure.
+	ErrInvalidYAML = errors.New("ini: invalid YAML data")
+
+	// match: a YAML scalar which can be written without quotes
+	isYAMLPlainRE = regexp.MustCompile(`^[A-Za-z0-9_./+-](?:[A-Za-z0-9_ ./+@-]*[A-Za-z0-9_./+@-])?$`)
+)
+
+type (
+	// `tYAMLLine` is a significant (i.e. non-blank) line of YAML data.
+	tYAMLLine struct {
+		indent int    // number of leading blanks
+		num    int    // line number (for error messages)
+		text   string // the line's text w/o indentation
+	}
+
+	// `tYAMLParser` is a minimal YAML reader for the two-level mapping
+	// written by `ToYAML()`.
+	tYAMLParser struct {
+		lines []tYAMLLine // the lines to parse
+		pos   int         // index of the current line
+	}
+)
+
+// `yamlScalar()` returns `aValue` as a plain or double-quoted YAML scalar.
+//
+// Parameters:
+// - `aValue` The value to convert.
+//
+// Returns:
+// - `string`: The YAML scalar.
+func yamlScalar(aValue string) string {
+	if isYAMLPlainRE.MatchString(aValue) && !strings.HasPrefix(aValue, "- ") {
+		return aValue
+	}
+
+	// TOML's basic strings are valid YAML double-quoted scalars
+	return tomlQuote(aValue)
+} // yamlScalar()
+
+// `yamlError()` returns an `ErrInvalidYAML` error mentioning `aLine`.
+//
+// Parameters:
+// - `aLine` The line number of the error.
+// - `aFormat` The format string of the error message.
+// - `aArgs` The arguments of the format string.
+//
+// Returns:
+// - `error`: The error wrapping `ErrInvalidYAML`.
+func yamlError(aLine int, aFormat string, aArgs ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidYAML, aLine,
+		fmt.Sprintf(aFormat, aArgs...))
+} // yamlError()
+
+// `yamlStripComment()` removes a trailing comment from `aText`.
+//
+// Parameters:
+// - `aText` The line's text.
+//
+// Returns:
+// - `string`: The text w/o comment and trailing whitespace.
+func yamlStripComment(aText string) string {
+	var quote byte
+	for i := 0; i < len(aText); i++ {
+		c := aText[i]
+		switch {
+		case 0 != quote:
+			if ('\\' == c) && ('"' == quote) {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case ('"' == c) || ('\'' == c):
+			if (0 == i) || strings.ContainsRune(" [,:", rune(aText[i-1])) {
+				quote = c
+			}
+		case '#' == c:
+			if (0 == i) || (' ' == aText[i-1]) || ('\t' == aText[i-1]) {
+				return strings.TrimRight(aText[:i], " \t")
+			}
+		}
+	}
+
+	return strings.TrimRight(aText, " \t")
+} // yamlStripComment()
+
+// `yamlUnquote()` returns the value of a (possibly quoted) YAML scalar.
+//
+// Parameters:
+// - `aText` The scalar's text.
+// - `aLine` The line number (for error messages).
+//
+// Returns:
+// - `string`: The scalar's value.
+// - `error`: A possible error condition.
+func yamlUnquote(aText string, aLine int) (string, error) {
+	aText = strings.TrimSpace(aText)
+	if "" == aText {
+		return "", nil
+	}
+	switch aText[0] {
+	case '"':
+		tp := &tTOMLParser{src: aText, line: aLine}
+		result, err := tp.readString()
+		if (nil != err) || !tp.eof() {
+			return "", yamlError(aLine, "invalid double-quoted scalar")
+		}
+		return result, nil
+
+	case '\'':
+		if (2 > len(aText)) || ('\'' != aText[len(aText)-1]) {
+			return "", yamlError(aLine, "invalid single-quoted scalar")
+		}
+		return strings.ReplaceAll(aText[1:len(aText)-1], "''", "'"), nil
+	}
+
+	if ("~" == aText) || ("null" == aText) {
+		return "", nil
+	}
+
+	return aText, nil
+} // yamlUnquote()
+
+// `yamlSplitKey()` splits `aText` into a mapping's key and value.
+//
+// Parameters:
+// - `aText` The line's text.
+// - `aLine` The line number (for error messages).
+//
+// Returns:
+// - `string`: The key.
+// - `string`: The (unparsed) value; empty if there's none.
+// - `error`: A possible error condition.
+func yamlSplitKey(aText string, aLine int) (string, string, error) {
+	end := -1
+	if ('"' == aText[0]) || ('\'' == aText[0]) {
+		// find the closing quote:
+		for i := 1; i < len(aText); i++ {
+			if ('\\' == aText[i]) && ('"' == aText[0]) {
+				i++
+			} else if aText[i] == aText[0] {
+				end = i + 1
+				break
+			}
+		}
+		if (0 > end) || (end < len(aText) && (':' != aText[end])) {
+			return "", "", yamlError(aLine, "invalid key")
+		}
+	} else if idx := strings.Index(aText+" ", ": "); 0 < idx {
+		end = idx
+	}
+	if (0 > end) || (len(aText) <= end) {
+		return "", "", yamlError(aLine, "missing mapping key")
+	}
+
+	key, err := yamlUnquote(aText[:end], aLine)
+	if nil != err {
+		return "", "", err
+	}
+
+	return key, strings.TrimSpace(aText[end+1:]), nil
+} // yamlSplitKey()
+
+// --------------------------------------------------------------------------
+
+// `current()` returns the current line or `nil` if all lines are read.
+func (yp *tYAMLParser) current() *tYAMLLine {
+	if yp.pos >= len(yp.lines) {
+		return nil
+	}
+
+	return &yp.lines[yp.pos]
+} // current()
+
+// `readBlockScalar()` reads the lines of a literal (`|`) or folded
+// (`>`) block scalar more indented than `aIndent`.
+//
+// Parameters:
+// - `aStyle` The block scalar's indicator.
+// - `aIndent` The indentation of the block scalar's key.
+//
+// Returns:
+// - `string`: The block scalar's value.
+func (yp *tYAMLParser) readBlockScalar(aStyle string, aIndent int) string {
+	var parts []string
+	for line := yp.current(); (nil != line) && (line.indent > aIndent); line = yp.current() {
+		parts = append(parts, line.text)
+		yp.pos++
+	}
+	if strings.HasPrefix(aStyle, ">") {
+		return strings.Join(parts, " ")
+	}
+
+	return strings.Join(parts, "\n")
+} // readBlockScalar()
+
+// `readValue()` reads the value of a mapping key whose (unparsed)
+// value is `aText`.
+//
+// Parameters:
+// - `aText` The text following the key.
+// - `aIndent` The indentation of the key.
+// - `aLine` The line number (for error messages).
+//
+// Returns:
+// - `string`: The value read.
+// - `error`: A possible error condition.
+func (yp *tYAMLParser) readValue(aText string, aIndent, aLine int) (string, error) {
+	switch {
+	case "" == aText:
+		// a block sequence may follow:
+		items, err := yp.readSequence(aIndent)
+		if nil != err {
+			return "", err
+		}
+		if nil == items {
+			return "", nil
+		}
+		return joinList(items, DefListSeparator), nil
+
+	case strings.HasPrefix(aText, "|") || strings.HasPrefix(aText, ">"):
+		return yp.readBlockScalar(aText, aIndent), nil
+
+	case strings.HasPrefix(aText, "["):
+		if !strings.HasSuffix(aText, "]") {
+			return "", yamlError(aLine, "multi-line flow sequences are not supported")
+		}
+		var items []string
+		for _, item := range splitList(aText[1:len(aText)-1], ",") {
+			val, err := yamlUnquote(item, aLine)
+			if nil != err {
+				return "", err
+			}
+			items = append(items, val)
+		}
+		return joinList(items, DefListSeparator), nil
+
+	case strings.HasPrefix(aText, "{"):
+		return "", yamlError(aLine, "flow mappings are not supported")
+	}
+
+	return yamlUnquote(aText, aLine)
+} // readValue()
+
+// `readSequence()` reads a block sequence of scalars at an indentation
+// of at least `aIndent`.
+//
+// Parameters:
+// - `aIndent` The indentation of the sequence's key.
+//
+// Returns:
+// - `[]string`: The sequence's items or `nil` if there's no sequence.
+// - `error`: A possible error condition.
+func (yp *tYAMLParser) readSequence(aIndent int) ([]string, error) {
+	var result []string
+	for line := yp.current(); nil != line; line = yp.current() {
+		if (line.indent < aIndent) ||
+			!(("-" == line.text) || strings.HasPrefix(line.text, "- ")) {
+			break
+		}
+		if (line.indent == aIndent) && (0 == aIndent) {
+			break // top-level sequences are not part of the mapping
+		}
+		item, err := yamlUnquote(strings.TrimPrefix(line.text, "-"), line.num)
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, item)
+		yp.pos++
+	}
+
+	return result, nil
+} // readSequence()
+
+// `parse()` reads all YAML data into `aList`.
+//
+// Parameters:
+// - `aList` The list to fill.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (yp *tYAMLParser) parse(aList *TSectionList) error {
+	var order []string
+	for line := yp.current(); nil != line; line = yp.current() {
+		if 0 != line.indent {
+			return yamlError(line.num, "unexpected indentation")
+		}
+		yp.pos++
+		name, text, err := yamlSplitKey(line.text, line.num)
+		if nil != err {
+			return err
+		}
+
+		if YAMLOrderKey == name {
+			if text, err = yp.readValue(text, 0, line.num); nil != err {
+				return err
+			}
+			order = splitList(text, DefListSeparator)
+			continue
+		}
+
+		if "{}" == text {
+			// an empty section
+			aList.addSection(name)
+			continue
+		}
+
+		next := yp.current()
+		if ("" != text) || (nil == next) || (0 == next.indent) {
+			// a key/value pair of the default section
+			value, err := yp.readValue(text, 0, line.num)
+			if nil != err {
+				return err
+			}
+			aList.AddSectionKey(aList.defSect, name, value)
+			continue
+		}
+
+		// a section with its key/value pairs:
+		if "" == name {
+			name = aList.defSect
+		}
+		aList.addSection(name)
+		indent := next.indent
+		for next = yp.current(); (nil != next) && (0 < next.indent); next = yp.current() {
+			if next.indent != indent {
+				return yamlError(next.num, "unexpected indentation")
+			}
+			yp.pos++
+			key, text, err := yamlSplitKey(next.text, next.num)
+			if nil != err {
+				return err
+			}
+			value, err := yp.readValue(text, indent, next.num)
+			if nil != err {
+				return err
+			}
+			aList.AddSectionKey(name, key, value)
+		}
+	}
+	aList.orderSections(order)
+
+	return nil
+} // parse()
+
+// `orderSections()` rearranges the list's sections according to
+// `aOrder`; sections not mentioned there follow in their current order.
+//
+// Parameters:
+// - `aOrder` The wanted order of section names.
+func (sl *TSectionList) orderSections(aOrder []string) {
+	if 0 == len(aOrder) {
+		return
+	}
+	defer sl.lock()()
+
+	result := make(tSectionOrder, 0, len(sl.secOrder))
+	seen := make(map[string]bool, len(sl.secOrder))
+	for _, name := range aOrder {
+		if _, ok := sl.sections[name]; ok && !seen[name] {
+			result = append(result, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sl.secOrder {
+		if !seen[name] {
+			result = append(result, name)
+		}
+	}
+	sl.secOrder = result
+	sl.InvalidateCache()
+} // orderSections()
+
+// --------------------------------------------------------------------------
+
+// `ToYAML()` writes the list as a two-level YAML mapping to `aWriter`.
+//
+// Each section becomes a top-level key mapping to the section's
+// key/value pairs. Since not all YAML tools preserve the order of
+// mapping keys, the section names are additionally written in their
+// INI order as a sequence under the `YAMLOrderKey`.
+//
+// Parameters:
+// - `aWriter` The writer to write the YAML data to.
+//
+// Returns:
+// - `error`: A possible error condition.
+func (sl *TSectionList) ToYAML(aWriter io.Writer) error {
+	var sb strings.Builder
+
+	sb.WriteString(YAMLOrderKey + ":\n")
+	sections := sl.OrderedSections()
+	for _, ns := range sections {
+		sb.WriteString("  - " + yamlScalar(ns.Name) + "\n")
+	}
+	for _, ns := range sections {
+		name, kl := ns.Name, ns.Section
+		kl.mtx.RLock()
+		if 0 == len(kl.data) {
+			sb.WriteString(yamlScalar(name) + ": {}\n")
+		} else {
+			sb.WriteString(yamlScalar(name) + ":\n")
+		}
+		for _, kv := range kl.data {
+			sb.WriteString("  " + yamlScalar(kv.Key) + ": " + yamlScalar(kv.Value) + "\n")
+		}
+		kl.mtx.RUnlock()
+	}
+
+	_, err := io.WriteString(aWriter, sb.String())
+
+	return err
+} // ToYAML()
+
+// `FromYAML()` returns a new list with the data read from `aReader`.
+//
+// The data is expected to be a two-level YAML mapping as written by
+// `ToYAML()`: top-level keys are section names mapping to the
+// section's key/value pairs, while top-level scalars are stored in
+// the default section. Sequences of scalars are stored as a list
+// (see `AsStringSlice()`). If the `YAMLOrderKey` is present, the
+// sections are arranged in the order given there.
+//
+// Parameters:
+// - `aReader` The reader to read the YAML data from.
+//
+// Returns:
+// - `*TSectionList`: The list read from `aReader`.
+// - `error`: A possible error condition.
+func FromYAML(aReader io.Reader) (*TSectionList, error) {
+	var (
+		num    int
+		parser tYAMLParser
+	)
+
+	scanner := bufio.NewScanner(aReader)
+	for scanner.Scan() {
+		num++
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, yamlError(num, "tabs are not allowed for indentation")
+		}
+		if ("---" == text) || ("..." == text) || strings.HasPrefix(text, "%") {
+			continue // document markers and directives
+		}
+		indent := len(raw) - len(text)
+		if text = yamlStripComment(text); "" == text {
+			continue
+		}
+		parser.lines = append(parser.lines, tYAMLLine{indent, num, text})
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, err
+	}
+
+	result := NewSectionList()
+	if err := parser.parse(result); nil != err {
+		return nil, err
+	}
+
+	return result, nil
+} // FromYAML()
+
+/* _EoF_ */
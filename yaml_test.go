@@ -0,0 +1,119 @@
+/*
+Copyright © 2019, 2024  M.Watermann, 10247 Berlin, Germany
+
+	   All rights reserved
+	EMail : <support@mwat.de>
+*/
+package ini
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+//lint:file-ignore ST1017 - I prefer Yoda conditions
+
+func TestTSectionList_ToYAML(t *testing.T) {
+	sl := NewSectionList()
+	_ = sl.AddSectionKey("zeta", "port", "80")
+	_ = sl.AddSectionKey("zeta", "title", `say: "hi"`)
+	_ = sl.AddSectionKey("alpha", "path", "/var/tmp")
+	sl.addSection("empty")
+
+	want := `__order__:
+  - zeta
+  - alpha
+  - empty
+zeta:
+  port: 80
+  title: "say: \"hi\""
+alpha:
+  path: /var/tmp
+empty: {}
+`
+	var sb strings.Builder
+	if err := sl.ToYAML(&sb); nil != err {
+		t.Fatalf("TSectionList.ToYAML() error = %v", err)
+	}
+	if got := sb.String(); want != got {
+		t.Errorf("TSectionList.ToYAML() =\n%s\nwant\n%s", got, want)
+	}
+
+	back, err := FromYAML(strings.NewReader(sb.String()))
+	if nil != err {
+		t.Fatalf("FromYAML() error = %v", err)
+	}
+	if !sl.CompareTo(back) {
+		t.Errorf("FromYAML() round trip mismatch:\n%s", back.String())
+	}
+	if got, _ := back.Sections(); !reflect.DeepEqual(got, []string{"zeta", "alpha", "empty"}) {
+		t.Errorf("FromYAML() section order = %q", got)
+	}
+} // TestTSectionList_ToYAML()
+
+func TestFromYAML(t *testing.T) {
+	type tWant struct {
+		section string
+		key     string
+		value   string
+	}
+	src := `---
+# a comment
+__order__: [second, first]
+title: 'It''s here'
+first:
+  name: plain text # trailing comment
+  hosts:
+    - a.example
+    - "b.example"
+  ports: [80, 443]
+  text: |
+    line one
+    line two
+  none: ~
+second:
+  "quoted key": "tab\there"
+`
+	tests := []tWant{
+		{"", "title", "It's here"},
+		{"first", "name", "plain text"},
+		{"first", "hosts", "a.example, b.example"},
+		{"first", "ports", "80, 443"},
+		{"first", "text", "line one\nline two"},
+		{"first", "none", ""},
+		{"second", "quoted key", "tab\there"},
+		// TODO: Add test cases.
+	}
+
+	sl, err := FromYAML(strings.NewReader(src))
+	if nil != err {
+		t.Fatalf("FromYAML() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := sl.AsString(tt.section, tt.key)
+			if !ok || (tt.value != got) {
+				t.Errorf("FromYAML() [%s] %s = %q, want %q",
+					tt.section, tt.key, got, tt.value)
+			}
+		})
+	}
+	if got, _ := sl.Sections(); !reflect.DeepEqual(got, []string{"second", "first", DefSection}) {
+		t.Errorf("FromYAML() section order = %q", got)
+	}
+
+	for _, bad := range []string{
+		"  indented: 1\n",
+		"section:\n  key: {a: 1}\n",
+		"section:\n  key: 1\n    deeper: 2\n",
+		"no mapping\n",
+	} {
+		if _, err := FromYAML(strings.NewReader(bad)); !errors.Is(err, ErrInvalidYAML) {
+			t.Errorf("FromYAML(%q) error = %v, want %v", bad, err, ErrInvalidYAML)
+		}
+	}
+} // TestFromYAML()
+
+/* _EoF_ */